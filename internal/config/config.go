@@ -51,7 +51,43 @@ type (
 	}
 
 	ServiceConfig struct {
-		LabsCIDR string `yaml:"labsCIDR" env:"LABS_CIDR" env-default:"128.0.0.0/8" env-description:"Labs subnet"`
+		LabsCIDR     string          `yaml:"labsCIDR" env:"LABS_CIDR" env-default:"128.0.0.0/8" env-description:"Labs subnet"`
+		LabsIPv6CIDR string          `yaml:"labsIPv6CIDR" env:"LABS_IPV6_CIDR" env-default:"" env-description:"Labs IPv6 subnet of the dual-stack cluster, empty value disables the IPv6 lab networks"`
+		DNSUpstreams []string        `yaml:"dnsUpstreams" env:"LAB_DNS_UPSTREAMS" env-default:"8.8.8.8,1.1.1.1" env-separator:"," env-description:"Upstream DNS server IP addresses of the lab DNS servers"`
+		VPN          VPNConfig       `yaml:"vpn"`
+		SSH          SSHConfig       `yaml:"ssh"`
+		Ingress      IngressConfig   `yaml:"ingress"`
+		AttackBox    AttackBoxConfig `yaml:"attackBox"`
+		Publish      PublishConfig   `yaml:"publish"`
+	}
+
+	PublishConfig struct {
+		ServiceType    string `yaml:"serviceType" env:"LAB_PUBLISH_SERVICE_TYPE" env-default:"NodePort" env-description:"Service type of the published instance TCP and UDP ports, NodePort or LoadBalancer"`
+		PortRangeStart int32  `yaml:"portRangeStart" env:"LAB_PUBLISH_PORT_RANGE_START" env-default:"30000" env-description:"First public port of the published instance ports, the NodePort range must be inside the node port range of the cluster"`
+		PortRangeEnd   int32  `yaml:"portRangeEnd" env:"LAB_PUBLISH_PORT_RANGE_END" env-default:"32767" env-description:"Last public port of the published instance ports"`
+		Host           string `yaml:"host" env:"LAB_PUBLISH_HOST" env-default:"" env-description:"Public host of the cluster nodes in the addresses of the NodePort published ports, empty value disables the NodePort published ports"`
+	}
+
+	IngressConfig struct {
+		BaseDomain string `yaml:"baseDomain" env:"LAB_INGRESS_BASE_DOMAIN" env-default:"" env-description:"Base domain of the lab hostnames, empty value disables the lab ingresses"`
+	}
+
+	AttackBoxConfig struct {
+		Image  string `yaml:"image" env:"LAB_ATTACK_BOX_IMAGE" env-default:"linuxserver/kali-linux:latest" env-description:"Default image of the lab attack boxes with the web desktop or terminal"`
+		Port   int32  `yaml:"port" env:"LAB_ATTACK_BOX_PORT" env-default:"3000" env-description:"Default web port of the lab attack boxes"`
+		CPU    int64  `yaml:"cpu" env:"LAB_ATTACK_BOX_CPU" env-default:"1000" env-description:"Default CPU limit in millicores of the lab attack boxes"`
+		Memory int64  `yaml:"memory" env:"LAB_ATTACK_BOX_MEMORY" env-default:"2147483648" env-description:"Default memory limit in bytes of the lab attack boxes"`
+	}
+
+	VPNConfig struct {
+		ClientsCIDR  string `yaml:"clientsCIDR" env:"LAB_VPN_CLIENTS_CIDR" env-default:"10.255.0.0/24" env-description:"Tunnel subnet of the lab VPN gateways, the first address is the gateway one and the others are given to the peers"`
+		EndpointHost string `yaml:"endpointHost" env:"LAB_VPN_ENDPOINT_HOST" env-default:"" env-description:"Public host of the cluster nodes in the client configs of the NodePort VPN gateways, empty value disables the NodePort gateways"`
+	}
+
+	SSHConfig struct {
+		RouterNamespace      string `yaml:"routerNamespace" env:"LAB_SSH_ROUTER_NAMESPACE" env-default:"" env-description:"Namespace of the shared SSH router, empty value disables the lab SSH bastions"`
+		RouterEndpoint       string `yaml:"routerEndpoint" env:"LAB_SSH_ROUTER_ENDPOINT" env-default:"" env-description:"Public host and port of the shared SSH router returned to the players"`
+		RouterServiceAccount string `yaml:"routerServiceAccount" env:"LAB_SSH_ROUTER_SERVICE_ACCOUNT" env-default:"ssh-router-sa" env-description:"Service account of the shared SSH router, it is allowed to read the bastion keys secret of each lab"`
 	}
 
 	RepositoryConfig struct {
@@ -63,8 +99,22 @@ type (
 	}
 
 	KubernetesConfig struct {
-		KubeConfigPath string `yaml:"kubeConfigPath" env:"KUBE_CONFIG_PATH" env-default:"" env-description:"Path to kubeconfig file"`
-		PodsCIDR       string // PodsCIDR is equal to LabsCIDR
+		KubeConfigPath string   `yaml:"kubeConfigPath" env:"KUBE_CONFIG_PATH" env-default:"" env-description:"Path to kubeconfig file"`
+		PodsCIDR       string   // PodsCIDR is equal to LabsCIDR
+		PodsIPv6CIDR   string   // PodsIPv6CIDR is equal to LabsIPv6CIDR
+		DNSUpstreams   []string // DNSUpstreams are equal to the lab DNS upstreams, the egress domains are resolved by them
+
+		DefaultContainerCPU              int64 `yaml:"defaultContainerCPU" env:"LAB_DEFAULT_CONTAINER_CPU" env-default:"100" env-description:"Default CPU limit in millicores for lab containers without resources"`
+		DefaultContainerMemory           int64 `yaml:"defaultContainerMemory" env:"LAB_DEFAULT_CONTAINER_MEMORY" env-default:"134217728" env-description:"Default memory limit in bytes for lab containers without resources"`
+		DefaultContainerEphemeralStorage int64 `yaml:"defaultContainerEphemeralStorage" env:"LAB_DEFAULT_CONTAINER_EPHEMERAL_STORAGE" env-default:"1073741824" env-description:"Default ephemeral storage limit in bytes for lab containers without resources in labs with an ephemeral storage quota"`
+
+		AdminNamespace string `yaml:"adminNamespace" env:"LAB_ADMIN_NAMESPACE" env-default:"" env-description:"Namespace allowed to reach the labs in the platform isolation mode"`
+
+		EgressDomainsRefresh time.Duration `yaml:"egressDomainsRefresh" env:"LAB_EGRESS_DOMAINS_REFRESH" env-default:"5m" env-description:"Interval of the new resolution of the lab egress domains by the lab DNS upstreams, zero value disables it"`
+
+		IngressClassName string `yaml:"ingressClassName" env:"LAB_INGRESS_CLASS" env-default:"nginx" env-description:"Ingress class of the lab ingresses, the basic auth of the ingresses requires the ingress-nginx controller"`
+		IngressNamespace string `yaml:"ingressNamespace" env:"LAB_INGRESS_NAMESPACE" env-default:"ingress-nginx" env-description:"Namespace of the ingress controller allowed to reach the lab web ports"`
+		IngressTLSSecret string `yaml:"ingressTLSSecret" env:"LAB_INGRESS_TLS_SECRET" env-default:"" env-description:"Wildcard TLS secret of the lab hostnames in the namespace/name format, it is copied to the lab namespaces. Empty value means the default certificate of the ingress controller"`
 	}
 
 	// PostgresConfig is the configuration for the Postgres database
@@ -122,4 +172,6 @@ func MustGetConfig() *Config {
 
 func (c *Config) populateForAllConfig() {
 	c.Infrastructure.Kubernetes.PodsCIDR = c.Service.LabsCIDR
+	c.Infrastructure.Kubernetes.PodsIPv6CIDR = c.Service.LabsIPv6CIDR
+	c.Infrastructure.Kubernetes.DNSUpstreams = c.Service.DNSUpstreams
 }
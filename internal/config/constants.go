@@ -13,12 +13,46 @@ const (
 	LabIDLabel       = "labID"
 	ChallengeIDLabel = "challengeID"
 	InstanceIDLabel  = "instanceID"
+	// EgressProfileLabel marks the pods with their own egress policy, the lab egress policy skips them
+	EgressProfileLabel = "egressProfile"
+	SegmentLabel       = "segment"
+	// SegmentedLabel marks the pods joined to the lab segments
+	SegmentedLabel = "segmented"
+	// IngressRulesLabel and EgressRulesLabel mark the instances with their own network rules, the lab policies skip them
+	IngressRulesLabel = "ingressRules"
+	EgressRulesLabel  = "egressRules"
+	// ServiceLabel marks the instances with their own service, the service name is resolved by the lab DNS server
+	ServiceLabel = "service"
+	// PrivilegedLabel marks the instances requiring the privileged permission of the lab group
+	PrivilegedLabel = "privileged"
 
-	Lab          = "lab"
-	LabNetwork   = "labNetwork"
-	LabDNSServer = "labDNSServer"
-	LabDNSConfig = "labDNSConfig"
-	Challenge    = "challenge"
+	Lab              = "lab"
+	LabNetwork       = "labNetwork"
+	LabSegment       = "labSegment"
+	LabIPv6          = "labIPv6Network"
+	LabDNSServer     = "labDNSServer"
+	LabDNSConfig     = "labDNSConfig"
+	LabQuota         = "labQuota"
+	LabVPN           = "labVPNGateway"
+	LabVPNConfig     = "labVPNConfig"
+	LabSSH           = "labSSHBastion"
+	LabSSHKeys       = "labSSHBastionKeys"
+	LabSSHPipe       = "labSSHPipe"
+	LabSSHAccess     = "labSSHRouterAccess"
+	LabAttackBox     = "labAttackBox"
+	LabWebAuth       = "labWebAuth"
+	LabIngressTLS    = "labIngressTLS"
+	LabEgressDomains = "labEgressDomains"
+	Challenge        = "challenge"
+	ChallengeFlag    = "challengeFlag"
+
+	InstanceEgress   = "instance"
+	DNSServerEgress  = "dnsServer"
+	VPNGatewayEgress = "vpnGateway"
+	InstanceRules    = "instance"
+	ClusterIPService = "clusterIP"
+	HeadlessService  = "headless"
+	PrivilegedTrue   = "true"
 
 	RecordsListLabel = "recordsList"
 )
@@ -8,57 +8,16 @@ import (
 )
 
 type IChallengeUseCase interface {
-	AddLabsChallenges(ctx context.Context, labsGroupID string, labIDs []string, configs []model.ChallengeConfig, flagsEnvVars map[string]map[string]map[string]model.EnvConfig) error
-	StartLabsChallenges(ctx context.Context, labsGroupID string, labIDs, challengeIDs []string) error
-	StopLabsChallenges(ctx context.Context, labsGroupID string, labIDs, challengeIDs []string) error
-	ResetLabsChallenges(ctx context.Context, labsGroupID string, labIDs, challengeIDs []string) error
-	DeleteLabsChallenges(ctx context.Context, labsGroupID string, labIDs, challengeIDs []string) error
+	AddLabsChallenges(ctx context.Context, labsGroupID string, labIDs []string, selector string, configs []model.ChallengeConfig, flagsEnvVars map[string]map[string]map[string]model.EnvConfig) error
+	StartLabsChallenges(ctx context.Context, labsGroupID string, labIDs []string, selector string, challengeIDs []string) error
+	StopLabsChallenges(ctx context.Context, labsGroupID string, labIDs []string, selector string, challengeIDs []string) error
+	ResetLabsChallenges(ctx context.Context, labsGroupID string, labIDs []string, selector string, challengeIDs []string) error
+	DeleteLabsChallenges(ctx context.Context, labsGroupID string, labIDs []string, selector string, challengeIDs []string) error
 }
 
 func (a *Agent) AddLabsChallenges(ctx context.Context, request *protobuf.AddLabsChallengesRequest) (*protobuf.EmptyResponse, error) {
-	challengesConfigs := make([]model.ChallengeConfig, 0)
-
-	for _, chConfig := range request.GetChallenges() {
-		instances := make([]model.InstanceConfig, 0)
-
-		for _, inst := range chConfig.GetInstances() {
-			envs := make([]model.EnvConfig, 0)
-			for _, env := range inst.GetEnvs() {
-				envs = append(envs, model.EnvConfig{
-					Name:  env.GetName(),
-					Value: env.GetValue(),
-				})
-			}
+	challengesConfigs := toModelChallenges(request.GetChallenges())
 
-			records := make([]model.DNSRecordConfig, 0)
-			for _, record := range inst.GetRecords() {
-				records = append(records, model.DNSRecordConfig{
-					Type: record.GetType(),
-					Name: record.GetName(),
-					Data: record.GetData(),
-				})
-			}
-
-			instances = append(instances, model.InstanceConfig{
-				ID:    inst.GetID(),
-				Image: inst.GetImage(),
-				Resources: model.ResourcesConfig{
-					Requests: model.ResourceConfig{
-						Memory: inst.GetResources().GetMemory(),
-						CPU:    inst.GetResources().GetCPU(),
-					},
-					Limit: model.ResourceConfig{
-						Memory: inst.GetResources().GetMemory(),
-						CPU:    inst.GetResources().GetCPU(),
-					},
-				},
-				Envs:    envs,
-				Records: records,
-			})
-		}
-
-		challengesConfigs = append(challengesConfigs, model.ChallengeConfig{ID: chConfig.GetID(), Instances: instances})
-	}
 	// map[labID]map[challengeID]map[instanceID]model.EnvConfig
 	flagEnvVariables := make(map[string]map[string]map[string]model.EnvConfig)
 
@@ -75,7 +34,7 @@ func (a *Agent) AddLabsChallenges(ctx context.Context, request *protobuf.AddLabs
 		}
 	}
 
-	if err := a.useCase.AddLabsChallenges(ctx, request.GetLabsGroupID(), request.GetLabIDs(), challengesConfigs, flagEnvVariables); err != nil {
+	if err := a.useCase.AddLabsChallenges(ctx, request.GetLabsGroupID(), request.GetLabIDs(), request.GetSelector(), challengesConfigs, flagEnvVariables); err != nil {
 		log.Error().Err(err).Msg("Failed to add lab challenges")
 		return nil, err
 	}
@@ -84,7 +43,7 @@ func (a *Agent) AddLabsChallenges(ctx context.Context, request *protobuf.AddLabs
 }
 
 func (a *Agent) DeleteLabsChallenges(ctx context.Context, request *protobuf.LabsChallengesRequest) (*protobuf.EmptyResponse, error) {
-	if err := a.useCase.DeleteLabsChallenges(ctx, request.GetLabsGroupID(), request.GetLabIDs(), request.GetChallengeIDs()); err != nil {
+	if err := a.useCase.DeleteLabsChallenges(ctx, request.GetLabsGroupID(), request.GetLabIDs(), request.GetSelector(), request.GetChallengeIDs()); err != nil {
 		log.Error().Err(err).Msg("Failed to delete lab challenges")
 		return nil, err
 	}
@@ -93,7 +52,7 @@ func (a *Agent) DeleteLabsChallenges(ctx context.Context, request *protobuf.Labs
 }
 
 func (a *Agent) StartLabsChallenges(ctx context.Context, request *protobuf.LabsChallengesRequest) (*protobuf.EmptyResponse, error) {
-	if err := a.useCase.StartLabsChallenges(ctx, request.GetLabsGroupID(), request.GetLabIDs(), request.GetChallengeIDs()); err != nil {
+	if err := a.useCase.StartLabsChallenges(ctx, request.GetLabsGroupID(), request.GetLabIDs(), request.GetSelector(), request.GetChallengeIDs()); err != nil {
 		log.Error().Err(err).Msg("Failed to start lab challenges")
 		return nil, err
 	}
@@ -102,7 +61,7 @@ func (a *Agent) StartLabsChallenges(ctx context.Context, request *protobuf.LabsC
 }
 
 func (a *Agent) StopLabsChallenges(ctx context.Context, request *protobuf.LabsChallengesRequest) (*protobuf.EmptyResponse, error) {
-	if err := a.useCase.StopLabsChallenges(ctx, request.GetLabsGroupID(), request.GetLabIDs(), request.GetChallengeIDs()); err != nil {
+	if err := a.useCase.StopLabsChallenges(ctx, request.GetLabsGroupID(), request.GetLabIDs(), request.GetSelector(), request.GetChallengeIDs()); err != nil {
 		log.Error().Err(err).Msg("Failed to stop lab challenges")
 		return nil, err
 	}
@@ -111,10 +70,325 @@ func (a *Agent) StopLabsChallenges(ctx context.Context, request *protobuf.LabsCh
 }
 
 func (a *Agent) ResetLabsChallenges(ctx context.Context, request *protobuf.LabsChallengesRequest) (*protobuf.EmptyResponse, error) {
-	if err := a.useCase.ResetLabsChallenges(ctx, request.GetLabsGroupID(), request.GetLabIDs(), request.GetChallengeIDs()); err != nil {
+	if err := a.useCase.ResetLabsChallenges(ctx, request.GetLabsGroupID(), request.GetLabIDs(), request.GetSelector(), request.GetChallengeIDs()); err != nil {
 		log.Error().Err(err).Msg("Failed to reset lab challenges")
 		return nil, err
 	}
 
 	return &protobuf.EmptyResponse{}, nil
 }
+
+func toModelChallenges(challenges []*protobuf.Challenge) []model.ChallengeConfig {
+	challengesConfigs := make([]model.ChallengeConfig, 0)
+
+	for _, chConfig := range challenges {
+		instances := make([]model.InstanceConfig, 0)
+
+		for _, inst := range chConfig.GetInstances() {
+			envs := make([]model.EnvConfig, 0)
+			for _, env := range inst.GetEnvs() {
+				envs = append(envs, model.EnvConfig{
+					Name:  env.GetName(),
+					Value: env.GetValue(),
+				})
+			}
+
+			records := make([]model.DNSRecordConfig, 0)
+			for _, record := range inst.GetRecords() {
+				records = append(records, model.DNSRecordConfig{
+					Type: record.GetType(),
+					Name: record.GetName(),
+					Data: record.GetData(),
+				})
+			}
+
+			instances = append(instances, model.InstanceConfig{
+				ID:    inst.GetID(),
+				Image: inst.GetImage(),
+				Resources: model.ResourcesConfig{
+					Requests: toModelResources(inst.GetRequests()),
+					Limit:    toModelResources(inst.GetResources()),
+				},
+				Envs:           envs,
+				Records:        records,
+				Egress:         toModelEgressProfile(inst.GetEgress()),
+				Segments:       inst.GetSegments(),
+				AllowFrom:      toModelNetworkRules(inst.GetAllowFrom()),
+				AllowTo:        toModelNetworkRules(inst.GetAllowTo()),
+				IP:             inst.GetIP(),
+				IPOffset:       inst.GetIPOffset(),
+				HTTPPorts:      inst.GetHTTPPorts(),
+				PublishedPorts: toModelPorts(inst.GetPublishedPorts()),
+				Service:        toModelInstanceService(inst.GetService()),
+				Privileged:     inst.GetPrivileged(),
+				CapAdds:        inst.GetCapAdds(),
+				ReadinessProbe: toModelProbe(inst.GetReadinessProbe()),
+				LivenessProbe:  toModelProbe(inst.GetLivenessProbe()),
+				Containers:     toModelContainers(inst.GetContainers()),
+				InitContainers: toModelContainers(inst.GetInitContainers()),
+				Command:        inst.GetCommand(),
+				Args:           inst.GetArgs(),
+				WorkingDir:     inst.GetWorkingDir(),
+				ContainerPorts: toModelPorts(inst.GetContainerPorts()),
+				RunAs:          toModelRunAs(inst.GetRunAs()),
+				Hostname:       inst.GetHostname(),
+			})
+		}
+
+		challengesConfigs = append(challengesConfigs, model.ChallengeConfig{ID: chConfig.GetID(), Instances: instances})
+	}
+
+	return challengesConfigs
+}
+
+func toProtobufChallenges(challenges []model.ChallengeConfig) []*protobuf.Challenge {
+	convChallenges := make([]*protobuf.Challenge, 0, len(challenges))
+
+	for _, chConfig := range challenges {
+		instances := make([]*protobuf.Instance, 0, len(chConfig.Instances))
+
+		for _, inst := range chConfig.Instances {
+			envs := make([]*protobuf.EnvVariable, 0, len(inst.Envs))
+			for _, env := range inst.Envs {
+				envs = append(envs, &protobuf.EnvVariable{
+					Name:  env.Name,
+					Value: env.Value,
+				})
+			}
+
+			records := make([]*protobuf.DNSRecord, 0, len(inst.Records))
+			for _, record := range inst.Records {
+				records = append(records, &protobuf.DNSRecord{
+					Type: record.Type,
+					Name: record.Name,
+					Data: record.Data,
+				})
+			}
+
+			instances = append(instances, &protobuf.Instance{
+				ID:             inst.ID,
+				Image:          inst.Image,
+				Resources:      toProtobufResources(inst.Resources.Limit),
+				Requests:       toProtobufRequests(inst.Resources.Requests),
+				Envs:           envs,
+				Records:        records,
+				Egress:         toProtobufEgressProfile(inst.Egress),
+				Segments:       inst.Segments,
+				AllowFrom:      toProtobufNetworkRules(inst.AllowFrom),
+				AllowTo:        toProtobufNetworkRules(inst.AllowTo),
+				IP:             inst.IP,
+				IPOffset:       inst.IPOffset,
+				HTTPPorts:      inst.HTTPPorts,
+				PublishedPorts: toProtobufPorts(inst.PublishedPorts),
+				Service:        toProtobufInstanceService(inst.Service),
+				Privileged:     inst.Privileged,
+				CapAdds:        inst.CapAdds,
+				ReadinessProbe: toProtobufProbe(inst.ReadinessProbe),
+				LivenessProbe:  toProtobufProbe(inst.LivenessProbe),
+				Containers:     toProtobufContainers(inst.Containers),
+				InitContainers: toProtobufContainers(inst.InitContainers),
+				Command:        inst.Command,
+				Args:           inst.Args,
+				WorkingDir:     inst.WorkingDir,
+				ContainerPorts: toProtobufPorts(inst.ContainerPorts),
+				RunAs:          toProtobufRunAs(inst.RunAs),
+				Hostname:       inst.Hostname,
+			})
+		}
+
+		convChallenges = append(convChallenges, &protobuf.Challenge{ID: chConfig.ID, Instances: instances})
+	}
+
+	return convChallenges
+}
+
+func toModelNetworkRules(rules []*protobuf.NetworkRule) []model.NetworkRule {
+	convRules := make([]model.NetworkRule, 0, len(rules))
+	for _, rule := range rules {
+		convRules = append(convRules, model.NetworkRule{
+			InstanceIDs: rule.GetInstanceIDs(),
+			Ports:       toModelPorts(rule.GetPorts()),
+		})
+	}
+	return convRules
+}
+
+func toProtobufNetworkRules(rules []model.NetworkRule) []*protobuf.NetworkRule {
+	convRules := make([]*protobuf.NetworkRule, 0, len(rules))
+	for _, rule := range rules {
+		convRules = append(convRules, &protobuf.NetworkRule{
+			InstanceIDs: rule.InstanceIDs,
+			Ports:       toProtobufPorts(rule.Ports),
+		})
+	}
+	return convRules
+}
+
+func toModelPorts(ports []*protobuf.Port) []model.PortConfig {
+	convPorts := make([]model.PortConfig, 0, len(ports))
+	for _, port := range ports {
+		convPorts = append(convPorts, model.PortConfig{
+			Port:     port.GetPort(),
+			Protocol: port.GetProtocol(),
+		})
+	}
+	return convPorts
+}
+
+func toProtobufPorts(ports []model.PortConfig) []*protobuf.Port {
+	convPorts := make([]*protobuf.Port, 0, len(ports))
+	for _, port := range ports {
+		convPorts = append(convPorts, &protobuf.Port{
+			Port:     port.Port,
+			Protocol: port.Protocol,
+		})
+	}
+	return convPorts
+}
+
+// toModelInstanceService returns nil config for the instance without its own service
+func toModelInstanceService(service *protobuf.InstanceService) *model.InstanceServiceConfig {
+	if service == nil {
+		return nil
+	}
+	return &model.InstanceServiceConfig{
+		Headless: service.GetHeadless(),
+		Ports:    toModelPorts(service.GetPorts()),
+	}
+}
+
+func toProtobufInstanceService(service *model.InstanceServiceConfig) *protobuf.InstanceService {
+	if service == nil {
+		return nil
+	}
+	return &protobuf.InstanceService{
+		Headless: service.Headless,
+		Ports:    toProtobufPorts(service.Ports),
+	}
+}
+
+// toModelProbe returns nil probe for the instance without the probe
+func toModelProbe(probe *protobuf.Probe) *model.Probe {
+	if probe == nil {
+		return nil
+	}
+	return &model.Probe{
+		Cmd:                 probe.GetCommand(),
+		HTTPPath:            probe.GetHTTPPath(),
+		HTTPPort:            probe.GetHTTPPort(),
+		TCPPort:             probe.GetTCPPort(),
+		InitialDelaySeconds: probe.GetInitialDelaySeconds(),
+		PeriodSeconds:       probe.GetPeriodSeconds(),
+		FailureThreshold:    probe.GetFailureThreshold(),
+	}
+}
+
+func toProtobufProbe(probe *model.Probe) *protobuf.Probe {
+	if probe == nil {
+		return nil
+	}
+	return &protobuf.Probe{
+		Command:             probe.Cmd,
+		HTTPPath:            probe.HTTPPath,
+		HTTPPort:            probe.HTTPPort,
+		TCPPort:             probe.TCPPort,
+		InitialDelaySeconds: probe.InitialDelaySeconds,
+		PeriodSeconds:       probe.PeriodSeconds,
+		FailureThreshold:    probe.FailureThreshold,
+	}
+}
+
+func toModelContainers(containers []*protobuf.Container) []model.ContainerConfig {
+	convContainers := make([]model.ContainerConfig, 0, len(containers))
+	for _, c := range containers {
+		envs := make([]model.EnvConfig, 0, len(c.GetEnvs()))
+		for _, env := range c.GetEnvs() {
+			envs = append(envs, model.EnvConfig{
+				Name:  env.GetName(),
+				Value: env.GetValue(),
+			})
+		}
+
+		convContainers = append(convContainers, model.ContainerConfig{
+			Name:  c.GetName(),
+			Image: c.GetImage(),
+			Envs:  envs,
+			Resources: model.ResourcesConfig{
+				Requests: toModelResources(c.GetRequests()),
+				Limit:    toModelResources(c.GetResources()),
+			},
+			Command: c.GetCommand(),
+			Args:    c.GetArgs(),
+		})
+	}
+	return convContainers
+}
+
+func toProtobufContainers(containers []model.ContainerConfig) []*protobuf.Container {
+	convContainers := make([]*protobuf.Container, 0, len(containers))
+	for _, c := range containers {
+		envs := make([]*protobuf.EnvVariable, 0, len(c.Envs))
+		for _, env := range c.Envs {
+			envs = append(envs, &protobuf.EnvVariable{
+				Name:  env.Name,
+				Value: env.Value,
+			})
+		}
+
+		convContainers = append(convContainers, &protobuf.Container{
+			Name:      c.Name,
+			Image:     c.Image,
+			Envs:      envs,
+			Resources: toProtobufResources(c.Resources.Limit),
+			Requests:  toProtobufRequests(c.Resources.Requests),
+			Command:   c.Command,
+			Args:      c.Args,
+		})
+	}
+	return convContainers
+}
+
+// toModelRunAs returns nil config for the instance running as the image user
+func toModelRunAs(runAs *protobuf.RunAs) *model.RunAsConfig {
+	if runAs == nil {
+		return nil
+	}
+	return &model.RunAsConfig{
+		UserID:  runAs.GetUserID(),
+		GroupID: runAs.GetGroupID(),
+	}
+}
+
+func toProtobufRunAs(runAs *model.RunAsConfig) *protobuf.RunAs {
+	if runAs == nil {
+		return nil
+	}
+	return &protobuf.RunAs{
+		UserID:  runAs.UserID,
+		GroupID: runAs.GroupID,
+	}
+}
+
+func toModelResources(resources *protobuf.Resources) model.ResourceConfig {
+	return model.ResourceConfig{
+		Memory:           resources.GetMemory(),
+		CPU:              resources.GetCPU(),
+		EphemeralStorage: resources.GetEphemeralStorage(),
+	}
+}
+
+func toProtobufResources(resources model.ResourceConfig) *protobuf.Resources {
+	return &protobuf.Resources{
+		Memory:           resources.Memory,
+		CPU:              resources.CPU,
+		EphemeralStorage: resources.EphemeralStorage,
+	}
+}
+
+// toProtobufRequests returns nil requests if they are not set
+func toProtobufRequests(requests model.ResourceConfig) *protobuf.Resources {
+	if requests == (model.ResourceConfig{}) {
+		return nil
+	}
+	return toProtobufResources(requests)
+}
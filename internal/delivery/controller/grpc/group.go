@@ -0,0 +1,153 @@
+package grpc
+
+import (
+	"context"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+	"github.com/cybericebox/agent/pkg/controller/grpc/protobuf"
+	"github.com/gofrs/uuid"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+type (
+	IGroupUseCase interface {
+		CreateLabsGroup(ctx context.Context, group model.LabsGroup) (*model.LabsGroup, error)
+		UpdateLabsGroup(ctx context.Context, group model.LabsGroup) (*model.LabsGroup, error)
+		GetLabsGroups(ctx context.Context, groupIDs []string) ([]*model.LabsGroup, error)
+		DeleteLabsGroup(ctx context.Context, groupID string) error
+	}
+)
+
+func (a *Agent) CreateLabsGroup(ctx context.Context, request *protobuf.LabsGroupRequest) (*protobuf.LabsGroupResponse, error) {
+	group, err := toModelLabsGroup(request.GetGroup())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to convert labs group")
+		return nil, err
+	}
+
+	created, err := a.useCase.CreateLabsGroup(ctx, group)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create labs group")
+		return nil, err
+	}
+
+	return &protobuf.LabsGroupResponse{
+		Group: toProtobufLabsGroup(created),
+	}, nil
+}
+
+func (a *Agent) UpdateLabsGroup(ctx context.Context, request *protobuf.LabsGroupRequest) (*protobuf.LabsGroupResponse, error) {
+	group, err := toModelLabsGroup(request.GetGroup())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to convert labs group")
+		return nil, err
+	}
+
+	updated, err := a.useCase.UpdateLabsGroup(ctx, group)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to update labs group")
+		return nil, err
+	}
+
+	return &protobuf.LabsGroupResponse{
+		Group: toProtobufLabsGroup(updated),
+	}, nil
+}
+
+func (a *Agent) GetLabsGroups(ctx context.Context, request *protobuf.GetLabsGroupsRequest) (*protobuf.GetLabsGroupsResponse, error) {
+	groups, err := a.useCase.GetLabsGroups(ctx, request.GetIDs())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get labs groups")
+		return nil, err
+	}
+
+	convGroups := make([]*protobuf.LabsGroup, 0, len(groups))
+	for _, group := range groups {
+		convGroups = append(convGroups, toProtobufLabsGroup(group))
+	}
+
+	return &protobuf.GetLabsGroupsResponse{
+		Groups: convGroups,
+	}, nil
+}
+
+func (a *Agent) DeleteLabsGroup(ctx context.Context, request *protobuf.DeleteLabsGroupRequest) (*protobuf.EmptyResponse, error) {
+	if err := a.useCase.DeleteLabsGroup(ctx, request.GetID()); err != nil {
+		log.Error().Err(err).Msg("Failed to delete labs group")
+		return nil, err
+	}
+
+	return &protobuf.EmptyResponse{}, nil
+}
+
+func toModelLabsGroup(group *protobuf.LabsGroup) (model.LabsGroup, error) {
+	var groupID uuid.UUID
+	if group.GetID() != "" {
+		var err error
+		groupID, err = uuid.FromString(group.GetID())
+		if err != nil {
+			return model.LabsGroup{}, appError.ErrGRPC.WithError(err).WithMessage("Failed to parse labs group id").WithContext("groupID", group.GetID()).Err()
+		}
+	}
+
+	return model.LabsGroup{
+		ID:          groupID,
+		Name:        group.GetName(),
+		Description: group.GetDescription(),
+		StartsAt:    fromUnix(group.GetStartsAt()),
+		EndsAt:      fromUnix(group.GetEndsAt()),
+		CIDRMask:    group.GetCIDRMask(),
+		CIDRPool:    group.GetCIDRPool(),
+		Quota: model.ResourceQuotaConfig{
+			CPU:              group.GetQuota().GetCPU(),
+			Memory:           group.GetQuota().GetMemory(),
+			Pods:             group.GetQuota().GetPods(),
+			EphemeralStorage: group.GetQuota().GetEphemeralStorage(),
+		},
+		MaxLabs:               group.GetMaxLabs(),
+		Labels:                group.GetLabels(),
+		IsolationMode:         model.IsolationMode(group.GetIsolationMode()),
+		AllowPrivileged:       group.GetAllowPrivileged(),
+		CPUOvercommitRatio:    group.GetCPUOvercommitRatio(),
+		MemoryOvercommitRatio: group.GetMemoryOvercommitRatio(),
+	}, nil
+}
+
+func toProtobufLabsGroup(group *model.LabsGroup) *protobuf.LabsGroup {
+	return &protobuf.LabsGroup{
+		ID:          group.ID.String(),
+		Name:        group.Name,
+		Description: group.Description,
+		StartsAt:    toUnix(group.StartsAt),
+		EndsAt:      toUnix(group.EndsAt),
+		CIDRMask:    group.CIDRMask,
+		CIDRPool:    group.CIDRPool,
+		Quota: &protobuf.ResourceQuota{
+			CPU:              group.Quota.CPU,
+			Memory:           group.Quota.Memory,
+			Pods:             group.Quota.Pods,
+			EphemeralStorage: group.Quota.EphemeralStorage,
+		},
+		MaxLabs:               group.MaxLabs,
+		Labels:                group.Labels,
+		IsolationMode:         int32(group.IsolationMode),
+		AllowPrivileged:       group.AllowPrivileged,
+		CPUOvercommitRatio:    group.CPUOvercommitRatio,
+		MemoryOvercommitRatio: group.MemoryOvercommitRatio,
+	}
+}
+
+func fromUnix(seconds int64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
+
+func toUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
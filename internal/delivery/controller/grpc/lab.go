@@ -9,16 +9,28 @@ import (
 
 type (
 	ILabUseCase interface {
-		CreateLabs(ctx context.Context, labsGroupID string, subnetMask uint32, count int) ([]*model.Lab, error)
-		GetLabs(ctx context.Context, labsGroupID string, labIDs []string) ([]*model.Lab, error)
-		DeleteLabs(ctx context.Context, labsGroupID string, labIDs []string) error
-		StartLabs(ctx context.Context, labsGroupID string, labIDs []string) error
-		StopLabs(ctx context.Context, labsGroupID string, labIDs []string) error
+		CreateLabs(ctx context.Context, labsGroupID string, count int, labConfig model.LabConfig, cidrs []string, templateID string, flagEnvVariables map[int]map[string]map[string]model.EnvConfig) ([]*model.Lab, error)
+		GetLabs(ctx context.Context, labsGroupID string, labIDs []string, selector string) ([]*model.Lab, error)
+		UpdateLabMetadata(ctx context.Context, labID string, metadata map[string]string) error
+		MoveLabs(ctx context.Context, labsGroupID string, labIDs []string, selector, targetGroupID string) error
+		SetLabsIsolationMode(ctx context.Context, labsGroupID string, labIDs []string, selector string, mode model.IsolationMode) error
+		SetLabsEgressProfile(ctx context.Context, labsGroupID string, labIDs []string, selector string, profile model.EgressProfile) error
+		CheckLabsIPAM(ctx context.Context, labsGroupID string, labIDs []string, selector string, release bool) ([]*model.LabIPAMReport, error)
+		AddLabVPNPeer(ctx context.Context, labID, peerID string) (string, error)
+		RevokeLabVPNPeer(ctx context.Context, labID, peerID string) error
+		AddLabSSHKey(ctx context.Context, labID, playerID, publicKey string) (*model.SSHAccess, error)
+		RemoveLabSSHKey(ctx context.Context, labID, playerID, publicKey string) error
+		GetLabAttackBoxAccess(ctx context.Context, labID string) (*model.AttackBoxAccess, error)
+		StartLabsAttackBox(ctx context.Context, labsGroupID string, labIDs []string, selector string) error
+		StopLabsAttackBox(ctx context.Context, labsGroupID string, labIDs []string, selector string) error
+		DeleteLabs(ctx context.Context, labsGroupID string, labIDs []string, selector string) error
+		StartLabs(ctx context.Context, labsGroupID string, labIDs []string, selector string) error
+		StopLabs(ctx context.Context, labsGroupID string, labIDs []string, selector string) error
 	}
 )
 
 func (a *Agent) GetLabs(ctx context.Context, request *protobuf.LabsRequest) (*protobuf.GetLabsResponse, error) {
-	labs, err := a.useCase.GetLabs(ctx, request.GetLabsGroupID(), request.GetIDs())
+	labs, err := a.useCase.GetLabs(ctx, request.GetLabsGroupID(), request.GetIDs(), request.GetSelector())
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get labs")
 		return nil, err
@@ -26,10 +38,7 @@ func (a *Agent) GetLabs(ctx context.Context, request *protobuf.LabsRequest) (*pr
 
 	convLabs := make([]*protobuf.Lab, 0, len(labs))
 	for _, lab := range labs {
-		convLabs = append(convLabs, &protobuf.Lab{
-			ID:   lab.ID.String(),
-			CIDR: lab.CIDR.String(),
-		})
+		convLabs = append(convLabs, toProtobufLab(lab))
 	}
 
 	return &protobuf.GetLabsResponse{
@@ -38,7 +47,43 @@ func (a *Agent) GetLabs(ctx context.Context, request *protobuf.LabsRequest) (*pr
 }
 
 func (a *Agent) CreateLabs(ctx context.Context, request *protobuf.CreateLabsRequest) (*protobuf.CreateLabsResponse, error) {
-	labs, err := a.useCase.CreateLabs(ctx, request.GetLabsGroupID(), request.GetCIDRMask(), int(request.GetCount()))
+	// map[labIndex]map[challengeID]map[instanceID]model.EnvConfig
+	flagEnvVariables := make(map[int]map[string]map[string]model.EnvConfig)
+
+	for _, flagEnv := range request.GetFlagEnvVariables() {
+		labIndex := int(flagEnv.GetLabIndex())
+		if _, ok := flagEnvVariables[labIndex]; !ok {
+			flagEnvVariables[labIndex] = make(map[string]map[string]model.EnvConfig)
+		}
+		if _, ok := flagEnvVariables[labIndex][flagEnv.GetChallengeID()]; !ok {
+			flagEnvVariables[labIndex][flagEnv.GetChallengeID()] = make(map[string]model.EnvConfig)
+		}
+		flagEnvVariables[labIndex][flagEnv.GetChallengeID()][flagEnv.GetInstanceID()] = model.EnvConfig{
+			Name:  flagEnv.GetVariable(),
+			Value: flagEnv.GetFlag(),
+		}
+	}
+
+	labConfig := model.LabConfig{
+		CIDRMask:     request.GetCIDRMask(),
+		IPv6CIDRMask: request.GetIPv6CIDRMask(),
+		CIDRPool:     request.GetCIDRPool(),
+		Quota: model.ResourceQuotaConfig{
+			CPU:              request.GetQuota().GetCPU(),
+			Memory:           request.GetQuota().GetMemory(),
+			Pods:             request.GetQuota().GetPods(),
+			EphemeralStorage: request.GetQuota().GetEphemeralStorage(),
+		},
+		Metadata:      request.GetMetadata(),
+		IsolationMode: model.IsolationMode(request.GetIsolationMode()),
+		Egress:        toModelEgressProfile(request.GetEgress()),
+		Segments:      toModelSegments(request.GetSegments()),
+		VPNGateway:    model.VPNGateway(request.GetVPNGateway()),
+		SSHBastion:    request.GetSSHBastion(),
+		AttackBox:     toModelAttackBox(request.GetAttackBox()),
+	}
+
+	labs, err := a.useCase.CreateLabs(ctx, request.GetLabsGroupID(), int(request.GetCount()), labConfig, request.GetCIDRs(), request.GetTemplateID(), flagEnvVariables)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to create labs")
 		return nil, err
@@ -46,10 +91,7 @@ func (a *Agent) CreateLabs(ctx context.Context, request *protobuf.CreateLabsRequ
 
 	convLabs := make([]*protobuf.Lab, 0, len(labs))
 	for _, lab := range labs {
-		convLabs = append(convLabs, &protobuf.Lab{
-			ID:   lab.ID.String(),
-			CIDR: lab.CIDR.String(),
-		})
+		convLabs = append(convLabs, toProtobufLab(lab))
 	}
 
 	return &protobuf.CreateLabsResponse{
@@ -58,7 +100,7 @@ func (a *Agent) CreateLabs(ctx context.Context, request *protobuf.CreateLabsRequ
 }
 
 func (a *Agent) StartLabs(ctx context.Context, request *protobuf.LabsRequest) (*protobuf.EmptyResponse, error) {
-	if err := a.useCase.StartLabs(ctx, request.GetLabsGroupID(), request.GetIDs()); err != nil {
+	if err := a.useCase.StartLabs(ctx, request.GetLabsGroupID(), request.GetIDs(), request.GetSelector()); err != nil {
 		log.Error().Err(err).Msg("Failed to start labs")
 		return nil, err
 	}
@@ -67,7 +109,7 @@ func (a *Agent) StartLabs(ctx context.Context, request *protobuf.LabsRequest) (*
 }
 
 func (a *Agent) StopLabs(ctx context.Context, request *protobuf.LabsRequest) (*protobuf.EmptyResponse, error) {
-	if err := a.useCase.StopLabs(ctx, request.GetLabsGroupID(), request.GetIDs()); err != nil {
+	if err := a.useCase.StopLabs(ctx, request.GetLabsGroupID(), request.GetIDs(), request.GetSelector()); err != nil {
 		log.Error().Err(err).Msg("Failed to stop labs")
 		return nil, err
 	}
@@ -75,11 +117,270 @@ func (a *Agent) StopLabs(ctx context.Context, request *protobuf.LabsRequest) (*p
 	return &protobuf.EmptyResponse{}, nil
 }
 
+func (a *Agent) UpdateLabMetadata(ctx context.Context, request *protobuf.UpdateLabMetadataRequest) (*protobuf.EmptyResponse, error) {
+	if err := a.useCase.UpdateLabMetadata(ctx, request.GetLabID(), request.GetMetadata()); err != nil {
+		log.Error().Err(err).Msg("Failed to update lab metadata")
+		return nil, err
+	}
+
+	return &protobuf.EmptyResponse{}, nil
+}
+
+func (a *Agent) MoveLabs(ctx context.Context, request *protobuf.MoveLabsRequest) (*protobuf.EmptyResponse, error) {
+	if err := a.useCase.MoveLabs(ctx, request.GetLabsGroupID(), request.GetIDs(), request.GetSelector(), request.GetTargetLabsGroupID()); err != nil {
+		log.Error().Err(err).Msg("Failed to move labs")
+		return nil, err
+	}
+
+	return &protobuf.EmptyResponse{}, nil
+}
+
+func (a *Agent) SetLabsIsolationMode(ctx context.Context, request *protobuf.SetLabsIsolationModeRequest) (*protobuf.EmptyResponse, error) {
+	if err := a.useCase.SetLabsIsolationMode(ctx, request.GetLabsGroupID(), request.GetIDs(), request.GetSelector(), model.IsolationMode(request.GetIsolationMode())); err != nil {
+		log.Error().Err(err).Msg("Failed to set labs isolation mode")
+		return nil, err
+	}
+
+	return &protobuf.EmptyResponse{}, nil
+}
+
+func (a *Agent) SetLabsEgressProfile(ctx context.Context, request *protobuf.SetLabsEgressProfileRequest) (*protobuf.EmptyResponse, error) {
+	if err := a.useCase.SetLabsEgressProfile(ctx, request.GetLabsGroupID(), request.GetIDs(), request.GetSelector(), toModelEgressProfile(request.GetEgress())); err != nil {
+		log.Error().Err(err).Msg("Failed to set labs egress profile")
+		return nil, err
+	}
+
+	return &protobuf.EmptyResponse{}, nil
+}
+
+func (a *Agent) CheckLabsIPAM(ctx context.Context, request *protobuf.CheckLabsIPAMRequest) (*protobuf.CheckLabsIPAMResponse, error) {
+	reports, err := a.useCase.CheckLabsIPAM(ctx, request.GetLabsGroupID(), request.GetIDs(), request.GetSelector(), request.GetReleaseLeaked())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to check labs IPAM")
+		return nil, err
+	}
+
+	convReports := make([]*protobuf.LabIPAMReport, 0, len(reports))
+	for _, report := range reports {
+		convReport := &protobuf.LabIPAMReport{
+			ID:             report.ID.String(),
+			LeakedIPs:      report.LeakedIPs,
+			UnallocatedIPs: report.UnallocatedIPs,
+			Released:       report.Released,
+		}
+		for _, network := range report.Networks {
+			convReport.Networks = append(convReport.Networks, &protobuf.NetworkUsage{
+				CIDR:        network.CIDR.String(),
+				UsedIPs:     network.UsedIPs,
+				Utilization: network.Utilization,
+			})
+		}
+		convReports = append(convReports, convReport)
+	}
+
+	return &protobuf.CheckLabsIPAMResponse{
+		Labs: convReports,
+	}, nil
+}
+
+func (a *Agent) AddLabVPNPeer(ctx context.Context, request *protobuf.LabVPNPeerRequest) (*protobuf.LabVPNPeerResponse, error) {
+	clientConfig, err := a.useCase.AddLabVPNPeer(ctx, request.GetLabID(), request.GetPeerID())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to add lab VPN peer")
+		return nil, err
+	}
+
+	return &protobuf.LabVPNPeerResponse{
+		ClientConfig: clientConfig,
+	}, nil
+}
+
+func (a *Agent) RevokeLabVPNPeer(ctx context.Context, request *protobuf.LabVPNPeerRequest) (*protobuf.EmptyResponse, error) {
+	if err := a.useCase.RevokeLabVPNPeer(ctx, request.GetLabID(), request.GetPeerID()); err != nil {
+		log.Error().Err(err).Msg("Failed to revoke lab VPN peer")
+		return nil, err
+	}
+
+	return &protobuf.EmptyResponse{}, nil
+}
+
+func (a *Agent) AddLabSSHKey(ctx context.Context, request *protobuf.LabSSHKeyRequest) (*protobuf.LabSSHKeyResponse, error) {
+	access, err := a.useCase.AddLabSSHKey(ctx, request.GetLabID(), request.GetPlayerID(), request.GetPublicKey())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to add lab SSH key")
+		return nil, err
+	}
+
+	return &protobuf.LabSSHKeyResponse{
+		Username: access.Username,
+		Endpoint: access.Endpoint,
+	}, nil
+}
+
+func (a *Agent) RemoveLabSSHKey(ctx context.Context, request *protobuf.LabSSHKeyRequest) (*protobuf.EmptyResponse, error) {
+	if err := a.useCase.RemoveLabSSHKey(ctx, request.GetLabID(), request.GetPlayerID(), request.GetPublicKey()); err != nil {
+		log.Error().Err(err).Msg("Failed to remove lab SSH key")
+		return nil, err
+	}
+
+	return &protobuf.EmptyResponse{}, nil
+}
+
+func (a *Agent) GetLabAttackBoxAccess(ctx context.Context, request *protobuf.LabAttackBoxRequest) (*protobuf.LabAttackBoxAccessResponse, error) {
+	access, err := a.useCase.GetLabAttackBoxAccess(ctx, request.GetLabID())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get lab attack box access")
+		return nil, err
+	}
+
+	return &protobuf.LabAttackBoxAccessResponse{
+		URL:      access.URL,
+		Username: access.Username,
+		Password: access.Password,
+		Status:   int32(access.Status),
+	}, nil
+}
+
+func (a *Agent) StartLabsAttackBox(ctx context.Context, request *protobuf.LabsRequest) (*protobuf.EmptyResponse, error) {
+	if err := a.useCase.StartLabsAttackBox(ctx, request.GetLabsGroupID(), request.GetIDs(), request.GetSelector()); err != nil {
+		log.Error().Err(err).Msg("Failed to start labs attack boxes")
+		return nil, err
+	}
+
+	return &protobuf.EmptyResponse{}, nil
+}
+
+func (a *Agent) StopLabsAttackBox(ctx context.Context, request *protobuf.LabsRequest) (*protobuf.EmptyResponse, error) {
+	if err := a.useCase.StopLabsAttackBox(ctx, request.GetLabsGroupID(), request.GetIDs(), request.GetSelector()); err != nil {
+		log.Error().Err(err).Msg("Failed to stop labs attack boxes")
+		return nil, err
+	}
+
+	return &protobuf.EmptyResponse{}, nil
+}
+
 func (a *Agent) DeleteLabs(ctx context.Context, request *protobuf.LabsRequest) (*protobuf.EmptyResponse, error) {
-	if err := a.useCase.DeleteLabs(ctx, request.GetLabsGroupID(), request.GetIDs()); err != nil {
+	if err := a.useCase.DeleteLabs(ctx, request.GetLabsGroupID(), request.GetIDs(), request.GetSelector()); err != nil {
 		log.Error().Err(err).Msg("Failed to delete labs")
 		return nil, err
 	}
 
 	return &protobuf.EmptyResponse{}, nil
 }
+
+func toProtobufLab(lab *model.Lab) *protobuf.Lab {
+	convLab := &protobuf.Lab{
+		ID:             lab.ID.String(),
+		CIDR:           lab.CIDR.String(),
+		CIDRPool:       lab.CIDRPool,
+		Metadata:       lab.Metadata,
+		IsolationMode:  int32(lab.IsolationMode),
+		Egress:         toProtobufEgressProfile(lab.Egress),
+		Segments:       toProtobufSegments(lab.Segments),
+		VPNGateway:     int32(lab.VPNGateway),
+		SSHBastion:     lab.SSHBastion,
+		AttackBox:      toProtobufAttackBox(lab.AttackBox),
+		PublicURLs:     toProtobufPublicURLs(lab.PublicURLs),
+		PublishedPorts: toProtobufPublishedPorts(lab.PublishedPorts),
+	}
+	if !lab.GroupID.IsNil() {
+		convLab.GroupID = lab.GroupID.String()
+	}
+	if lab.IPv6CIDR.IsValid() {
+		convLab.IPv6CIDR = lab.IPv6CIDR.String()
+	}
+	return convLab
+}
+
+func toModelEgressProfile(profile *protobuf.EgressProfile) model.EgressProfile {
+	return model.EgressProfile{
+		Mode:    model.EgressMode(profile.GetMode()),
+		CIDRs:   profile.GetCIDRs(),
+		Domains: profile.GetDomains(),
+	}
+}
+
+func toProtobufEgressProfile(profile model.EgressProfile) *protobuf.EgressProfile {
+	return &protobuf.EgressProfile{
+		Mode:    int32(profile.Mode),
+		CIDRs:   profile.CIDRs,
+		Domains: profile.Domains,
+	}
+}
+
+// toModelAttackBox returns nil config for the lab without the attack box
+func toModelAttackBox(attackBox *protobuf.AttackBox) *model.AttackBoxConfig {
+	if attackBox == nil {
+		return nil
+	}
+	return &model.AttackBoxConfig{
+		Image: attackBox.GetImage(),
+		Port:  attackBox.GetPort(),
+		Resources: model.ResourcesConfig{
+			Requests: toModelResources(attackBox.GetResources()),
+			Limit:    toModelResources(attackBox.GetResources()),
+		},
+	}
+}
+
+func toProtobufAttackBox(attackBox *model.AttackBoxConfig) *protobuf.AttackBox {
+	if attackBox == nil {
+		return nil
+	}
+	return &protobuf.AttackBox{
+		Image:     attackBox.Image,
+		Port:      attackBox.Port,
+		Resources: toProtobufResources(attackBox.Resources.Limit),
+	}
+}
+
+func toProtobufPublicURLs(urls []model.PublicURL) []*protobuf.PublicURL {
+	convURLs := make([]*protobuf.PublicURL, 0, len(urls))
+	for _, url := range urls {
+		convURLs = append(convURLs, &protobuf.PublicURL{
+			ChallengeID: url.ChallengeID,
+			InstanceID:  url.InstanceID,
+			URL:         url.URL,
+		})
+	}
+	return convURLs
+}
+
+func toProtobufPublishedPorts(ports []model.PublishedPort) []*protobuf.PublishedPort {
+	convPorts := make([]*protobuf.PublishedPort, 0, len(ports))
+	for _, port := range ports {
+		convPorts = append(convPorts, &protobuf.PublishedPort{
+			ChallengeID: port.ChallengeID,
+			InstanceID:  port.InstanceID,
+			Port:        port.Port,
+			Protocol:    port.Protocol,
+			Address:     port.Address,
+		})
+	}
+	return convPorts
+}
+
+func toModelSegments(segments []*protobuf.LabSegment) []model.SegmentConfig {
+	convSegments := make([]model.SegmentConfig, 0, len(segments))
+	for _, segment := range segments {
+		convSegments = append(convSegments, model.SegmentConfig{
+			Name:      segment.GetName(),
+			CIDRMask:  segment.GetCIDRMask(),
+			AllowFrom: segment.GetAllowFrom(),
+		})
+	}
+	return convSegments
+}
+
+func toProtobufSegments(segments []model.Segment) []*protobuf.LabSegment {
+	convSegments := make([]*protobuf.LabSegment, 0, len(segments))
+	for _, segment := range segments {
+		convSegments = append(convSegments, &protobuf.LabSegment{
+			Name:      segment.Name,
+			CIDRMask:  uint32(segment.CIDR.Bits()),
+			CIDR:      segment.CIDR.String(),
+			AllowFrom: segment.AllowFrom,
+		})
+	}
+	return convSegments
+}
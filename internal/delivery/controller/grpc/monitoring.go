@@ -58,9 +58,28 @@ func (a *Agent) Monitoring(stream protobuf.Agent_MonitoringServer) error {
 						Memory: inst.Resources.Memory,
 						CPU:    inst.Resources.CPU,
 					},
+					URLs: inst.URLs,
 				})
 			}
 
+			var quota *protobuf.QuotaStatus
+			if lab.Quota != nil {
+				quota = &protobuf.QuotaStatus{
+					Hard: &protobuf.ResourceQuota{
+						CPU:              lab.Quota.Hard.CPU,
+						Memory:           lab.Quota.Hard.Memory,
+						Pods:             lab.Quota.Hard.Pods,
+						EphemeralStorage: lab.Quota.Hard.EphemeralStorage,
+					},
+					Used: &protobuf.ResourceQuota{
+						CPU:              lab.Quota.Used.CPU,
+						Memory:           lab.Quota.Used.Memory,
+						Pods:             lab.Quota.Used.Pods,
+						EphemeralStorage: lab.Quota.Used.EphemeralStorage,
+					},
+				}
+			}
+
 			convLabs = append(convLabs, &protobuf.LabStatus{
 				ID: lab.ID.String(),
 				DNS: &protobuf.DNSStatus{
@@ -71,6 +90,7 @@ func (a *Agent) Monitoring(stream protobuf.Agent_MonitoringServer) error {
 						CPU:    lab.DNS.Resources.CPU,
 					},
 				},
+				Quota:     quota,
 				Instances: instance,
 			})
 		}
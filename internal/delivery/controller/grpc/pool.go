@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+	"github.com/cybericebox/agent/pkg/controller/grpc/protobuf"
+	"github.com/rs/zerolog/log"
+	"net/netip"
+)
+
+type (
+	IPoolUseCase interface {
+		AddCIDRPool(ctx context.Context, pool model.CIDRPool) (*model.CIDRPool, error)
+		GetCIDRPools(ctx context.Context) ([]model.CIDRPool, error)
+		GetCIDRPoolsUsage(ctx context.Context) ([]model.CIDRPoolUsage, error)
+	}
+)
+
+func (a *Agent) AddCIDRPool(ctx context.Context, request *protobuf.AddCIDRPoolRequest) (*protobuf.CIDRPoolResponse, error) {
+	cidr, err := netip.ParsePrefix(request.GetCIDR())
+	if err != nil {
+		err = appError.ErrCIDRPoolInvalidCIDR.WithError(err).WithContext("cidr", request.GetCIDR()).Err()
+		log.Error().Err(err).Msg("Failed to parse CIDR pool cidr")
+		return nil, err
+	}
+
+	created, err := a.useCase.AddCIDRPool(ctx, model.CIDRPool{
+		Name: request.GetName(),
+		CIDR: cidr,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to add CIDR pool")
+		return nil, err
+	}
+
+	return &protobuf.CIDRPoolResponse{
+		Pool: toProtobufCIDRPool(*created),
+	}, nil
+}
+
+func (a *Agent) GetCIDRPools(ctx context.Context, _ *protobuf.EmptyRequest) (*protobuf.GetCIDRPoolsResponse, error) {
+	pools, err := a.useCase.GetCIDRPools(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get CIDR pools")
+		return nil, err
+	}
+
+	convPools := make([]*protobuf.CIDRPool, 0, len(pools))
+	for _, pool := range pools {
+		convPools = append(convPools, toProtobufCIDRPool(pool))
+	}
+
+	return &protobuf.GetCIDRPoolsResponse{
+		Pools: convPools,
+	}, nil
+}
+
+func (a *Agent) GetCIDRPoolsUsage(ctx context.Context, _ *protobuf.EmptyRequest) (*protobuf.GetCIDRPoolsUsageResponse, error) {
+	usages, err := a.useCase.GetCIDRPoolsUsage(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get CIDR pools usage")
+		return nil, err
+	}
+
+	convUsages := make([]*protobuf.CIDRPoolUsage, 0, len(usages))
+	for _, usage := range usages {
+		childCIDRs := make([]string, 0, len(usage.ChildCIDRs))
+		for _, cidr := range usage.ChildCIDRs {
+			childCIDRs = append(childCIDRs, cidr.String())
+		}
+
+		convUsages = append(convUsages, &protobuf.CIDRPoolUsage{
+			Pool:        toProtobufCIDRPool(usage.Pool),
+			ChildCIDRs:  childCIDRs,
+			Utilization: usage.Utilization,
+		})
+	}
+
+	return &protobuf.GetCIDRPoolsUsageResponse{
+		Pools: convUsages,
+	}, nil
+}
+
+func toProtobufCIDRPool(pool model.CIDRPool) *protobuf.CIDRPool {
+	return &protobuf.CIDRPool{
+		Name:      pool.Name,
+		CIDR:      pool.CIDR.String(),
+		CreatedAt: toUnix(pool.CreatedAt),
+	}
+}
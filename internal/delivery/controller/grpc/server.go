@@ -26,6 +26,9 @@ type (
 		IChallengeUseCase
 		ILabUseCase
 		IMonitoringUseCase
+		ITemplateUseCase
+		IGroupUseCase
+		IPoolUseCase
 	}
 
 	Dependencies struct {
@@ -0,0 +1,63 @@
+package grpc
+
+import (
+	"context"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/controller/grpc/protobuf"
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	ITemplateUseCase interface {
+		CreateTemplate(ctx context.Context, name string, challenges []model.ChallengeConfig) (*model.LabTemplate, error)
+		ListTemplates(ctx context.Context, name string) ([]*model.LabTemplate, error)
+		DeleteTemplate(ctx context.Context, templateID string) error
+	}
+)
+
+func (a *Agent) CreateTemplate(ctx context.Context, request *protobuf.CreateTemplateRequest) (*protobuf.CreateTemplateResponse, error) {
+	template, err := a.useCase.CreateTemplate(ctx, request.GetName(), toModelChallenges(request.GetChallenges()))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create template")
+		return nil, err
+	}
+
+	return &protobuf.CreateTemplateResponse{
+		Template: toProtobufTemplate(template),
+	}, nil
+}
+
+func (a *Agent) ListTemplates(ctx context.Context, request *protobuf.ListTemplatesRequest) (*protobuf.ListTemplatesResponse, error) {
+	templates, err := a.useCase.ListTemplates(ctx, request.GetName())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list templates")
+		return nil, err
+	}
+
+	convTemplates := make([]*protobuf.Template, 0, len(templates))
+	for _, template := range templates {
+		convTemplates = append(convTemplates, toProtobufTemplate(template))
+	}
+
+	return &protobuf.ListTemplatesResponse{
+		Templates: convTemplates,
+	}, nil
+}
+
+func (a *Agent) DeleteTemplate(ctx context.Context, request *protobuf.DeleteTemplateRequest) (*protobuf.EmptyResponse, error) {
+	if err := a.useCase.DeleteTemplate(ctx, request.GetID()); err != nil {
+		log.Error().Err(err).Msg("Failed to delete template")
+		return nil, err
+	}
+
+	return &protobuf.EmptyResponse{}, nil
+}
+
+func toProtobufTemplate(template *model.LabTemplate) *protobuf.Template {
+	return &protobuf.Template{
+		ID:         template.ID.String(),
+		Name:       template.Name,
+		Version:    uint32(template.Version),
+		Challenges: toProtobufChallenges(template.Challenges),
+	}
+}
@@ -6,14 +6,18 @@ import (
 	"github.com/cybericebox/agent/internal/model"
 	"github.com/cybericebox/agent/internal/tools"
 	"github.com/cybericebox/agent/pkg/appError"
+	appsV1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	v1 "k8s.io/client-go/applyconfigurations/apps/v1"
 	v13 "k8s.io/client-go/applyconfigurations/core/v1"
 	v12 "k8s.io/client-go/applyconfigurations/meta/v1"
+	"maps"
+	"slices"
 	"strings"
 )
 
@@ -30,6 +34,10 @@ func (k *Kubernetes) ApplyDeployment(ctx context.Context, cfg model.ApplyDeploym
 			volumes = append(volumes, v13.Volume().WithName(v.Name).
 				WithConfigMap(v13.ConfigMapVolumeSource().WithName(v.ConfigMapName)))
 		}
+		if v.SecretName != "" {
+			volumes = append(volumes, v13.Volume().WithName(v.Name).
+				WithSecret(v13.SecretVolumeSource().WithSecretName(v.SecretName)))
+		}
 		volumeMounts = append(volumeMounts, v13.VolumeMount().WithName(v.Name).WithMountPath(v.MountPath))
 	}
 	if len(volumeMounts) == 0 {
@@ -37,43 +45,78 @@ func (k *Kubernetes) ApplyDeployment(ctx context.Context, cfg model.ApplyDeploym
 		volumeMounts = nil
 	}
 
-	envVars := make([]*v13.EnvVarApplyConfiguration, 0)
-	for _, env := range cfg.Envs {
-		envVars = append(envVars, v13.EnvVar().WithName(env.Name).WithValue(env.Value))
+	envVars := envVariables(cfg.Envs)
+	for _, env := range cfg.SecretEnvs {
+		envVars = append(envVars, v13.EnvVar().WithName(env.Name).
+			WithValueFrom(v13.EnvVarSource().
+				WithSecretKeyRef(v13.SecretKeySelector().WithName(env.SecretName).WithKey(env.Key))))
 	}
 	if len(envVars) > 0 {
 		container = container.WithEnv(envVars...)
 	}
 
+	if len(cfg.Command) > 0 {
+		container = container.WithCommand(cfg.Command...)
+	}
+
 	if len(cfg.Args) > 0 {
 		container = container.WithArgs(cfg.Args...)
 	}
 
-	if (cfg.Resources.Limit.CPU != 0 && cfg.Resources.Limit.Memory != 0) || (cfg.Resources.Requests.CPU != 0 && cfg.Resources.Requests.Memory != 0) {
-		r := v13.ResourceRequirements()
-		if cfg.Resources.Limit.CPU != 0 {
-			cpu := resource.NewMilliQuantity(cfg.Resources.Limit.CPU, resource.DecimalExponent)
-			memory := resource.NewQuantity(cfg.Resources.Limit.Memory, resource.BinarySI)
-			r.WithLimits(coreV1.ResourceList{
-				coreV1.ResourceCPU:    *cpu,
-				coreV1.ResourceMemory: *memory,
-			})
-		}
-		if cfg.Resources.Requests.CPU != 0 {
-			cpu := resource.NewMilliQuantity(cfg.Resources.Requests.CPU, resource.DecimalExponent)
-			memory := resource.NewQuantity(cfg.Resources.Requests.Memory, resource.BinarySI)
-			r.WithRequests(coreV1.ResourceList{
-				coreV1.ResourceCPU:    *cpu,
-				coreV1.ResourceMemory: *memory,
-			})
-		}
+	if cfg.WorkingDir != "" {
+		container = container.WithWorkingDir(cfg.WorkingDir)
+	}
+
+	for _, port := range cfg.ContainerPorts {
+		container = container.WithPorts(v13.ContainerPort().
+			WithContainerPort(port.Port).
+			WithProtocol(coreV1.Protocol(port.Protocol)))
+	}
+
+	if r := resourceRequirements(cfg.Resources); r != nil {
 		container = container.WithResources(r)
 	}
 
+	// the sidecars share the pod network with the main container, the init containers run before all of them
+	sidecars := make([]*v13.ContainerApplyConfiguration, 0, len(cfg.Sidecars))
+	for _, c := range cfg.Sidecars {
+		sidecars = append(sidecars, extraContainer(c))
+	}
+	initContainers := make([]*v13.ContainerApplyConfiguration, 0, len(cfg.InitContainers))
+	for _, c := range cfg.InitContainers {
+		initContainers = append(initContainers, extraContainer(c))
+	}
+
 	annotations := make(map[string]string)
 	if cfg.IP != "" {
 		annotations["cni.projectcalico.org/ipAddrs"] = fmt.Sprintf("[\"%s\"]", strings.Split(cfg.IP, "/")[0])
 		annotations["ip"] = strings.Split(cfg.IP, "/")[0]
+		// the dual-stack pod is pinned to the both addresses
+		if cfg.IPv6 != "" {
+			annotations["cni.projectcalico.org/ipAddrs"] = fmt.Sprintf("[\"%s\",\"%s\"]", strings.Split(cfg.IP, "/")[0], strings.Split(cfg.IPv6, "/")[0])
+			annotations["ipv6"] = strings.Split(cfg.IPv6, "/")[0]
+		}
+	}
+
+	securityContext := v13.SecurityContext().
+		WithPrivileged(cfg.Privileged).
+		WithAllowPrivilegeEscalation(cfg.Privileged)
+	if cfg.RunAs != nil {
+		securityContext = securityContext.
+			WithRunAsUser(cfg.RunAs.UserID).
+			WithRunAsGroup(cfg.RunAs.GroupID)
+	}
+
+	podSpec := v13.PodSpec()
+	if cfg.Hostname != "" {
+		podSpec = podSpec.WithHostname(cfg.Hostname)
+	}
+	if len(cfg.Sysctls) > 0 {
+		sysctls := make([]*v13.SysctlApplyConfiguration, 0, len(cfg.Sysctls))
+		for _, name := range slices.Sorted(maps.Keys(cfg.Sysctls)) {
+			sysctls = append(sysctls, v13.Sysctl().WithName(name).WithValue(cfg.Sysctls[name]))
+		}
+		podSpec = podSpec.WithSecurityContext(v13.PodSecurityContext().WithSysctls(sysctls...))
 	}
 
 	capAdds := make([]coreV1.Capability, 0)
@@ -85,9 +128,11 @@ func (k *Kubernetes) ApplyDeployment(ctx context.Context, cfg model.ApplyDeploym
 	}
 
 	if cfg.ReadinessProbe != nil {
-		container = container.WithReadinessProbe(v13.Probe().
-			WithPeriodSeconds(cfg.ReadinessProbe.PeriodSeconds).
-			WithExec(v13.ExecAction().WithCommand(cfg.ReadinessProbe.Cmd...)))
+		container = container.WithReadinessProbe(probe(cfg.ReadinessProbe))
+	}
+
+	if cfg.LivenessProbe != nil {
+		container = container.WithLivenessProbe(probe(cfg.LivenessProbe))
 	}
 
 	dnsConfig := v13.PodDNSConfig()
@@ -97,8 +142,19 @@ func (k *Kubernetes) ApplyDeployment(ctx context.Context, cfg model.ApplyDeploym
 		dnsConfig = dnsConfig.WithNameservers(strings.Split(cfg.DNS, "/")[0])
 		dnsPolicy = coreV1.DNSNone
 	}
-	if cfg.UsePublicDNS {
-		dnsConfig = dnsConfig.WithNameservers("1.1.1.1", "8.8.8.8")
+
+	// the service is applied first, so the failed service does not leave the running deployment without it
+	if cfg.Service != nil {
+		if err := k.ApplyService(ctx, model.ApplyServiceConfig{
+			Name:     cfg.Name,
+			LabID:    cfg.LabID,
+			Labels:   cfg.Labels,
+			Selector: map[string]string{labInstanceIDLabel: tools.GetLabel(cfg.LabID, cfg.Name)},
+			Headless: cfg.Service.Headless,
+			Ports:    cfg.Service.Ports,
+		}); err != nil {
+			return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply deployment service").Err()
+		}
 	}
 
 	if _, err := k.kubeClient.AppsV1().Deployments(cfg.LabID).Apply(
@@ -115,20 +171,20 @@ func (k *Kubernetes) ApplyDeployment(ctx context.Context, cfg model.ApplyDeploym
 					}).
 					WithLabels(cfg.Labels).
 					WithAnnotations(annotations).
-					WithSpec(v13.PodSpec().
+					WithSpec(podSpec.
 						WithDNSPolicy(dnsPolicy).
 						WithRestartPolicy(coreV1.RestartPolicyAlways).
 						WithDNSConfig(dnsConfig).
 						WithVolumes(volumes...).
+						WithInitContainers(initContainers...).
 						WithContainers(container.
 							WithName(cfg.Name).
 							WithImage(cfg.Image).
-							WithSecurityContext(v13.SecurityContext().
-								WithPrivileged(cfg.Privileged).
-								WithAllowPrivilegeEscalation(cfg.Privileged).
+							WithSecurityContext(securityContext.
 								WithCapabilities(v13.Capabilities().
 									WithAdd(capAdds...))).
-							WithVolumeMounts(volumeMounts...))))),
+							WithVolumeMounts(volumeMounts...)).
+						WithContainers(sidecars...)))),
 		metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
 		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply deployment").Err()
 	}
@@ -136,6 +192,100 @@ func (k *Kubernetes) ApplyDeployment(ctx context.Context, cfg model.ApplyDeploym
 	return nil
 }
 
+// extraContainer returns the sidecar or the init container of the pod
+func extraContainer(c model.ContainerConfig) *v13.ContainerApplyConfiguration {
+	container := v13.Container().
+		WithName(c.Name).
+		WithImage(c.Image)
+
+	if envVars := envVariables(c.Envs); len(envVars) > 0 {
+		container = container.WithEnv(envVars...)
+	}
+
+	if len(c.Command) > 0 {
+		container = container.WithCommand(c.Command...)
+	}
+
+	if len(c.Args) > 0 {
+		container = container.WithArgs(c.Args...)
+	}
+
+	if r := resourceRequirements(c.Resources); r != nil {
+		container = container.WithResources(r)
+	}
+
+	return container
+}
+
+func envVariables(envs []model.EnvConfig) []*v13.EnvVarApplyConfiguration {
+	envVars := make([]*v13.EnvVarApplyConfiguration, 0, len(envs))
+	for _, env := range envs {
+		envVars = append(envVars, v13.EnvVar().WithName(env.Name).WithValue(env.Value))
+	}
+	return envVars
+}
+
+// resourceRequirements returns nil if neither the limits nor the requests are set, the lab limit range fills the defaults then
+func resourceRequirements(resources model.ResourcesConfig) *v13.ResourceRequirementsApplyConfiguration {
+	limits := resourceList(resources.Limit)
+	requests := resourceList(resources.Requests)
+	if len(limits) == 0 && len(requests) == 0 {
+		return nil
+	}
+
+	r := v13.ResourceRequirements()
+	if len(limits) > 0 {
+		r.WithLimits(limits)
+	}
+	if len(requests) > 0 {
+		r.WithRequests(requests)
+	}
+	return r
+}
+
+// resourceList returns the set resources only, the unset ones are left to the lab limit range
+func resourceList(rc model.ResourceConfig) coreV1.ResourceList {
+	list := coreV1.ResourceList{}
+	if rc.CPU != 0 {
+		list[coreV1.ResourceCPU] = *resource.NewMilliQuantity(rc.CPU, resource.DecimalExponent)
+	}
+	if rc.Memory != 0 {
+		list[coreV1.ResourceMemory] = *resource.NewQuantity(rc.Memory, resource.BinarySI)
+	}
+	if rc.EphemeralStorage != 0 {
+		list[coreV1.ResourceEphemeralStorage] = *resource.NewQuantity(rc.EphemeralStorage, resource.BinarySI)
+	}
+	return list
+}
+
+// probe returns the exec, HTTP GET or TCP socket probe, the zero timings are left to the kubernetes defaults
+func probe(p *model.Probe) *v13.ProbeApplyConfiguration {
+	pr := v13.Probe()
+	switch {
+	case len(p.Cmd) > 0:
+		pr = pr.WithExec(v13.ExecAction().WithCommand(p.Cmd...))
+	case p.HTTPPort != 0:
+		path := p.HTTPPath
+		if path == "" {
+			path = "/"
+		}
+		pr = pr.WithHTTPGet(v13.HTTPGetAction().WithPath(path).WithPort(intstr.FromInt32(p.HTTPPort)))
+	case p.TCPPort != 0:
+		pr = pr.WithTCPSocket(v13.TCPSocketAction().WithPort(intstr.FromInt32(p.TCPPort)))
+	}
+
+	if p.InitialDelaySeconds != 0 {
+		pr = pr.WithInitialDelaySeconds(p.InitialDelaySeconds)
+	}
+	if p.PeriodSeconds != 0 {
+		pr = pr.WithPeriodSeconds(p.PeriodSeconds)
+	}
+	if p.FailureThreshold != 0 {
+		pr = pr.WithFailureThreshold(p.FailureThreshold)
+	}
+	return pr
+}
+
 func (k *Kubernetes) GetDeploymentsInNamespaceBySelector(ctx context.Context, labID string, selector ...string) ([]model.DeploymentStatus, error) {
 	labelSelector := strings.Join(selector, ",")
 
@@ -149,12 +299,23 @@ func (k *Kubernetes) GetDeploymentsInNamespaceBySelector(ctx context.Context, la
 	dpsStatus := make([]model.DeploymentStatus, 0)
 
 	for _, dp := range dps.Items {
-		dpsStatus = append(dpsStatus, model.DeploymentStatus{
+		status := model.DeploymentStatus{
 			Name:   dp.GetName(),
 			IP:     dp.Spec.Template.Annotations["ip"],
+			IPv6:   dp.Spec.Template.Annotations["ipv6"],
 			Status: StatusFromReplicas(dp.Status.Replicas, dp.Status.ReadyReplicas, dp.Status.AvailableReplicas, dp.Status.UnavailableReplicas),
 			Labels: dp.GetLabels(),
-		})
+		}
+
+		// pods rejected on creation, for example by the lab resource quota, are reported by the replica failure condition
+		for _, condition := range dp.Status.Conditions {
+			if condition.Type == appsV1.DeploymentReplicaFailure && condition.Status == coreV1.ConditionTrue {
+				status.Status = model.StatusError
+				status.Reason = condition.Message
+			}
+		}
+
+		dpsStatus = append(dpsStatus, status)
 	}
 
 	return dpsStatus, nil
@@ -204,6 +365,9 @@ func (k *Kubernetes) ResetDeployment(ctx context.Context, name, labID string) er
 
 func (k *Kubernetes) DeleteDeployment(ctx context.Context, name, labID string) error {
 	if err := k.kubeClient.AppsV1().Deployments(labID).Delete(ctx, name, metaV1.DeleteOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
 		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to delete deployment").Err()
 	}
 
@@ -0,0 +1,210 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/cybericebox/agent/internal/config"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog/log"
+	coreV1 "k8s.io/api/core/v1"
+	apinetworkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	v13 "k8s.io/client-go/applyconfigurations/core/v1"
+	v1 "k8s.io/client-go/applyconfigurations/networking/v1"
+	"maps"
+	"strings"
+	"time"
+)
+
+const (
+	// ingressTLSSecretName is the lab copy of the wildcard TLS secret
+	ingressTLSSecretName = "ingress-tls"
+	// ingressTLSWatchRetry is the delay before the closed or failed watch of the wildcard TLS secret is started again
+	ingressTLSWatchRetry = 10 * time.Second
+)
+
+// ApplyIngress routes the lab hosts to the lab service ports, the hosts are served with the wildcard TLS secret
+// or the default certificate of the ingress controller if the secret is not configured
+func (k *Kubernetes) ApplyIngress(ctx context.Context, cfg model.ApplyIngressConfig) error {
+	annotations := make(map[string]string)
+	if cfg.BasicAuthSecret != "" {
+		annotations["nginx.ingress.kubernetes.io/auth-type"] = "basic"
+		annotations["nginx.ingress.kubernetes.io/auth-secret"] = cfg.BasicAuthSecret
+		annotations["nginx.ingress.kubernetes.io/auth-realm"] = "Authentication Required"
+	}
+
+	hosts := make([]string, 0, len(cfg.Rules))
+	rules := make([]*v1.IngressRuleApplyConfiguration, 0, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		hosts = append(hosts, rule.Host)
+		rules = append(rules, v1.IngressRule().
+			WithHost(rule.Host).
+			WithHTTP(v1.HTTPIngressRuleValue().
+				WithPaths(v1.HTTPIngressPath().
+					WithPath("/").
+					WithPathType(apinetworkingv1.PathTypePrefix).
+					WithBackend(v1.IngressBackend().
+						WithService(v1.IngressServiceBackend().
+							WithName(cfg.ServiceName).
+							WithPort(v1.ServiceBackendPort().WithNumber(rule.Port)))))))
+	}
+
+	tls := v1.IngressTLS().WithHosts(hosts...)
+	if k.ingressTLSSecret != "" {
+		if err := k.copyIngressTLSSecret(ctx, cfg.LabID); err != nil {
+			return appError.ErrKubernetes.WithError(err).WithMessage("Failed to copy ingress TLS secret").Err()
+		}
+		tls = tls.WithSecretName(ingressTLSSecretName)
+	}
+
+	if _, err := k.kubeClient.NetworkingV1().Ingresses(cfg.LabID).Apply(ctx,
+		v1.Ingress(cfg.Name, cfg.LabID).WithLabels(cfg.Labels).WithAnnotations(annotations).
+			WithSpec(v1.IngressSpec().
+				WithIngressClassName(k.ingressClassName).
+				WithTLS(tls).
+				WithRules(rules...)),
+		metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply ingress").Err()
+	}
+
+	return nil
+}
+
+func (k *Kubernetes) GetIngressesBySelector(ctx context.Context, labID string, selector ...string) ([]model.IngressStatus, error) {
+	ingresses, err := k.kubeClient.NetworkingV1().Ingresses(labID).List(ctx, metaV1.ListOptions{
+		LabelSelector: strings.Join(selector, ","),
+	})
+	if err != nil {
+		return nil, appError.ErrKubernetes.WithError(err).WithMessage("Failed to get ingresses").Err()
+	}
+
+	statuses := make([]model.IngressStatus, 0, len(ingresses.Items))
+	for _, ingress := range ingresses.Items {
+		status := model.IngressStatus{
+			Name:   ingress.GetName(),
+			Labels: ingress.GetLabels(),
+		}
+		for _, rule := range ingress.Spec.Rules {
+			status.URLs = append(status.URLs, fmt.Sprintf("https://%s", rule.Host))
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (k *Kubernetes) DeleteIngress(ctx context.Context, name, labID string) error {
+	if err := k.kubeClient.NetworkingV1().Ingresses(labID).Delete(ctx, name, metaV1.DeleteOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to delete ingress").Err()
+	}
+
+	return nil
+}
+
+// copyIngressTLSSecret copies the wildcard TLS secret to the lab namespace, because the ingress reads the secret of its own namespace
+func (k *Kubernetes) copyIngressTLSSecret(ctx context.Context, labID string) error {
+	namespace, name, err := k.ingressTLSSecretRef()
+	if err != nil {
+		return err
+	}
+
+	secret, err := k.kubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metaV1.GetOptions{})
+	if err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to get ingress TLS secret").Err()
+	}
+
+	return k.applyIngressTLSSecret(ctx, labID, secret.Data)
+}
+
+// watchIngressTLSSecret copies the renewed wildcard TLS secret to the lab namespaces having its copy,
+// the watch is started again until the context is done
+func (k *Kubernetes) watchIngressTLSSecret(ctx context.Context) {
+	namespace, name, err := k.ingressTLSSecretRef()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to watch ingress TLS secret")
+		return
+	}
+
+	for {
+		watcher, err := k.kubeClient.CoreV1().Secrets(namespace).Watch(ctx, metaV1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String(),
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to watch ingress TLS secret")
+		} else {
+			for event := range watcher.ResultChan() {
+				if event.Type != watch.Added && event.Type != watch.Modified {
+					continue
+				}
+				if secret, ok := event.Object.(*coreV1.Secret); ok {
+					if err = k.refreshIngressTLSSecrets(ctx, secret.Data); err != nil {
+						log.Error().Err(err).Msg("Failed to refresh lab ingress TLS secrets")
+					}
+				}
+			}
+			watcher.Stop()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(ingressTLSWatchRetry):
+		}
+	}
+}
+
+// refreshIngressTLSSecrets applies the wildcard TLS secret data to the lab copies
+func (k *Kubernetes) refreshIngressTLSSecrets(ctx context.Context, data map[string][]byte) (errs error) {
+	secrets, err := k.kubeClient.CoreV1().Secrets(metaV1.NamespaceAll).List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", config.PlatformLabel, config.LabIngressTLS),
+	})
+	if err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to get lab ingress TLS secrets").Err()
+	}
+
+	for _, secret := range secrets.Items {
+		if maps.EqualFunc(secret.Data, data, bytes.Equal) {
+			continue
+		}
+		if err = k.applyIngressTLSSecret(ctx, secret.GetNamespace(), data); err != nil {
+			errs = multierror.Append(errs, appError.ErrKubernetes.WithError(err).WithContext("labID", secret.GetNamespace()).Err())
+		}
+	}
+
+	return
+}
+
+func (k *Kubernetes) applyIngressTLSSecret(ctx context.Context, labID string, data map[string][]byte) error {
+	if _, err := k.kubeClient.CoreV1().Secrets(labID).Apply(ctx,
+		v13.Secret(ingressTLSSecretName, labID).
+			WithLabels(map[string]string{
+				config.PlatformLabel: config.LabIngressTLS,
+				config.LabIDLabel:    labID,
+			}).
+			WithType(coreV1.SecretTypeTLS).
+			WithData(data),
+		metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply lab ingress TLS secret").Err()
+	}
+
+	return nil
+}
+
+// ingressTLSSecretRef returns the namespace and the name of the wildcard TLS secret
+func (k *Kubernetes) ingressTLSSecretRef() (string, string, error) {
+	namespace, name, found := strings.Cut(k.ingressTLSSecret, "/")
+	if !found {
+		return "", "", appError.ErrKubernetes.WithMessageF("Ingress TLS secret %s is not in the namespace/name format", k.ingressTLSSecret).Err()
+	}
+
+	return namespace, name, nil
+}
@@ -1,16 +1,21 @@
 package k8s
 
 import (
+	"context"
 	"github.com/cybericebox/agent/internal/config"
 	"github.com/cybericebox/lib/pkg/worker"
 	calico "github.com/projectcalico/api/pkg/client/clientset_generated/clientset"
 	"github.com/rs/zerolog/log"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 	metricsv "k8s.io/metrics/pkg/client/clientset/versioned"
+	"net"
 	"path/filepath"
+	"sync"
+	"time"
 )
 
 type (
@@ -18,8 +23,27 @@ type (
 		kubeClient    *kubernetes.Clientset
 		calicoClient  *calico.Clientset
 		metricsClient *metricsv.Clientset
+		dynamicClient *dynamic.DynamicClient
 		worker        worker.Worker
-		podCIDR       string
+		podIPv6CIDR   string
+
+		// podCIDRs are the networks of the CIDR pools the lab pods get the addresses from
+		podCIDRsMutex sync.RWMutex
+		podCIDRs      []string
+
+		// resolver resolves the egress domains by the lab DNS upstreams
+		resolver             *net.Resolver
+		egressDomainsRefresh time.Duration
+
+		defaultContainerCPU              int64
+		defaultContainerMemory           int64
+		defaultContainerEphemeralStorage int64
+
+		adminNamespace string
+
+		ingressClassName string
+		ingressNamespace string
+		ingressTLSSecret string
 	}
 
 	Dependencies struct {
@@ -43,8 +67,22 @@ func NewKubernetes(deps Dependencies) *Kubernetes {
 	}
 
 	k := &Kubernetes{
-		podCIDR: deps.Config.PodsCIDR,
-		worker:  deps.Worker,
+		podCIDRs:    []string{deps.Config.PodsCIDR},
+		podIPv6CIDR: deps.Config.PodsIPv6CIDR,
+		worker:      deps.Worker,
+		resolver:    upstreamsResolver(deps.Config.DNSUpstreams),
+
+		egressDomainsRefresh: deps.Config.EgressDomainsRefresh,
+
+		defaultContainerCPU:              deps.Config.DefaultContainerCPU,
+		defaultContainerMemory:           deps.Config.DefaultContainerMemory,
+		defaultContainerEphemeralStorage: deps.Config.DefaultContainerEphemeralStorage,
+
+		adminNamespace: deps.Config.AdminNamespace,
+
+		ingressClassName: deps.Config.IngressClassName,
+		ingressNamespace: deps.Config.IngressNamespace,
+		ingressTLSSecret: deps.Config.IngressTLSSecret,
 	}
 	k.kubeClient, err = kubernetes.NewForConfig(cfg)
 	if err != nil {
@@ -58,10 +96,44 @@ func NewKubernetes(deps Dependencies) *Kubernetes {
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to create metrics client")
 	}
+	k.dynamicClient, err = dynamic.NewForConfig(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create dynamic client")
+	}
+
+	// the lab copies of the wildcard TLS secret follow its renewals
+	if k.ingressTLSSecret != "" {
+		go k.watchIngressTLSSecret(context.Background())
+	}
+
+	// the egress domains policies follow the changed domain addresses
+	if k.egressDomainsRefresh > 0 {
+		go k.refreshEgressDomainsPeriodically(context.Background())
+	}
 
 	return k
 }
 
+// upstreamsResolver returns the resolver asking the upstream DNS servers in order, no upstreams mean the system resolver
+func upstreamsResolver(upstreams []string) *net.Resolver {
+	if len(upstreams) == 0 {
+		return net.DefaultResolver
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (conn net.Conn, err error) {
+			dialer := net.Dialer{}
+			for _, upstream := range upstreams {
+				if conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(upstream, "53")); err == nil {
+					return conn, nil
+				}
+			}
+			return nil, err
+		},
+	}
+}
+
 func (k *Kubernetes) GetKubeClient() *kubernetes.Clientset {
 	return k.kubeClient
 }
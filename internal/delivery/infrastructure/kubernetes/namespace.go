@@ -10,19 +10,23 @@ import (
 	v1 "k8s.io/client-go/applyconfigurations/core/v1"
 )
 
-func (k *Kubernetes) ApplyNamespace(ctx context.Context, name string, ipPoolName *string) error {
+// ApplyNamespace applies the lab namespace, the extra labels can not override the platform ones
+func (k *Kubernetes) ApplyNamespace(ctx context.Context, name string, ipPoolName *string, extraLabels map[string]string) error {
 	annotations := make(map[string]string)
 	if ipPoolName != nil {
 		annotations["cni.projectcalico.org/ipv4pools"] = fmt.Sprintf("[\"%s\"]", *ipPoolName)
 	}
 
+	labels := make(map[string]string, len(extraLabels)+2)
+	for key, value := range extraLabels {
+		labels[key] = value
+	}
+	labels[config.PlatformLabel] = config.Lab
+	labels[config.LabIDLabel] = name
+
 	if _, err := k.kubeClient.CoreV1().Namespaces().Apply(
 		ctx,
-		v1.Namespace(name).WithAnnotations(annotations).WithLabels(
-			map[string]string{
-				config.PlatformLabel: config.Lab,
-				config.LabIDLabel:    name,
-			}),
+		v1.Namespace(name).WithAnnotations(annotations).WithLabels(labels),
 		metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
 		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply namespace").Err()
 	}
@@ -50,3 +54,12 @@ func (k *Kubernetes) DeleteNamespace(ctx context.Context, name string) error {
 
 	return nil
 }
+
+func (k *Kubernetes) GetNamespaceLabels(ctx context.Context, name string) (map[string]string, error) {
+	ns, err := k.kubeClient.CoreV1().Namespaces().Get(ctx, name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, appError.ErrKubernetes.WithError(err).WithMessage("Failed to get namespace").Err()
+	}
+
+	return ns.GetLabels(), nil
+}
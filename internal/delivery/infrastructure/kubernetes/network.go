@@ -2,10 +2,13 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 	"github.com/cybericebox/agent/internal/config"
 	"github.com/cybericebox/agent/pkg/appError"
 	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/netip"
 )
 
 func (k *Kubernetes) ApplyNetwork(ctx context.Context, name, cidr string, blockSize int) error {
@@ -38,20 +41,108 @@ func (k *Kubernetes) GetNetworkCIDR(ctx context.Context, name string) (string, e
 	return get.Spec.CIDR, nil
 }
 
+// ApplySegmentNetwork applies the network of the lab segment
+func (k *Kubernetes) ApplySegmentNetwork(ctx context.Context, labID, segment, cidr string, blockSize int) error {
+	name := segmentNetworkName(labID, segment)
+	if k.networkExists(ctx, name) {
+		return nil
+	}
+	return k.createNetworkWithLabels(ctx, name, cidr, blockSize, map[string]string{
+		config.PlatformLabel: config.LabSegment,
+		config.LabIDLabel:    labID,
+		config.SegmentLabel:  segment,
+	})
+}
+
+// GetSegmentNetworks returns the CIDRs of the lab segments networks by the segments names
+func (k *Kubernetes) GetSegmentNetworks(ctx context.Context, labID string) (map[string]string, error) {
+	pools, err := k.calicoClient.ProjectcalicoV3().IPPools().List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s=%s", config.PlatformLabel, config.LabSegment, config.LabIDLabel, labID),
+	})
+	if err != nil {
+		return nil, appError.ErrKubernetes.WithError(err).WithMessage("Failed to get segment networks").Err()
+	}
+
+	networks := make(map[string]string, len(pools.Items))
+	for _, pool := range pools.Items {
+		networks[pool.GetLabels()[config.SegmentLabel]] = pool.Spec.CIDR
+	}
+
+	return networks, nil
+}
+
+func (k *Kubernetes) DeleteSegmentNetwork(ctx context.Context, labID, segment string) error {
+	if err := k.calicoClient.ProjectcalicoV3().IPPools().Delete(ctx, segmentNetworkName(labID, segment), metaV1.DeleteOptions{}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to delete segment network").Err()
+	}
+
+	return nil
+}
+
+// ApplyIPv6Network applies the IPv6 network of the dual-stack lab
+func (k *Kubernetes) ApplyIPv6Network(ctx context.Context, labID, cidr string, blockSize int) error {
+	name := ipv6NetworkName(labID)
+	if k.networkExists(ctx, name) {
+		return nil
+	}
+	return k.createNetworkWithLabels(ctx, name, cidr, blockSize, map[string]string{
+		config.PlatformLabel: config.LabIPv6,
+		config.LabIDLabel:    labID,
+	})
+}
+
+// GetIPv6NetworkCIDR returns the CIDR of the lab IPv6 network, or empty string if the lab has no IPv6 network
+func (k *Kubernetes) GetIPv6NetworkCIDR(ctx context.Context, labID string) (string, error) {
+	get, err := k.calicoClient.ProjectcalicoV3().IPPools().Get(ctx, ipv6NetworkName(labID), metaV1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", appError.ErrKubernetes.WithError(err).WithMessage("Failed to get IPv6 network CIDR").Err()
+	}
+	return get.Spec.CIDR, nil
+}
+
+func (k *Kubernetes) DeleteIPv6Network(ctx context.Context, labID string) error {
+	if err := k.calicoClient.ProjectcalicoV3().IPPools().Delete(ctx, ipv6NetworkName(labID), metaV1.DeleteOptions{}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to delete IPv6 network").Err()
+	}
+
+	return nil
+}
+
+func ipv6NetworkName(labID string) string {
+	return fmt.Sprintf("%s-ipv6", labID)
+}
+
+func segmentNetworkName(labID, segment string) string {
+	return fmt.Sprintf("%s-%s", labID, segment)
+}
+
 func (k *Kubernetes) createNetwork(ctx context.Context, name, cidr string, blockSize int) error {
+	return k.createNetworkWithLabels(ctx, name, cidr, blockSize, map[string]string{
+		config.PlatformLabel: config.LabNetwork,
+		config.LabIDLabel:    name,
+	})
+}
+
+func (k *Kubernetes) createNetworkWithLabels(ctx context.Context, name, cidr string, blockSize int, labels map[string]string) error {
+	// the IP in IP encapsulation supports only IPv4
+	ipipMode := v3.IPIPModeAlways
+	if prefix, err := netip.ParsePrefix(cidr); err == nil && prefix.Addr().Is6() {
+		ipipMode = v3.IPIPModeNever
+	}
+
 	if _, err := k.calicoClient.ProjectcalicoV3().IPPools().Create(ctx,
 		&v3.IPPool{
 			TypeMeta: metaV1.TypeMeta{},
 			ObjectMeta: metaV1.ObjectMeta{
-				Name: name,
-				Labels: map[string]string{
-					config.PlatformLabel: config.LabNetwork,
-					config.LabIDLabel:    name,
-				},
+				Name:   name,
+				Labels: labels,
 			},
 			Spec: v3.IPPoolSpec{
 				CIDR:         cidr,
-				IPIPMode:     "Always",
+				IPIPMode:     ipipMode,
 				NATOutgoing:  true,
 				BlockSize:    blockSize,
 				NodeSelector: "!all()",
@@ -2,32 +2,101 @@ package k8s
 
 import (
 	"context"
+	"fmt"
+	"github.com/cybericebox/agent/internal/config"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/internal/tools"
 	"github.com/cybericebox/agent/pkg/appError"
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog/log"
+	coreV1 "k8s.io/api/core/v1"
 	apinetworkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	networkingv1 "k8s.io/client-go/applyconfigurations/networking/v1"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
 )
 
-func (k *Kubernetes) ApplyNetworkPolicy(ctx context.Context, labID string) error {
+const (
+	intraLabPolicyName        = "intra-lab"
+	intraLabEgressPolicyName  = "intra-lab-egress"
+	groupSharedPolicyName     = "group-shared"
+	groupSharedEgressName     = "group-shared-egress"
+	instanceRulesPolicyPrefix = "rules"
+	labEgressPolicyName       = "egress"
+	dnsServerEgressPolicyName = "egress-dns-server"
+	vpnGatewayPolicyName      = "vpn-gateway"
+	sshBastionPolicyName      = "ssh-bastion"
+	ingressPolicyPrefix       = "ingress"
+	publicPortsPolicyPrefix   = "public"
+
+	// egressDomainsAnnotation keeps the domains of the egress policy to resolve them again
+	egressDomainsAnnotation = "egressDomains"
+)
+
+// ApplyNetworkPolicy applies the default lab network policy, the isolation mode defines which pods outside the lab can reach the lab.
+// The pods without segments can be reached from the whole lab and the group shared labs, the segments members are reached by the segment policies,
+// the instances with their own network rules are reached and reach the lab pods by the instance policies.
+// The egress outside the cluster is allowed by the egress policies
+func (k *Kubernetes) ApplyNetworkPolicy(ctx context.Context, labID, groupID string, mode model.IsolationMode) error {
 	if _, err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, &networkingv1.NetworkPolicyApplyConfiguration{
 		TypeMetaApplyConfiguration:   *v1.TypeMeta().WithKind("NetworkPolicy").WithAPIVersion("networking.k8s.io/v1"),
-		ObjectMetaApplyConfiguration: v1.ObjectMeta().WithName("default").WithNamespace(labID),
+		ObjectMetaApplyConfiguration: v1.ObjectMeta().WithName(intraLabPolicyName).WithNamespace(labID),
+		Spec: networkingv1.NetworkPolicySpec().
+			WithPolicyTypes(apinetworkingv1.PolicyTypeIngress).
+			WithPodSelector(notSegmentedSelector().WithMatchExpressions(withoutLabel(config.IngressRulesLabel))).
+			WithIngress(networkingv1.NetworkPolicyIngressRule().
+				WithFrom(networkingv1.NetworkPolicyPeer().WithPodSelector(v1.LabelSelector()))),
+	}, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply intra lab network policy").Err()
+	}
+
+	if _, err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, &networkingv1.NetworkPolicyApplyConfiguration{
+		TypeMetaApplyConfiguration:   *v1.TypeMeta().WithKind("NetworkPolicy").WithAPIVersion("networking.k8s.io/v1"),
+		ObjectMetaApplyConfiguration: v1.ObjectMeta().WithName(intraLabEgressPolicyName).WithNamespace(labID),
 		Spec: networkingv1.NetworkPolicySpec().
-			WithPolicyTypes(apinetworkingv1.PolicyTypeIngress, apinetworkingv1.PolicyTypeEgress).
-			WithPodSelector(v1.LabelSelector()).
-			WithIngress(
-				networkingv1.NetworkPolicyIngressRule().
-					WithFrom(networkingv1.NetworkPolicyPeer().WithPodSelector(v1.LabelSelector())),
-				networkingv1.NetworkPolicyIngressRule().
-					WithFrom(networkingv1.NetworkPolicyPeer().WithNamespaceSelector(v1.LabelSelector())),
-			).
-			WithEgress(
-				networkingv1.NetworkPolicyEgressRule().
-					WithTo(networkingv1.NetworkPolicyPeer().WithPodSelector(v1.LabelSelector())),
-				networkingv1.NetworkPolicyEgressRule().
-					WithTo(networkingv1.NetworkPolicyPeer().WithIPBlock(networkingv1.IPBlock().WithCIDR("0.0.0.0/0").WithExcept(k.podCIDR))),
-			),
+			WithPolicyTypes(apinetworkingv1.PolicyTypeEgress).
+			WithPodSelector(v1.LabelSelector().WithMatchExpressions(withoutLabel(config.EgressRulesLabel))).
+			WithEgress(networkingv1.NetworkPolicyEgressRule().
+				WithTo(networkingv1.NetworkPolicyPeer().WithPodSelector(v1.LabelSelector()))),
+	}, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply intra lab egress network policy").Err()
+	}
+
+	// labs of the same group reach each other as the lab pods do, so the segments and the instance rules are kept
+	if err := k.applyGroupSharedPolicies(ctx, labID, groupID, mode == model.IsolationModeGroupShared); err != nil {
+		return err
+	}
+
+	ingress := make([]*networkingv1.NetworkPolicyIngressRuleApplyConfiguration, 0)
+
+	if mode == model.IsolationModePlatform {
+		if k.adminNamespace == "" {
+			return appError.ErrKubernetes.WithMessage("Platform isolation mode requires the admin namespace").Err()
+		}
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule().
+			WithFrom(networkingv1.NetworkPolicyPeer().WithNamespaceSelector(v1.LabelSelector().WithMatchLabels(map[string]string{
+				"kubernetes.io/metadata.name": k.adminNamespace,
+			}))))
+	}
+
+	spec := networkingv1.NetworkPolicySpec().
+		WithPolicyTypes(apinetworkingv1.PolicyTypeIngress, apinetworkingv1.PolicyTypeEgress).
+		WithPodSelector(v1.LabelSelector())
+	if len(ingress) > 0 {
+		spec = spec.WithIngress(ingress...)
+	}
+
+	if _, err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, &networkingv1.NetworkPolicyApplyConfiguration{
+		TypeMetaApplyConfiguration:   *v1.TypeMeta().WithKind("NetworkPolicy").WithAPIVersion("networking.k8s.io/v1"),
+		ObjectMetaApplyConfiguration: v1.ObjectMeta().WithName("default").WithNamespace(labID),
+		Spec:                         spec,
 	}, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
 		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply network policy").Err()
 	}
@@ -41,3 +110,664 @@ func (k *Kubernetes) DeleteNetworkPolicy(ctx context.Context, labID string) erro
 
 	return nil
 }
+
+// applyGroupSharedPolicies allows the pods of the group labs to reach the lab pods without segments and instance rules,
+// the policies are deleted if the lab does not share the group network
+func (k *Kubernetes) applyGroupSharedPolicies(ctx context.Context, labID, groupID string, shared bool) error {
+	if !shared {
+		for _, name := range []string{groupSharedPolicyName, groupSharedEgressName} {
+			if err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Delete(ctx, name, metaV1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				return appError.ErrKubernetes.WithError(err).WithMessage("Failed to delete group shared network policy").WithContext("policy", name).Err()
+			}
+		}
+		return nil
+	}
+
+	groupLabs := groupLabsPeer(groupID)
+
+	if _, err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, &networkingv1.NetworkPolicyApplyConfiguration{
+		TypeMetaApplyConfiguration:   *v1.TypeMeta().WithKind("NetworkPolicy").WithAPIVersion("networking.k8s.io/v1"),
+		ObjectMetaApplyConfiguration: v1.ObjectMeta().WithName(groupSharedPolicyName).WithNamespace(labID),
+		Spec: networkingv1.NetworkPolicySpec().
+			WithPolicyTypes(apinetworkingv1.PolicyTypeIngress).
+			WithPodSelector(notSegmentedSelector().WithMatchExpressions(withoutLabel(config.IngressRulesLabel))).
+			WithIngress(networkingv1.NetworkPolicyIngressRule().WithFrom(groupLabs)),
+	}, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply group shared network policy").Err()
+	}
+
+	if _, err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, &networkingv1.NetworkPolicyApplyConfiguration{
+		TypeMetaApplyConfiguration:   *v1.TypeMeta().WithKind("NetworkPolicy").WithAPIVersion("networking.k8s.io/v1"),
+		ObjectMetaApplyConfiguration: v1.ObjectMeta().WithName(groupSharedEgressName).WithNamespace(labID),
+		Spec: networkingv1.NetworkPolicySpec().
+			WithPolicyTypes(apinetworkingv1.PolicyTypeEgress).
+			WithPodSelector(v1.LabelSelector().WithMatchExpressions(withoutLabel(config.EgressRulesLabel))).
+			WithEgress(networkingv1.NetworkPolicyEgressRule().WithTo(groupLabs)),
+	}, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply group shared egress network policy").Err()
+	}
+
+	return nil
+}
+
+// ApplySegmentPolicy allows the segment members to be reached by the members of the same segment and the segments from the AllowFrom,
+// the segment allowing the default segment is reached by the pods without segments of the shared group labs too, empty shared group means none
+func (k *Kubernetes) ApplySegmentPolicy(ctx context.Context, labID, sharedGroupID string, segment model.Segment) error {
+	peers := []*networkingv1.NetworkPolicyPeerApplyConfiguration{
+		networkingv1.NetworkPolicyPeer().WithPodSelector(v1.LabelSelector().WithMatchLabels(map[string]string{tools.SegmentMemberLabel(segment.Name): segment.Name})),
+	}
+	for _, from := range segment.AllowFrom {
+		if from == model.DefaultSegment {
+			peers = append(peers, networkingv1.NetworkPolicyPeer().WithPodSelector(notSegmentedSelector()))
+			if sharedGroupID != "" {
+				peers = append(peers, groupLabsPeer(sharedGroupID).WithPodSelector(notSegmentedSelector()))
+			}
+			continue
+		}
+		peers = append(peers, networkingv1.NetworkPolicyPeer().WithPodSelector(v1.LabelSelector().WithMatchLabels(map[string]string{tools.SegmentMemberLabel(from): from})))
+	}
+
+	if _, err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, &networkingv1.NetworkPolicyApplyConfiguration{
+		TypeMetaApplyConfiguration:   *v1.TypeMeta().WithKind("NetworkPolicy").WithAPIVersion("networking.k8s.io/v1"),
+		ObjectMetaApplyConfiguration: v1.ObjectMeta().WithName(tools.SegmentMemberLabel(segment.Name)).WithNamespace(labID),
+		Spec: networkingv1.NetworkPolicySpec().
+			WithPolicyTypes(apinetworkingv1.PolicyTypeIngress).
+			WithPodSelector(v1.LabelSelector().
+				WithMatchLabels(map[string]string{tools.SegmentMemberLabel(segment.Name): segment.Name}).
+				WithMatchExpressions(withoutLabel(config.IngressRulesLabel))).
+			WithIngress(networkingv1.NetworkPolicyIngressRule().WithFrom(peers...)),
+	}, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply segment network policy").Err()
+	}
+
+	return nil
+}
+
+// ApplyInstanceNetworkPolicy restricts the lab pods the instance can be reached from and can reach by the instance rules,
+// the instance without the rules of the direction keeps the lab default. The rules select the pods of the lab only,
+// so the group shared labs do not reach the instance with the ingress rules
+func (k *Kubernetes) ApplyInstanceNetworkPolicy(ctx context.Context, labID, instanceID string, allowFrom, allowTo []model.NetworkRule) error {
+	policyTypes := make([]apinetworkingv1.PolicyType, 0, 2)
+
+	ingress := make([]*networkingv1.NetworkPolicyIngressRuleApplyConfiguration, 0, len(allowFrom))
+	for _, rule := range allowFrom {
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule().
+			WithFrom(instancesPeer(rule.InstanceIDs)).
+			WithPorts(networkPolicyPorts(rule.Ports)...))
+	}
+	if len(ingress) > 0 {
+		policyTypes = append(policyTypes, apinetworkingv1.PolicyTypeIngress)
+	}
+
+	egress := make([]*networkingv1.NetworkPolicyEgressRuleApplyConfiguration, 0, len(allowTo)+1)
+	for _, rule := range allowTo {
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule().
+			WithTo(instancesPeer(rule.InstanceIDs)).
+			WithPorts(networkPolicyPorts(rule.Ports)...))
+	}
+	if len(egress) > 0 {
+		policyTypes = append(policyTypes, apinetworkingv1.PolicyTypeEgress)
+		// the instance resolves the names by the lab DNS server
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule().
+			WithTo(networkingv1.NetworkPolicyPeer().WithPodSelector(v1.LabelSelector().WithMatchLabels(map[string]string{
+				config.PlatformLabel: config.LabDNSServer,
+			}))))
+	}
+
+	if len(policyTypes) == 0 {
+		return nil
+	}
+
+	spec := networkingv1.NetworkPolicySpec().
+		WithPolicyTypes(policyTypes...).
+		WithPodSelector(v1.LabelSelector().WithMatchLabels(map[string]string{config.InstanceIDLabel: instanceID}))
+	if len(ingress) > 0 {
+		spec = spec.WithIngress(ingress...)
+	}
+	if len(egress) > 0 {
+		spec = spec.WithEgress(egress...)
+	}
+
+	if _, err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, &networkingv1.NetworkPolicyApplyConfiguration{
+		TypeMetaApplyConfiguration:   *v1.TypeMeta().WithKind("NetworkPolicy").WithAPIVersion("networking.k8s.io/v1"),
+		ObjectMetaApplyConfiguration: v1.ObjectMeta().WithName(instanceRulesPolicyName(instanceID)).WithNamespace(labID),
+		Spec:                         spec,
+	}, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply instance network policy").Err()
+	}
+
+	return nil
+}
+
+func (k *Kubernetes) DeleteInstanceNetworkPolicy(ctx context.Context, labID, instanceID string) error {
+	if err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Delete(ctx, instanceRulesPolicyName(instanceID), metaV1.DeleteOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to delete instance network policy").Err()
+	}
+
+	return nil
+}
+
+// ApplyLabEgressPolicy applies the egress profile to the lab pods without their own egress policy
+func (k *Kubernetes) ApplyLabEgressPolicy(ctx context.Context, labID string, profile model.EgressProfile) error {
+	// the default lab profile allows the internet
+	if profile.Mode == model.EgressModeDefault {
+		profile.Mode = model.EgressModeInternet
+	}
+
+	return k.applyEgressPolicy(ctx, labID, labEgressPolicyName,
+		v1.LabelSelector().WithMatchExpressions(v1.LabelSelectorRequirement().
+			WithKey(config.EgressProfileLabel).
+			WithOperator(metaV1.LabelSelectorOpDoesNotExist)),
+		profile)
+}
+
+// ApplyInstanceEgressPolicy applies the egress profile to the pods of the challenge instance
+func (k *Kubernetes) ApplyInstanceEgressPolicy(ctx context.Context, labID, instanceID string, profile model.EgressProfile) error {
+	return k.applyEgressPolicy(ctx, labID, instanceEgressPolicyName(instanceID),
+		v1.LabelSelector().WithMatchLabels(map[string]string{
+			config.InstanceIDLabel:    instanceID,
+			config.EgressProfileLabel: config.InstanceEgress,
+		}),
+		profile)
+}
+
+func (k *Kubernetes) DeleteInstanceEgressPolicy(ctx context.Context, labID, instanceID string) error {
+	if err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Delete(ctx, instanceEgressPolicyName(instanceID), metaV1.DeleteOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to delete instance egress policy").Err()
+	}
+
+	return nil
+}
+
+// ApplyDNSEgressPolicy allows the lab DNS server to reach only the upstream DNS servers
+func (k *Kubernetes) ApplyDNSEgressPolicy(ctx context.Context, labID string, upstreams []string) error {
+	peers := make([]*networkingv1.NetworkPolicyPeerApplyConfiguration, 0, len(upstreams))
+	for _, upstream := range upstreams {
+		peers = append(peers, networkingv1.NetworkPolicyPeer().WithIPBlock(networkingv1.IPBlock().WithCIDR(hostCIDR(net.ParseIP(upstream)))))
+	}
+
+	dnsPort := intstr.FromInt32(53)
+
+	if _, err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, &networkingv1.NetworkPolicyApplyConfiguration{
+		TypeMetaApplyConfiguration:   *v1.TypeMeta().WithKind("NetworkPolicy").WithAPIVersion("networking.k8s.io/v1"),
+		ObjectMetaApplyConfiguration: v1.ObjectMeta().WithName(dnsServerEgressPolicyName).WithNamespace(labID),
+		Spec: networkingv1.NetworkPolicySpec().
+			WithPolicyTypes(apinetworkingv1.PolicyTypeEgress).
+			WithPodSelector(v1.LabelSelector().WithMatchLabels(map[string]string{
+				config.PlatformLabel:      config.LabDNSServer,
+				config.EgressProfileLabel: config.DNSServerEgress,
+			})).
+			WithEgress(networkingv1.NetworkPolicyEgressRule().
+				WithTo(peers...).
+				WithPorts(
+					networkingv1.NetworkPolicyPort().WithProtocol(coreV1.ProtocolUDP).WithPort(dnsPort),
+					networkingv1.NetworkPolicyPort().WithProtocol(coreV1.ProtocolTCP).WithPort(dnsPort),
+				)),
+	}, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply dns egress policy").Err()
+	}
+
+	return nil
+}
+
+// ApplyVPNGatewayPolicy allows the lab VPN gateway to be reached from outside the cluster on the WireGuard port
+func (k *Kubernetes) ApplyVPNGatewayPolicy(ctx context.Context, labID string, port int32) error {
+	peers := []*networkingv1.NetworkPolicyPeerApplyConfiguration{k.externalPeer("0.0.0.0/0")}
+	if k.podIPv6CIDR != "" {
+		peers = append(peers, k.externalPeer("::/0"))
+	}
+
+	if _, err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, &networkingv1.NetworkPolicyApplyConfiguration{
+		TypeMetaApplyConfiguration:   *v1.TypeMeta().WithKind("NetworkPolicy").WithAPIVersion("networking.k8s.io/v1"),
+		ObjectMetaApplyConfiguration: v1.ObjectMeta().WithName(vpnGatewayPolicyName).WithNamespace(labID),
+		Spec: networkingv1.NetworkPolicySpec().
+			WithPolicyTypes(apinetworkingv1.PolicyTypeIngress).
+			WithPodSelector(v1.LabelSelector().WithMatchLabels(map[string]string{
+				config.PlatformLabel: config.LabVPN,
+			})).
+			WithIngress(networkingv1.NetworkPolicyIngressRule().
+				WithFrom(peers...).
+				WithPorts(networkingv1.NetworkPolicyPort().WithProtocol(coreV1.ProtocolUDP).WithPort(intstr.FromInt32(port)))),
+	}, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply vpn gateway network policy").Err()
+	}
+
+	return nil
+}
+
+// ApplySSHBastionPolicy allows the lab SSH bastion to be reached from the shared SSH router namespace on the SSH port
+func (k *Kubernetes) ApplySSHBastionPolicy(ctx context.Context, labID, routerNamespace string, port int32) error {
+	if _, err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, &networkingv1.NetworkPolicyApplyConfiguration{
+		TypeMetaApplyConfiguration:   *v1.TypeMeta().WithKind("NetworkPolicy").WithAPIVersion("networking.k8s.io/v1"),
+		ObjectMetaApplyConfiguration: v1.ObjectMeta().WithName(sshBastionPolicyName).WithNamespace(labID),
+		Spec: networkingv1.NetworkPolicySpec().
+			WithPolicyTypes(apinetworkingv1.PolicyTypeIngress).
+			WithPodSelector(v1.LabelSelector().WithMatchLabels(map[string]string{
+				config.PlatformLabel: config.LabSSH,
+			})).
+			WithIngress(networkingv1.NetworkPolicyIngressRule().
+				WithFrom(networkingv1.NetworkPolicyPeer().WithNamespaceSelector(v1.LabelSelector().WithMatchLabels(map[string]string{
+					"kubernetes.io/metadata.name": routerNamespace,
+				}))).
+				WithPorts(networkingv1.NetworkPolicyPort().WithProtocol(coreV1.ProtocolTCP).WithPort(intstr.FromInt32(port)))),
+	}, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply ssh bastion network policy").Err()
+	}
+
+	return nil
+}
+
+// ApplyIngressPolicy allows the selected lab pods to be reached from the ingress controller namespace on the web port
+func (k *Kubernetes) ApplyIngressPolicy(ctx context.Context, labID, name string, podSelector map[string]string, ports []int32) error {
+	policyPorts := make([]*networkingv1.NetworkPolicyPortApplyConfiguration, 0, len(ports))
+	for _, port := range ports {
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort().WithProtocol(coreV1.ProtocolTCP).WithPort(intstr.FromInt32(port)))
+	}
+
+	if _, err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, &networkingv1.NetworkPolicyApplyConfiguration{
+		TypeMetaApplyConfiguration:   *v1.TypeMeta().WithKind("NetworkPolicy").WithAPIVersion("networking.k8s.io/v1"),
+		ObjectMetaApplyConfiguration: v1.ObjectMeta().WithName(ingressPolicyName(name)).WithNamespace(labID),
+		Spec: networkingv1.NetworkPolicySpec().
+			WithPolicyTypes(apinetworkingv1.PolicyTypeIngress).
+			WithPodSelector(v1.LabelSelector().WithMatchLabels(podSelector)).
+			WithIngress(networkingv1.NetworkPolicyIngressRule().
+				WithFrom(networkingv1.NetworkPolicyPeer().WithNamespaceSelector(v1.LabelSelector().WithMatchLabels(map[string]string{
+					"kubernetes.io/metadata.name": k.ingressNamespace,
+				}))).
+				WithPorts(policyPorts...)),
+	}, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply ingress network policy").Err()
+	}
+
+	return nil
+}
+
+func (k *Kubernetes) DeleteIngressPolicy(ctx context.Context, labID, name string) error {
+	if err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Delete(ctx, ingressPolicyName(name), metaV1.DeleteOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to delete ingress network policy").Err()
+	}
+
+	return nil
+}
+
+// ApplyPublicPortsPolicy allows the selected lab pods to be reached from outside the cluster on the published ports
+func (k *Kubernetes) ApplyPublicPortsPolicy(ctx context.Context, labID, name string, podSelector map[string]string, ports []model.PortConfig) error {
+	peers := []*networkingv1.NetworkPolicyPeerApplyConfiguration{k.externalPeer("0.0.0.0/0")}
+	if k.podIPv6CIDR != "" {
+		peers = append(peers, k.externalPeer("::/0"))
+	}
+
+	if _, err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, &networkingv1.NetworkPolicyApplyConfiguration{
+		TypeMetaApplyConfiguration:   *v1.TypeMeta().WithKind("NetworkPolicy").WithAPIVersion("networking.k8s.io/v1"),
+		ObjectMetaApplyConfiguration: v1.ObjectMeta().WithName(publicPortsPolicyName(name)).WithNamespace(labID),
+		Spec: networkingv1.NetworkPolicySpec().
+			WithPolicyTypes(apinetworkingv1.PolicyTypeIngress).
+			WithPodSelector(v1.LabelSelector().WithMatchLabels(podSelector)).
+			WithIngress(networkingv1.NetworkPolicyIngressRule().
+				WithFrom(peers...).
+				WithPorts(networkPolicyPorts(ports)...)),
+	}, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply public ports network policy").Err()
+	}
+
+	return nil
+}
+
+func (k *Kubernetes) DeletePublicPortsPolicy(ctx context.Context, labID, name string) error {
+	if err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).Delete(ctx, publicPortsPolicyName(name), metaV1.DeleteOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to delete public ports network policy").Err()
+	}
+
+	return nil
+}
+
+// applyEgressPolicy applies the egress rules of the profile, the policy without rules denies the egress outside the lab
+func (k *Kubernetes) applyEgressPolicy(ctx context.Context, labID, name string, podSelector *v1.LabelSelectorApplyConfiguration, profile model.EgressProfile) error {
+	egress, err := k.egressRules(ctx, profile)
+	if err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to get egress rules").Err()
+	}
+
+	spec := networkingv1.NetworkPolicySpec().
+		WithPolicyTypes(apinetworkingv1.PolicyTypeEgress).
+		WithPodSelector(podSelector)
+	if len(egress) > 0 {
+		spec = spec.WithEgress(egress...)
+	}
+
+	// the domains policies are found and resolved again by the periodic refresh
+	meta := v1.ObjectMeta().WithName(name).WithNamespace(labID)
+	if profile.Mode == model.EgressModeDomains {
+		meta = meta.
+			WithLabels(map[string]string{config.PlatformLabel: config.LabEgressDomains}).
+			WithAnnotations(map[string]string{egressDomainsAnnotation: strings.Join(profile.Domains, ",")})
+	}
+
+	if _, err = k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, &networkingv1.NetworkPolicyApplyConfiguration{
+		TypeMetaApplyConfiguration:   *v1.TypeMeta().WithKind("NetworkPolicy").WithAPIVersion("networking.k8s.io/v1"),
+		ObjectMetaApplyConfiguration: meta,
+		Spec:                         spec,
+	}, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply egress policy").Err()
+	}
+
+	return nil
+}
+
+// egressRules returns the egress rules of the profile, the domains are resolved to the addresses by the lab DNS upstreams
+// when the policy is applied and again by the periodic refresh
+func (k *Kubernetes) egressRules(ctx context.Context, profile model.EgressProfile) ([]*networkingv1.NetworkPolicyEgressRuleApplyConfiguration, error) {
+	switch profile.Mode {
+	case model.EgressModeInternet:
+		peers := []*networkingv1.NetworkPolicyPeerApplyConfiguration{k.externalPeer("0.0.0.0/0")}
+		if k.podIPv6CIDR != "" {
+			peers = append(peers, k.externalPeer("::/0"))
+		}
+		return []*networkingv1.NetworkPolicyEgressRuleApplyConfiguration{networkingv1.NetworkPolicyEgressRule().WithTo(peers...)}, nil
+	case model.EgressModeCIDRs:
+		peers := make([]*networkingv1.NetworkPolicyPeerApplyConfiguration, 0, len(profile.CIDRs))
+		for _, cidr := range profile.CIDRs {
+			peers = append(peers, k.externalPeer(cidr))
+		}
+		// the rule without peers would allow all the egress
+		if len(peers) == 0 {
+			return nil, fmt.Errorf("egress CIDRs are empty")
+		}
+		return []*networkingv1.NetworkPolicyEgressRuleApplyConfiguration{networkingv1.NetworkPolicyEgressRule().WithTo(peers...)}, nil
+	case model.EgressModeDomains:
+		peers := make([]*networkingv1.NetworkPolicyPeerApplyConfiguration, 0, len(profile.Domains))
+		for _, domain := range profile.Domains {
+			network := "ip4"
+			if k.podIPv6CIDR != "" {
+				network = "ip"
+			}
+			addresses, err := k.resolver.LookupIP(ctx, network, domain)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve domain %s: %w", domain, err)
+			}
+			if len(addresses) == 0 {
+				return nil, fmt.Errorf("domain %s resolves to no addresses", domain)
+			}
+			for _, address := range addresses {
+				peers = append(peers, networkingv1.NetworkPolicyPeer().WithIPBlock(networkingv1.IPBlock().WithCIDR(hostCIDR(address))))
+			}
+		}
+		// the rule without peers would allow all the egress
+		if len(peers) == 0 {
+			return nil, fmt.Errorf("egress domains resolve to no addresses")
+		}
+		return []*networkingv1.NetworkPolicyEgressRuleApplyConfiguration{networkingv1.NetworkPolicyEgressRule().WithTo(peers...)}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// refreshEgressDomainsPeriodically resolves the domains of the egress policies again every refresh interval
+func (k *Kubernetes) refreshEgressDomainsPeriodically(ctx context.Context) {
+	ticker := time.NewTicker(k.egressDomainsRefresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := k.refreshEgressDomainsPolicies(ctx); err != nil {
+				log.Error().Err(err).Msg("Failed to refresh egress domains policies")
+			}
+		}
+	}
+}
+
+// refreshEgressDomainsPolicies applies the current addresses of the domains to the egress policies with the changed addresses,
+// the policy changed since it was listed is skipped by the resource version, so the new profile is not replaced
+func (k *Kubernetes) refreshEgressDomainsPolicies(ctx context.Context) (errs error) {
+	policies, err := k.kubeClient.NetworkingV1().NetworkPolicies(metaV1.NamespaceAll).List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", config.PlatformLabel, config.LabEgressDomains),
+	})
+	if err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to get egress domains policies").Err()
+	}
+
+	for i := range policies.Items {
+		item := &policies.Items[i]
+
+		domains := item.GetAnnotations()[egressDomainsAnnotation]
+		if domains == "" {
+			continue
+		}
+
+		egress, err := k.egressRules(ctx, model.EgressProfile{Mode: model.EgressModeDomains, Domains: strings.Split(domains, ",")})
+		if err != nil {
+			errs = multierror.Append(errs, appError.ErrKubernetes.WithError(err).WithMessage("Failed to resolve egress domains").WithContext("labID", item.GetNamespace()).WithContext("policy", item.GetName()).Err())
+			continue
+		}
+
+		if slices.Equal(appliedEgressCIDRs(item.Spec.Egress), egressCIDRs(egress)) {
+			continue
+		}
+
+		policy, err := networkingv1.ExtractNetworkPolicy(item, "application/apply-patch")
+		if err != nil {
+			errs = multierror.Append(errs, appError.ErrKubernetes.WithError(err).WithMessage("Failed to extract network policy").WithContext("labID", item.GetNamespace()).WithContext("policy", item.GetName()).Err())
+			continue
+		}
+		policy.WithResourceVersion(item.GetResourceVersion())
+		policy.Spec.Egress = nil
+		policy.Spec.WithEgress(egress...)
+
+		if _, err = k.kubeClient.NetworkingV1().NetworkPolicies(item.GetNamespace()).Apply(ctx, policy, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil && !errors.IsConflict(err) {
+			errs = multierror.Append(errs, appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply egress domains policy").WithContext("labID", item.GetNamespace()).WithContext("policy", item.GetName()).Err())
+		}
+	}
+
+	return
+}
+
+// SetPodCIDRs replaces the lab pods networks excepted from the external peers, the applied policies keep the previous networks until they are refreshed
+func (k *Kubernetes) SetPodCIDRs(cidrs []string) {
+	k.podCIDRsMutex.Lock()
+	defer k.podCIDRsMutex.Unlock()
+
+	k.podCIDRs = slices.Clone(cidrs)
+}
+
+// RefreshExternalPolicies applies the current lab pods networks to the external peers of the lab network policies
+func (k *Kubernetes) RefreshExternalPolicies(ctx context.Context, labID string) error {
+	policies, err := k.kubeClient.NetworkingV1().NetworkPolicies(labID).List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to list network policies").Err()
+	}
+
+	podCIDRs := k.getPodCIDRs()
+
+	for i := range policies.Items {
+		policy, err := networkingv1.ExtractNetworkPolicy(&policies.Items[i], "application/apply-patch")
+		if err != nil {
+			return appError.ErrKubernetes.WithError(err).WithMessage("Failed to extract network policy").WithContext("policy", policies.Items[i].Name).Err()
+		}
+		if policy.Spec == nil {
+			continue
+		}
+
+		changed := false
+		for _, rule := range policy.Spec.Ingress {
+			for _, peer := range rule.From {
+				changed = refreshExcept(peer, podCIDRs) || changed
+			}
+		}
+		for _, rule := range policy.Spec.Egress {
+			for _, peer := range rule.To {
+				changed = refreshExcept(peer, podCIDRs) || changed
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		if _, err = k.kubeClient.NetworkingV1().NetworkPolicies(labID).Apply(ctx, policy, metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+			return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply network policy").WithContext("policy", policies.Items[i].Name).Err()
+		}
+	}
+
+	return nil
+}
+
+// externalPeer selects the addresses of the CIDR outside the lab pods networks,
+// only the pods networks inside the CIDR are excepted because the except outside the CIDR is rejected by the API
+func (k *Kubernetes) externalPeer(cidr string) *networkingv1.NetworkPolicyPeerApplyConfiguration {
+	block := networkingv1.IPBlock().WithCIDR(cidr)
+	if except := exceptCIDRs(cidr, k.getPodCIDRs()...); len(except) > 0 {
+		block = block.WithExcept(except...)
+	}
+	return networkingv1.NetworkPolicyPeer().WithIPBlock(block)
+}
+
+func (k *Kubernetes) getPodCIDRs() []string {
+	k.podCIDRsMutex.RLock()
+	defer k.podCIDRsMutex.RUnlock()
+
+	return append(slices.Clone(k.podCIDRs), k.podIPv6CIDR)
+}
+
+// refreshExcept replaces the except of the peer ip block by the pods networks inside the block, it reports whether the except is changed
+func refreshExcept(peer networkingv1.NetworkPolicyPeerApplyConfiguration, podCIDRs []string) bool {
+	if peer.IPBlock == nil || peer.IPBlock.CIDR == nil {
+		return false
+	}
+
+	except := exceptCIDRs(*peer.IPBlock.CIDR, podCIDRs...)
+	if slices.Equal(except, peer.IPBlock.Except) {
+		return false
+	}
+
+	peer.IPBlock.Except = except
+	return true
+}
+
+// groupLabsPeer selects the namespaces of the group labs
+func groupLabsPeer(groupID string) *networkingv1.NetworkPolicyPeerApplyConfiguration {
+	return networkingv1.NetworkPolicyPeer().WithNamespaceSelector(v1.LabelSelector().WithMatchLabels(map[string]string{
+		config.PlatformLabel:   config.Lab,
+		config.LabGroupIDLabel: groupID,
+	}))
+}
+
+// appliedEgressCIDRs returns the sorted ip blocks of the applied egress rules
+func appliedEgressCIDRs(rules []apinetworkingv1.NetworkPolicyEgressRule) []string {
+	cidrs := make([]string, 0)
+	for _, rule := range rules {
+		for _, peer := range rule.To {
+			if peer.IPBlock != nil {
+				cidrs = append(cidrs, peer.IPBlock.CIDR)
+			}
+		}
+	}
+	slices.Sort(cidrs)
+	return cidrs
+}
+
+// egressCIDRs returns the sorted ip blocks of the egress rules
+func egressCIDRs(rules []*networkingv1.NetworkPolicyEgressRuleApplyConfiguration) []string {
+	cidrs := make([]string, 0)
+	for _, rule := range rules {
+		for _, peer := range rule.To {
+			if peer.IPBlock != nil && peer.IPBlock.CIDR != nil {
+				cidrs = append(cidrs, *peer.IPBlock.CIDR)
+			}
+		}
+	}
+	slices.Sort(cidrs)
+	return cidrs
+}
+
+func notSegmentedSelector() *v1.LabelSelectorApplyConfiguration {
+	return v1.LabelSelector().WithMatchExpressions(withoutLabel(config.SegmentedLabel))
+}
+
+func withoutLabel(key string) *v1.LabelSelectorRequirementApplyConfiguration {
+	return v1.LabelSelectorRequirement().
+		WithKey(key).
+		WithOperator(metaV1.LabelSelectorOpDoesNotExist)
+}
+
+// instancesPeer selects the lab instances, empty instances select all lab pods
+func instancesPeer(instanceIDs []string) *networkingv1.NetworkPolicyPeerApplyConfiguration {
+	if len(instanceIDs) == 0 {
+		return networkingv1.NetworkPolicyPeer().WithPodSelector(v1.LabelSelector())
+	}
+	return networkingv1.NetworkPolicyPeer().WithPodSelector(v1.LabelSelector().WithMatchExpressions(v1.LabelSelectorRequirement().
+		WithKey(config.InstanceIDLabel).
+		WithOperator(metaV1.LabelSelectorOpIn).
+		WithValues(instanceIDs...)))
+}
+
+func networkPolicyPorts(ports []model.PortConfig) []*networkingv1.NetworkPolicyPortApplyConfiguration {
+	policyPorts := make([]*networkingv1.NetworkPolicyPortApplyConfiguration, 0, len(ports))
+	for _, port := range ports {
+		protocol := coreV1.ProtocolTCP
+		if port.Protocol != "" {
+			protocol = coreV1.Protocol(port.Protocol)
+		}
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort().
+			WithProtocol(protocol).
+			WithPort(intstr.FromInt32(port.Port)))
+	}
+	return policyPorts
+}
+
+func instanceRulesPolicyName(instanceID string) string {
+	return fmt.Sprintf("%s-%s", instanceRulesPolicyPrefix, instanceID)
+}
+
+func ingressPolicyName(name string) string {
+	return fmt.Sprintf("%s-%s", ingressPolicyPrefix, name)
+}
+
+func publicPortsPolicyName(name string) string {
+	return fmt.Sprintf("%s-%s", publicPortsPolicyPrefix, name)
+}
+
+func instanceEgressPolicyName(instanceID string) string {
+	return fmt.Sprintf("%s-%s", labEgressPolicyName, instanceID)
+}
+
+// exceptCIDRs returns the CIDRs contained in the cidr
+func exceptCIDRs(cidr string, cidrs ...string) []string {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil
+	}
+	prefix = prefix.Masked()
+
+	except := make([]string, 0, len(cidrs))
+	for _, c := range cidrs {
+		p, err := netip.ParsePrefix(c)
+		if err != nil {
+			continue
+		}
+		if p.Addr().Is4() == prefix.Addr().Is4() && p.Bits() >= prefix.Bits() && prefix.Contains(p.Addr()) {
+			except = append(except, p.Masked().String())
+		}
+	}
+	return except
+}
+
+func hostCIDR(ip net.IP) string {
+	if ip.To4() != nil {
+		return fmt.Sprintf("%s/32", ip.String())
+	}
+	return fmt.Sprintf("%s/128", ip.String())
+}
@@ -0,0 +1,108 @@
+package k8s
+
+import (
+	"context"
+	"github.com/cybericebox/agent/internal/config"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"strings"
+)
+
+const (
+	labQuotaName      = "lab-quota"
+	labLimitRangeName = "lab-limits"
+)
+
+// ApplyResourceQuota applies the lab resource quota and the default container limits to the lab namespace
+func (k *Kubernetes) ApplyResourceQuota(ctx context.Context, labID string, quota model.ResourceQuotaConfig) error {
+	labels := map[string]string{
+		config.PlatformLabel: config.LabQuota,
+		config.LabIDLabel:    labID,
+	}
+
+	// the quota bounds the requests only, the limits are above them by the group overcommit ratios
+	hard := coreV1.ResourceList{}
+	if quota.CPU != 0 {
+		hard[coreV1.ResourceRequestsCPU] = *resource.NewMilliQuantity(quota.CPU, resource.DecimalSI)
+	}
+	if quota.Memory != 0 {
+		hard[coreV1.ResourceRequestsMemory] = *resource.NewQuantity(quota.Memory, resource.BinarySI)
+	}
+	if quota.Pods != 0 {
+		hard[coreV1.ResourcePods] = *resource.NewQuantity(quota.Pods, resource.DecimalSI)
+	}
+	if quota.EphemeralStorage != 0 {
+		hard[coreV1.ResourceRequestsEphemeralStorage] = *resource.NewQuantity(quota.EphemeralStorage, resource.BinarySI)
+	}
+
+	if _, err := k.kubeClient.CoreV1().ResourceQuotas(labID).Apply(ctx,
+		v1.ResourceQuota(labQuotaName, labID).WithLabels(labels).
+			WithSpec(v1.ResourceQuotaSpec().WithHard(hard)),
+		metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply resource quota").Err()
+	}
+
+	// containers without resources get the default ones, otherwise they are rejected by the quota
+	defaults := coreV1.ResourceList{
+		coreV1.ResourceCPU:    *resource.NewMilliQuantity(k.defaultContainerCPU, resource.DecimalSI),
+		coreV1.ResourceMemory: *resource.NewQuantity(k.defaultContainerMemory, resource.BinarySI),
+	}
+	if quota.EphemeralStorage != 0 {
+		defaults[coreV1.ResourceEphemeralStorage] = *resource.NewQuantity(k.defaultContainerEphemeralStorage, resource.BinarySI)
+	}
+
+	if _, err := k.kubeClient.CoreV1().LimitRanges(labID).Apply(ctx,
+		v1.LimitRange(labLimitRangeName, labID).WithLabels(labels).
+			WithSpec(v1.LimitRangeSpec().WithLimits(v1.LimitRangeItem().
+				WithType(coreV1.LimitTypeContainer).
+				WithDefault(defaults).
+				WithDefaultRequest(defaults))),
+		metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply limit range").Err()
+	}
+
+	return nil
+}
+
+func (k *Kubernetes) GetResourceQuotasBySelector(ctx context.Context, labID string, selector ...string) ([]model.ResourceQuotaStatus, error) {
+	labelSelector := strings.Join(selector, ",")
+
+	quotas, err := k.kubeClient.CoreV1().ResourceQuotas(labID).List(ctx, metaV1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, appError.ErrKubernetes.WithError(err).WithMessage("Failed to get resource quotas").Err()
+	}
+
+	quotasStatus := make([]model.ResourceQuotaStatus, 0, len(quotas.Items))
+	for _, q := range quotas.Items {
+		quotasStatus = append(quotasStatus, model.ResourceQuotaStatus{
+			Labels: q.GetLabels(),
+			Hard:   quotaFromResourceList(q.Status.Hard),
+			Used:   quotaFromResourceList(q.Status.Used),
+		})
+	}
+
+	return quotasStatus, nil
+}
+
+func quotaFromResourceList(list coreV1.ResourceList) model.ResourceQuotaConfig {
+	quota := model.ResourceQuotaConfig{}
+	if cpu, ok := list[coreV1.ResourceRequestsCPU]; ok {
+		quota.CPU = cpu.MilliValue()
+	}
+	if memory, ok := list[coreV1.ResourceRequestsMemory]; ok {
+		quota.Memory = memory.Value()
+	}
+	if pods, ok := list[coreV1.ResourcePods]; ok {
+		quota.Pods = pods.Value()
+	}
+	if storage, ok := list[coreV1.ResourceRequestsEphemeralStorage]; ok {
+		quota.EphemeralStorage = storage.Value()
+	}
+	return quota
+}
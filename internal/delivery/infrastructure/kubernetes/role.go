@@ -0,0 +1,40 @@
+package k8s
+
+import (
+	"context"
+	"github.com/cybericebox/agent/pkg/appError"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rbacV1 "k8s.io/client-go/applyconfigurations/rbac/v1"
+)
+
+// ApplySecretReaderRole allows the service account of another namespace to get only the named secret of the lab
+func (k *Kubernetes) ApplySecretReaderRole(ctx context.Context, name, labID, secretName, serviceAccountNamespace, serviceAccount string, labels map[string]string) error {
+	if _, err := k.kubeClient.RbacV1().Roles(labID).Apply(ctx,
+		rbacV1.Role(name, labID).
+			WithLabels(labels).
+			WithRules(rbacV1.PolicyRule().
+				WithAPIGroups("").
+				WithResources("secrets").
+				WithResourceNames(secretName).
+				WithVerbs("get")),
+		metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply secret reader role").Err()
+	}
+
+	if _, err := k.kubeClient.RbacV1().RoleBindings(labID).Apply(ctx,
+		rbacV1.RoleBinding(name, labID).
+			WithLabels(labels).
+			WithRoleRef(rbacV1.RoleRef().
+				WithAPIGroup("rbac.authorization.k8s.io").
+				WithKind("Role").
+				WithName(name)).
+			WithSubjects(rbacV1.Subject().
+				WithKind("ServiceAccount").
+				WithName(serviceAccount).
+				WithNamespace(serviceAccountNamespace)),
+		metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply secret reader role binding").Err()
+	}
+
+	return nil
+}
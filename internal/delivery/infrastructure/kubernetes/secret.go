@@ -0,0 +1,44 @@
+package k8s
+
+import (
+	"context"
+	"github.com/cybericebox/agent/pkg/appError"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+func (k *Kubernetes) ApplySecret(ctx context.Context, name, labID string, labels, data map[string]string) error {
+	if _, err := k.kubeClient.CoreV1().Secrets(labID).Apply(ctx,
+		v1.Secret(name, labID).WithLabels(labels).WithStringData(data),
+		metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply secret").Err()
+	}
+
+	return nil
+}
+
+func (k *Kubernetes) GetSecretData(ctx context.Context, name, labID string) (map[string]string, error) {
+	get, err := k.kubeClient.CoreV1().Secrets(labID).Get(ctx, name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, appError.ErrKubernetes.WithError(err).WithMessage("Failed to get secret data").Err()
+	}
+
+	data := make(map[string]string, len(get.Data))
+	for key, value := range get.Data {
+		data[key] = string(value)
+	}
+
+	return data, nil
+}
+
+func (k *Kubernetes) DeleteSecret(ctx context.Context, name, labID string) error {
+	if err := k.kubeClient.CoreV1().Secrets(labID).Delete(ctx, name, metaV1.DeleteOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to delete secret").Err()
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package k8s
+
+import (
+	"context"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+func (k *Kubernetes) ApplyService(ctx context.Context, cfg model.ApplyServiceConfig) error {
+	serviceType := coreV1.ServiceTypeClusterIP
+	if cfg.Type != "" {
+		serviceType = coreV1.ServiceType(cfg.Type)
+	}
+
+	ports := make([]*v1.ServicePortApplyConfiguration, 0, len(cfg.Ports))
+	for _, port := range cfg.Ports {
+		protocol := coreV1.ProtocolTCP
+		if port.Protocol != "" {
+			protocol = coreV1.Protocol(port.Protocol)
+		}
+		targetPort := port.Port
+		if port.TargetPort != 0 {
+			targetPort = port.TargetPort
+		}
+		servicePort := v1.ServicePort().
+			WithName(port.Name).
+			WithProtocol(protocol).
+			WithPort(port.Port).
+			WithTargetPort(intstr.FromInt32(targetPort))
+		if port.NodePort != 0 {
+			servicePort = servicePort.WithNodePort(port.NodePort)
+		}
+		ports = append(ports, servicePort)
+	}
+
+	spec := v1.ServiceSpec().
+		WithType(serviceType).
+		WithSelector(cfg.Selector).
+		WithPorts(ports...)
+	if cfg.Headless && serviceType == coreV1.ServiceTypeClusterIP {
+		spec = spec.WithClusterIP(coreV1.ClusterIPNone)
+	}
+
+	if _, err := k.kubeClient.CoreV1().Services(cfg.LabID).Apply(ctx,
+		v1.Service(cfg.Name, cfg.LabID).WithLabels(cfg.Labels).
+			WithSpec(spec),
+		metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply service").Err()
+	}
+
+	return nil
+}
+
+func (k *Kubernetes) GetService(ctx context.Context, name, labID string) (*model.ServiceStatus, error) {
+	svc, err := k.kubeClient.CoreV1().Services(labID).Get(ctx, name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, appError.ErrKubernetes.WithError(err).WithMessage("Failed to get service").Err()
+	}
+
+	status := &model.ServiceStatus{
+		Name:      svc.GetName(),
+		Type:      string(svc.Spec.Type),
+		ClusterIP: svc.Spec.ClusterIP,
+	}
+
+	for _, port := range svc.Spec.Ports {
+		status.Ports = append(status.Ports, model.ServicePort{
+			Name:       port.Name,
+			Protocol:   string(port.Protocol),
+			Port:       port.Port,
+			TargetPort: port.TargetPort.IntVal,
+			NodePort:   port.NodePort,
+		})
+	}
+
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			status.ExternalAddresses = append(status.ExternalAddresses, ingress.IP)
+			continue
+		}
+		if ingress.Hostname != "" {
+			status.ExternalAddresses = append(status.ExternalAddresses, ingress.Hostname)
+		}
+	}
+
+	return status, nil
+}
+
+func (k *Kubernetes) DeleteService(ctx context.Context, name, labID string) error {
+	if err := k.kubeClient.CoreV1().Services(labID).Delete(ctx, name, metaV1.DeleteOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to delete service").Err()
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package k8s
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/cybericebox/agent/internal/config"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"strings"
+)
+
+// sshPipeResource is the sshpiper pipe, the shared SSH router watches the pipes of all namespaces
+var sshPipeResource = schema.GroupVersionResource{Group: "sshpiper.com", Version: "v1beta1", Resource: "pipes"}
+
+func (k *Kubernetes) ApplySSHPipe(ctx context.Context, labID string, pipe model.SSHPipe) error {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": sshPipeResource.GroupVersion().String(),
+		"kind":       "Pipe",
+		"metadata": map[string]interface{}{
+			"name":      pipe.Name,
+			"namespace": labID,
+			"labels": map[string]interface{}{
+				config.PlatformLabel: config.LabSSHPipe,
+				config.LabIDLabel:    labID,
+			},
+		},
+		"spec": map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{
+					"username":             pipe.Username,
+					"authorized_keys_data": base64.StdEncoding.EncodeToString([]byte(strings.Join(pipe.AuthorizedKeys, "\n") + "\n")),
+				},
+			},
+			"to": map[string]interface{}{
+				"host":     pipe.Host,
+				"username": pipe.UpstreamUsername,
+				// the bastion is reached by the lab address only
+				"ignore_hostkey": true,
+				"private_key_secret": map[string]interface{}{
+					"name": pipe.PrivateKeySecret,
+				},
+			},
+		},
+	}}
+
+	if _, err := k.dynamicClient.Resource(sshPipeResource).Namespace(labID).Apply(ctx, pipe.Name, obj,
+		metaV1.ApplyOptions{FieldManager: "application/apply-patch"}); err != nil {
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to apply ssh pipe").Err()
+	}
+
+	return nil
+}
+
+// GetSSHPipe returns the pipe of the lab, the nil pipe means the pipe does not exist
+func (k *Kubernetes) GetSSHPipe(ctx context.Context, labID, name string) (*model.SSHPipe, error) {
+	obj, err := k.dynamicClient.Resource(sshPipeResource).Namespace(labID).Get(ctx, name, metaV1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, appError.ErrKubernetes.WithError(err).WithMessage("Failed to get ssh pipe").Err()
+	}
+
+	pipe := &model.SSHPipe{Name: obj.GetName()}
+
+	from, _, _ := unstructured.NestedSlice(obj.Object, "spec", "from")
+	if len(from) > 0 {
+		if f, ok := from[0].(map[string]interface{}); ok {
+			pipe.Username, _, _ = unstructured.NestedString(f, "username")
+			keysData, _, _ := unstructured.NestedString(f, "authorized_keys_data")
+			keys, err := base64.StdEncoding.DecodeString(keysData)
+			if err != nil {
+				return nil, appError.ErrKubernetes.WithError(err).WithMessage("Failed to decode ssh pipe authorized keys").Err()
+			}
+			for _, key := range strings.Split(string(keys), "\n") {
+				if key = strings.TrimSpace(key); key != "" {
+					pipe.AuthorizedKeys = append(pipe.AuthorizedKeys, key)
+				}
+			}
+		}
+	}
+
+	pipe.Host, _, _ = unstructured.NestedString(obj.Object, "spec", "to", "host")
+	pipe.UpstreamUsername, _, _ = unstructured.NestedString(obj.Object, "spec", "to", "username")
+	pipe.PrivateKeySecret, _, _ = unstructured.NestedString(obj.Object, "spec", "to", "private_key_secret", "name")
+
+	return pipe, nil
+}
+
+func (k *Kubernetes) DeleteSSHPipe(ctx context.Context, labID, name string) error {
+	if err := k.dynamicClient.Resource(sshPipeResource).Namespace(labID).Delete(ctx, name, metaV1.DeleteOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return appError.ErrKubernetes.WithError(err).WithMessage("Failed to delete ssh pipe").Err()
+	}
+
+	return nil
+}
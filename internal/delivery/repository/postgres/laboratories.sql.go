@@ -10,21 +10,67 @@ import (
 	"net/netip"
 
 	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countLaboratories = `-- name: CountLaboratories :one
+select count(*)
+from laboratories
+where group_id = $1
+`
+
+func (q *Queries) CountLaboratories(ctx context.Context, groupID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countLaboratories, groupID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createLaboratory = `-- name: CreateLaboratory :exec
-insert into laboratories (id, group_id, cidr)
-values ($1, $2, $3)
+insert into laboratories (id, group_id, cidr, cpu_quota, memory_quota, pods_quota, metadata, isolation_mode,
+                          egress_profile, segments, ipv6_cidr, cidr_pool, vpn_gateway, ssh_bastion, attack_box,
+                          ephemeral_storage_quota)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 `
 
 type CreateLaboratoryParams struct {
-	ID      uuid.UUID    `json:"id"`
-	GroupID uuid.UUID    `json:"group_id"`
-	Cidr    netip.Prefix `json:"cidr"`
+	ID                    uuid.UUID     `json:"id"`
+	GroupID               uuid.UUID     `json:"group_id"`
+	Cidr                  netip.Prefix  `json:"cidr"`
+	CpuQuota              int64         `json:"cpu_quota"`
+	MemoryQuota           int64         `json:"memory_quota"`
+	PodsQuota             int64         `json:"pods_quota"`
+	Metadata              []byte        `json:"metadata"`
+	IsolationMode         int32         `json:"isolation_mode"`
+	EgressProfile         []byte        `json:"egress_profile"`
+	Segments              []byte        `json:"segments"`
+	Ipv6Cidr              *netip.Prefix `json:"ipv6_cidr"`
+	CidrPool              string        `json:"cidr_pool"`
+	VpnGateway            int32         `json:"vpn_gateway"`
+	SshBastion            bool          `json:"ssh_bastion"`
+	AttackBox             []byte        `json:"attack_box"`
+	EphemeralStorageQuota int64         `json:"ephemeral_storage_quota"`
 }
 
 func (q *Queries) CreateLaboratory(ctx context.Context, arg CreateLaboratoryParams) error {
-	_, err := q.db.Exec(ctx, createLaboratory, arg.ID, arg.GroupID, arg.Cidr)
+	_, err := q.db.Exec(ctx, createLaboratory,
+		arg.ID,
+		arg.GroupID,
+		arg.Cidr,
+		arg.CpuQuota,
+		arg.MemoryQuota,
+		arg.PodsQuota,
+		arg.Metadata,
+		arg.IsolationMode,
+		arg.EgressProfile,
+		arg.Segments,
+		arg.Ipv6Cidr,
+		arg.CidrPool,
+		arg.VpnGateway,
+		arg.SshBastion,
+		arg.AttackBox,
+		arg.EphemeralStorageQuota,
+	)
 	return err
 }
 
@@ -42,8 +88,40 @@ func (q *Queries) DeleteLaboratory(ctx context.Context, id uuid.UUID) (int64, er
 	return result.RowsAffected(), nil
 }
 
+const getLaboratory = `-- name: GetLaboratory :one
+select id, group_id, cidr, updated_at, created_at, cpu_quota, memory_quota, pods_quota, metadata, isolation_mode, egress_profile, segments, ipv6_cidr, cidr_pool, vpn_gateway, ssh_bastion, attack_box, ephemeral_storage_quota
+from laboratories
+where id = $1
+`
+
+func (q *Queries) GetLaboratory(ctx context.Context, id uuid.UUID) (Laboratory, error) {
+	row := q.db.QueryRow(ctx, getLaboratory, id)
+	var i Laboratory
+	err := row.Scan(
+		&i.ID,
+		&i.GroupID,
+		&i.Cidr,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.CpuQuota,
+		&i.MemoryQuota,
+		&i.PodsQuota,
+		&i.Metadata,
+		&i.IsolationMode,
+		&i.EgressProfile,
+		&i.Segments,
+		&i.Ipv6Cidr,
+		&i.CidrPool,
+		&i.VpnGateway,
+		&i.SshBastion,
+		&i.AttackBox,
+		&i.EphemeralStorageQuota,
+	)
+	return i, err
+}
+
 const getLaboratories = `-- name: GetLaboratories :many
-select id, group_id, cidr, updated_at, created_at
+select id, group_id, cidr, updated_at, created_at, cpu_quota, memory_quota, pods_quota, metadata, isolation_mode, egress_profile, segments, ipv6_cidr, cidr_pool, vpn_gateway, ssh_bastion, attack_box, ephemeral_storage_quota
 from laboratories
 where group_id = coalesce($1, group_id)
 `
@@ -63,6 +141,19 @@ func (q *Queries) GetLaboratories(ctx context.Context, groupID uuid.NullUUID) ([
 			&i.Cidr,
 			&i.UpdatedAt,
 			&i.CreatedAt,
+			&i.CpuQuota,
+			&i.MemoryQuota,
+			&i.PodsQuota,
+			&i.Metadata,
+			&i.IsolationMode,
+			&i.EgressProfile,
+			&i.Segments,
+			&i.Ipv6Cidr,
+			&i.CidrPool,
+			&i.VpnGateway,
+			&i.SshBastion,
+			&i.AttackBox,
+			&i.EphemeralStorageQuota,
 		); err != nil {
 			return nil, err
 		}
@@ -73,3 +164,98 @@ func (q *Queries) GetLaboratories(ctx context.Context, groupID uuid.NullUUID) ([
 	}
 	return items, nil
 }
+
+const updateLaboratoryIsolationMode = `-- name: UpdateLaboratoryIsolationMode :execrows
+update laboratories
+set isolation_mode = $2,
+    updated_at     = now()
+where id = $1
+`
+
+type UpdateLaboratoryIsolationModeParams struct {
+	ID            uuid.UUID `json:"id"`
+	IsolationMode int32     `json:"isolation_mode"`
+}
+
+func (q *Queries) UpdateLaboratoryIsolationMode(ctx context.Context, arg UpdateLaboratoryIsolationModeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateLaboratoryIsolationMode, arg.ID, arg.IsolationMode)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateLaboratoryEgressProfile = `-- name: UpdateLaboratoryEgressProfile :execrows
+update laboratories
+set egress_profile = $2,
+    updated_at     = now()
+where id = $1
+`
+
+type UpdateLaboratoryEgressProfileParams struct {
+	ID            uuid.UUID `json:"id"`
+	EgressProfile []byte    `json:"egress_profile"`
+}
+
+func (q *Queries) UpdateLaboratoryEgressProfile(ctx context.Context, arg UpdateLaboratoryEgressProfileParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateLaboratoryEgressProfile, arg.ID, arg.EgressProfile)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateLaboratoryGroup = `-- name: UpdateLaboratoryGroup :execrows
+update laboratories
+set group_id                = $2,
+    cpu_quota               = coalesce($3, cpu_quota),
+    memory_quota            = coalesce($4, memory_quota),
+    pods_quota              = coalesce($5, pods_quota),
+    ephemeral_storage_quota = coalesce($6, ephemeral_storage_quota),
+    updated_at              = now()
+where id = $1
+`
+
+type UpdateLaboratoryGroupParams struct {
+	ID                    uuid.UUID   `json:"id"`
+	GroupID               uuid.UUID   `json:"group_id"`
+	CpuQuota              pgtype.Int8 `json:"cpu_quota"`
+	MemoryQuota           pgtype.Int8 `json:"memory_quota"`
+	PodsQuota             pgtype.Int8 `json:"pods_quota"`
+	EphemeralStorageQuota pgtype.Int8 `json:"ephemeral_storage_quota"`
+}
+
+func (q *Queries) UpdateLaboratoryGroup(ctx context.Context, arg UpdateLaboratoryGroupParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateLaboratoryGroup,
+		arg.ID,
+		arg.GroupID,
+		arg.CpuQuota,
+		arg.MemoryQuota,
+		arg.PodsQuota,
+		arg.EphemeralStorageQuota,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateLaboratoryMetadata = `-- name: UpdateLaboratoryMetadata :execrows
+update laboratories
+set metadata   = $2,
+    updated_at = now()
+where id = $1
+`
+
+type UpdateLaboratoryMetadataParams struct {
+	ID       uuid.UUID `json:"id"`
+	Metadata []byte    `json:"metadata"`
+}
+
+func (q *Queries) UpdateLaboratoryMetadata(ctx context.Context, arg UpdateLaboratoryMetadataParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateLaboratoryMetadata, arg.ID, arg.Metadata)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
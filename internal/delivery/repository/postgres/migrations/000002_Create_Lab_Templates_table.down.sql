@@ -0,0 +1 @@
+drop table if exists lab_templates;
\ No newline at end of file
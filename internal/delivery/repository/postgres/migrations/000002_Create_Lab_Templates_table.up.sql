@@ -0,0 +1,14 @@
+create table if not exists lab_templates
+(
+    id         uuid        not null primary key,
+    name       text        not null,
+    version    integer     not null,
+
+    challenges jsonb       not null,
+
+    updated_at timestamptz,
+
+    created_at timestamptz not null default now(),
+
+    unique (name, version)
+);
\ No newline at end of file
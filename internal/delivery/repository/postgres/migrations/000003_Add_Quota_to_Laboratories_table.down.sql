@@ -0,0 +1,4 @@
+alter table laboratories
+    drop column if exists cpu_quota,
+    drop column if exists memory_quota,
+    drop column if exists pods_quota;
\ No newline at end of file
@@ -0,0 +1,4 @@
+alter table laboratories
+    add column if not exists cpu_quota    bigint not null default 0,
+    add column if not exists memory_quota bigint not null default 0,
+    add column if not exists pods_quota   bigint not null default 0;
\ No newline at end of file
@@ -0,0 +1 @@
+drop table if exists lab_groups;
\ No newline at end of file
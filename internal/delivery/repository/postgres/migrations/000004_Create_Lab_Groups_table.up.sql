@@ -0,0 +1,21 @@
+create table if not exists lab_groups
+(
+    id           uuid        not null primary key,
+    name         text        not null,
+    description  text        not null default '',
+
+    starts_at    timestamptz,
+    ends_at      timestamptz,
+
+    cidr_mask    integer     not null default 0,
+    cpu_quota    bigint      not null default 0,
+    memory_quota bigint      not null default 0,
+    pods_quota   bigint      not null default 0,
+    max_labs     integer     not null default 0,
+
+    labels       jsonb       not null default '{}',
+
+    updated_at   timestamptz,
+
+    created_at   timestamptz not null default now()
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+alter table laboratories
+    drop column if exists isolation_mode;
+
+alter table lab_groups
+    drop column if exists isolation_mode;
\ No newline at end of file
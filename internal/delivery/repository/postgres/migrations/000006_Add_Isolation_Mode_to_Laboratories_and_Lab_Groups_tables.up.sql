@@ -0,0 +1,5 @@
+alter table laboratories
+    add column if not exists isolation_mode integer not null default 0;
+
+alter table lab_groups
+    add column if not exists isolation_mode integer not null default 0;
\ No newline at end of file
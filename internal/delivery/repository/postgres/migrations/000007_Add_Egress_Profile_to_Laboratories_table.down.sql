@@ -0,0 +1,2 @@
+alter table laboratories
+    drop column if exists egress_profile;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table laboratories
+    add column if not exists egress_profile jsonb not null default '{}';
\ No newline at end of file
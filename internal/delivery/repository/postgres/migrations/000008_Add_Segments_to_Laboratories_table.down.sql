@@ -0,0 +1,2 @@
+alter table laboratories
+    drop column if exists segments;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table laboratories
+    add column if not exists segments jsonb not null default '[]';
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table laboratories
+    drop column if exists ipv6_cidr;
\ No newline at end of file
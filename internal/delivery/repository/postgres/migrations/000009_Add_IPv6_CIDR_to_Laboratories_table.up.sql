@@ -0,0 +1,2 @@
+alter table laboratories
+    add column if not exists ipv6_cidr cidr;
\ No newline at end of file
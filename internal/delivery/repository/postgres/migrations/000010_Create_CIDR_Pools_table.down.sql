@@ -0,0 +1,7 @@
+alter table lab_groups
+    drop column if exists cidr_pool;
+
+alter table laboratories
+    drop column if exists cidr_pool;
+
+drop table if exists cidr_pools;
\ No newline at end of file
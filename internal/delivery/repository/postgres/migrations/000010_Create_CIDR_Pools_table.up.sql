@@ -0,0 +1,13 @@
+create table if not exists cidr_pools
+(
+    name       text        not null primary key,
+    cidr       cidr        not null unique,
+
+    created_at timestamptz not null default now()
+);
+
+alter table laboratories
+    add column if not exists cidr_pool text not null default 'default';
+
+alter table lab_groups
+    add column if not exists cidr_pool text not null default '';
\ No newline at end of file
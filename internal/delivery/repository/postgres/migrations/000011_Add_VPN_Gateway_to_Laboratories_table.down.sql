@@ -0,0 +1,2 @@
+alter table laboratories
+    drop column if exists vpn_gateway;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table laboratories
+    add column if not exists vpn_gateway integer not null default 0;
\ No newline at end of file
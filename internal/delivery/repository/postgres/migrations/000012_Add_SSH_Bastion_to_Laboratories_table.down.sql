@@ -0,0 +1,2 @@
+alter table laboratories
+    drop column if exists ssh_bastion;
\ No newline at end of file
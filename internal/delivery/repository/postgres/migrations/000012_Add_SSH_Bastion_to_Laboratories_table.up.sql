@@ -0,0 +1,2 @@
+alter table laboratories
+    add column if not exists ssh_bastion boolean not null default false;
\ No newline at end of file
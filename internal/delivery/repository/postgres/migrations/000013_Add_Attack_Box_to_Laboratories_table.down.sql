@@ -0,0 +1,2 @@
+alter table laboratories
+    drop column if exists attack_box;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table laboratories
+    add column if not exists attack_box jsonb;
\ No newline at end of file
@@ -0,0 +1 @@
+drop table if exists published_ports;
\ No newline at end of file
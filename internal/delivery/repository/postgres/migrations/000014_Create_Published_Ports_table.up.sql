@@ -0,0 +1,14 @@
+create table if not exists published_ports
+(
+    lab_id       uuid        not null references laboratories (id) on delete cascade,
+    challenge_id text        not null,
+    instance_id  text        not null,
+    port         integer     not null,
+    protocol     text        not null,
+
+    public_port  integer     not null unique,
+
+    created_at   timestamptz not null default now(),
+
+    primary key (lab_id, instance_id, port, protocol)
+);
\ No newline at end of file
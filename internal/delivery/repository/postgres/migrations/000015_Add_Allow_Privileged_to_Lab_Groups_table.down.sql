@@ -0,0 +1,2 @@
+alter table lab_groups
+    drop column if exists allow_privileged;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table lab_groups
+    add column if not exists allow_privileged boolean not null default false;
\ No newline at end of file
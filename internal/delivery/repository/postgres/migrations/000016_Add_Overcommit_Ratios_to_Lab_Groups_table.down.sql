@@ -0,0 +1,3 @@
+alter table lab_groups
+    drop column if exists cpu_overcommit_ratio,
+    drop column if exists memory_overcommit_ratio;
\ No newline at end of file
@@ -0,0 +1,3 @@
+alter table lab_groups
+    add column if not exists cpu_overcommit_ratio    double precision not null default 0,
+    add column if not exists memory_overcommit_ratio double precision not null default 0;
\ No newline at end of file
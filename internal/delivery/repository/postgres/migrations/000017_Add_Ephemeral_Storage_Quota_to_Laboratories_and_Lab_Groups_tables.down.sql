@@ -0,0 +1,5 @@
+alter table laboratories
+    drop column if exists ephemeral_storage_quota;
+
+alter table lab_groups
+    drop column if exists ephemeral_storage_quota;
\ No newline at end of file
@@ -0,0 +1,5 @@
+alter table laboratories
+    add column if not exists ephemeral_storage_quota bigint not null default 0;
+
+alter table lab_groups
+    add column if not exists ephemeral_storage_quota bigint not null default 0;
\ No newline at end of file
@@ -12,10 +12,70 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type CidrPool struct {
+	Name      string       `json:"name"`
+	Cidr      netip.Prefix `json:"cidr"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type LabGroup struct {
+	ID                    uuid.UUID          `json:"id"`
+	Name                  string             `json:"name"`
+	Description           string             `json:"description"`
+	StartsAt              pgtype.Timestamptz `json:"starts_at"`
+	EndsAt                pgtype.Timestamptz `json:"ends_at"`
+	CidrMask              int32              `json:"cidr_mask"`
+	CpuQuota              int64              `json:"cpu_quota"`
+	MemoryQuota           int64              `json:"memory_quota"`
+	PodsQuota             int64              `json:"pods_quota"`
+	MaxLabs               int32              `json:"max_labs"`
+	Labels                []byte             `json:"labels"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	CreatedAt             time.Time          `json:"created_at"`
+	IsolationMode         int32              `json:"isolation_mode"`
+	CidrPool              string             `json:"cidr_pool"`
+	AllowPrivileged       bool               `json:"allow_privileged"`
+	CpuOvercommitRatio    float64            `json:"cpu_overcommit_ratio"`
+	MemoryOvercommitRatio float64            `json:"memory_overcommit_ratio"`
+	EphemeralStorageQuota int64              `json:"ephemeral_storage_quota"`
+}
+
+type LabTemplate struct {
+	ID         uuid.UUID          `json:"id"`
+	Name       string             `json:"name"`
+	Version    int32              `json:"version"`
+	Challenges []byte             `json:"challenges"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
 type Laboratory struct {
-	ID        uuid.UUID          `json:"id"`
-	GroupID   uuid.UUID          `json:"group_id"`
-	Cidr      netip.Prefix       `json:"cidr"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	CreatedAt time.Time          `json:"created_at"`
+	ID                    uuid.UUID          `json:"id"`
+	GroupID               uuid.UUID          `json:"group_id"`
+	Cidr                  netip.Prefix       `json:"cidr"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	CreatedAt             time.Time          `json:"created_at"`
+	CpuQuota              int64              `json:"cpu_quota"`
+	MemoryQuota           int64              `json:"memory_quota"`
+	PodsQuota             int64              `json:"pods_quota"`
+	Metadata              []byte             `json:"metadata"`
+	IsolationMode         int32              `json:"isolation_mode"`
+	EgressProfile         []byte             `json:"egress_profile"`
+	Segments              []byte             `json:"segments"`
+	Ipv6Cidr              *netip.Prefix      `json:"ipv6_cidr"`
+	CidrPool              string             `json:"cidr_pool"`
+	VpnGateway            int32              `json:"vpn_gateway"`
+	SshBastion            bool               `json:"ssh_bastion"`
+	AttackBox             []byte             `json:"attack_box"`
+	EphemeralStorageQuota int64              `json:"ephemeral_storage_quota"`
+}
+
+type PublishedPort struct {
+	LabID       uuid.UUID `json:"lab_id"`
+	ChallengeID string    `json:"challenge_id"`
+	InstanceID  string    `json:"instance_id"`
+	Port        int32     `json:"port"`
+	Protocol    string    `json:"protocol"`
+	PublicPort  int32     `json:"public_port"`
+	CreatedAt   time.Time `json:"created_at"`
 }
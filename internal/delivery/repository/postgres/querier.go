@@ -8,12 +8,36 @@ import (
 	"context"
 
 	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	AllocatePublishedPort(ctx context.Context, arg AllocatePublishedPortParams) (int32, error)
+	CountLaboratories(ctx context.Context, groupID uuid.UUID) (int64, error)
+	CountPrivilegedLaboratories(ctx context.Context, laboratoryIds []uuid.UUID) (int64, error)
+	CreateCIDRPool(ctx context.Context, arg CreateCIDRPoolParams) (CidrPool, error)
+	CreateLabGroup(ctx context.Context, arg CreateLabGroupParams) (LabGroup, error)
+	CreateLabTemplate(ctx context.Context, arg CreateLabTemplateParams) (LabTemplate, error)
 	CreateLaboratory(ctx context.Context, arg CreateLaboratoryParams) error
+	DeleteInstancePublishedPorts(ctx context.Context, arg DeleteInstancePublishedPortsParams) (int64, error)
+	DeleteLabGroup(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteLabTemplate(ctx context.Context, id uuid.UUID) (int64, error)
 	DeleteLaboratory(ctx context.Context, id uuid.UUID) (int64, error)
+	GetCIDRPool(ctx context.Context, name string) (CidrPool, error)
+	GetCIDRPools(ctx context.Context) ([]CidrPool, error)
+	GetLabGroup(ctx context.Context, id uuid.UUID) (LabGroup, error)
+	GetLabGroups(ctx context.Context) ([]LabGroup, error)
+	GetLabGroupsByLaboratories(ctx context.Context, laboratoryIds []uuid.UUID) ([]LabGroup, error)
+	GetLabTemplate(ctx context.Context, id uuid.UUID) (LabTemplate, error)
+	GetLabTemplates(ctx context.Context, name pgtype.Text) ([]LabTemplate, error)
 	GetLaboratories(ctx context.Context, groupID uuid.NullUUID) ([]Laboratory, error)
+	GetLaboratory(ctx context.Context, id uuid.UUID) (Laboratory, error)
+	GetPublishedPorts(ctx context.Context, labID uuid.UUID) ([]PublishedPort, error)
+	UpdateLabGroup(ctx context.Context, arg UpdateLabGroupParams) (LabGroup, error)
+	UpdateLaboratoryEgressProfile(ctx context.Context, arg UpdateLaboratoryEgressProfileParams) (int64, error)
+	UpdateLaboratoryGroup(ctx context.Context, arg UpdateLaboratoryGroupParams) (int64, error)
+	UpdateLaboratoryIsolationMode(ctx context.Context, arg UpdateLaboratoryIsolationModeParams) (int64, error)
+	UpdateLaboratoryMetadata(ctx context.Context, arg UpdateLaboratoryMetadataParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
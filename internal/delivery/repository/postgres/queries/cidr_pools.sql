@@ -0,0 +1,14 @@
+-- name: GetCIDRPools :many
+select *
+from cidr_pools
+order by created_at;
+
+-- name: GetCIDRPool :one
+select *
+from cidr_pools
+where name = $1;
+
+-- name: CreateCIDRPool :one
+insert into cidr_pools (name, cidr)
+values ($1, $2)
+returning *;
\ No newline at end of file
@@ -0,0 +1,55 @@
+-- name: GetLabGroups :many
+select *
+from lab_groups
+order by created_at;
+
+-- name: GetLabGroup :one
+select *
+from lab_groups
+where id = $1;
+
+-- name: GetLabGroupsByLaboratories :many
+select *
+from lab_groups
+where id in (select group_id from laboratories where laboratories.id = any (sqlc.arg(laboratory_ids)::uuid[]));
+
+-- name: CountPrivilegedLaboratories :one
+select count(*)
+from laboratories
+         join lab_groups on lab_groups.id = laboratories.group_id
+where laboratories.id = any (sqlc.arg(laboratory_ids)::uuid[])
+  and lab_groups.allow_privileged;
+
+-- name: CreateLabGroup :one
+insert into lab_groups (id, name, description, starts_at, ends_at, cidr_mask, cpu_quota, memory_quota, pods_quota,
+                        max_labs, labels, isolation_mode, cidr_pool, allow_privileged, cpu_overcommit_ratio,
+                        memory_overcommit_ratio, ephemeral_storage_quota)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+returning *;
+
+-- name: UpdateLabGroup :one
+update lab_groups
+set name                    = $2,
+    description             = $3,
+    starts_at               = $4,
+    ends_at                 = $5,
+    cidr_mask               = $6,
+    cpu_quota               = $7,
+    memory_quota            = $8,
+    pods_quota              = $9,
+    max_labs                = $10,
+    labels                  = $11,
+    isolation_mode          = $12,
+    cidr_pool               = $13,
+    allow_privileged        = $14,
+    cpu_overcommit_ratio    = $15,
+    memory_overcommit_ratio = $16,
+    ephemeral_storage_quota = $17,
+    updated_at              = now()
+where id = $1
+returning *;
+
+-- name: DeleteLabGroup :execrows
+delete
+from lab_groups
+where id = $1;
\ No newline at end of file
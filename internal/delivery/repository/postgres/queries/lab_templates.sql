@@ -0,0 +1,20 @@
+-- name: GetLabTemplates :many
+select *
+from lab_templates
+where name = coalesce(sqlc.narg(name), name)
+order by name, version;
+
+-- name: GetLabTemplate :one
+select *
+from lab_templates
+where id = $1;
+
+-- name: CreateLabTemplate :one
+insert into lab_templates (id, name, version, challenges)
+values ($1, $2, (select coalesce(max(version), 0) + 1 from lab_templates where name = $2), $3)
+returning *;
+
+-- name: DeleteLabTemplate :execrows
+delete
+from lab_templates
+where id = $1;
\ No newline at end of file
@@ -3,9 +3,49 @@ select *
 from laboratories
 where group_id = coalesce(sqlc.narg(group_id), group_id);
 
+-- name: GetLaboratory :one
+select *
+from laboratories
+where id = $1;
+
+-- name: CountLaboratories :one
+select count(*)
+from laboratories
+where group_id = $1;
+
 -- name: CreateLaboratory :exec
-insert into laboratories (id, group_id, cidr)
-values ($1, $2, $3);
+insert into laboratories (id, group_id, cidr, cpu_quota, memory_quota, pods_quota, metadata, isolation_mode,
+                          egress_profile, segments, ipv6_cidr, cidr_pool, vpn_gateway, ssh_bastion, attack_box,
+                          ephemeral_storage_quota)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16);
+
+-- name: UpdateLaboratoryMetadata :execrows
+update laboratories
+set metadata   = $2,
+    updated_at = now()
+where id = $1;
+
+-- name: UpdateLaboratoryIsolationMode :execrows
+update laboratories
+set isolation_mode = $2,
+    updated_at     = now()
+where id = $1;
+
+-- name: UpdateLaboratoryEgressProfile :execrows
+update laboratories
+set egress_profile = $2,
+    updated_at     = now()
+where id = $1;
+
+-- name: UpdateLaboratoryGroup :execrows
+update laboratories
+set group_id                = $2,
+    cpu_quota               = coalesce(sqlc.narg(cpu_quota), cpu_quota),
+    memory_quota            = coalesce(sqlc.narg(memory_quota), memory_quota),
+    pods_quota              = coalesce(sqlc.narg(pods_quota), pods_quota),
+    ephemeral_storage_quota = coalesce(sqlc.narg(ephemeral_storage_quota), ephemeral_storage_quota),
+    updated_at              = now()
+where id = $1;
 
 -- name: DeleteLaboratory :execrows
 delete
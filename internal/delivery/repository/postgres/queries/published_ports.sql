@@ -0,0 +1,21 @@
+-- name: GetPublishedPorts :many
+select *
+from published_ports
+where lab_id = $1
+order by created_at;
+
+-- name: AllocatePublishedPort :one
+insert into published_ports (lab_id, challenge_id, instance_id, port, protocol, public_port)
+select $1, $2, $3, $4, $5, p
+from generate_series(sqlc.arg(range_start)::integer, sqlc.arg(range_end)::integer) p
+where not exists (select 1 from published_ports where public_port = p)
+order by p
+limit 1
+on conflict (lab_id, instance_id, port, protocol) do update set public_port = published_ports.public_port
+returning public_port;
+
+-- name: DeleteInstancePublishedPorts :execrows
+delete
+from published_ports
+where lab_id = $1
+  and instance_id = $2;
\ No newline at end of file
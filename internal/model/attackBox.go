@@ -0,0 +1,19 @@
+package model
+
+type (
+	// AttackBoxConfig is the lab attack box with the web desktop or terminal, the zero values are the platform defaults
+	AttackBoxConfig struct {
+		Image string
+		// Port is the web port of the attack box front end
+		Port      int32
+		Resources ResourcesConfig
+	}
+
+	// AttackBoxAccess is the authenticated lab URL of the attack box
+	AttackBoxAccess struct {
+		URL      string
+		Username string
+		Password string
+		Status   Status
+	}
+)
@@ -0,0 +1,22 @@
+package model
+
+type (
+	// SSHPipe routes the player connections of the shared SSH router to the lab bastion
+	SSHPipe struct {
+		Name string
+		// Username is the router username of the player, the player is authenticated by the AuthorizedKeys
+		Username       string
+		AuthorizedKeys []string
+		// Host is the bastion address with the port
+		Host             string
+		UpstreamUsername string
+		// PrivateKeySecret is the lab secret with the router key authorized by the bastion
+		PrivateKeySecret string
+	}
+
+	// SSHAccess is the player login of the lab bastion through the shared SSH router
+	SSHAccess struct {
+		Username string
+		Endpoint string
+	}
+)
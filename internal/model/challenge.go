@@ -11,7 +11,84 @@ type (
 		Image     string
 		Resources ResourcesConfig
 		Envs      []EnvConfig
-		Records   []DNSRecordConfig
+		// Flag is stored in the instance flag secret and referenced by the instance container, it is never written to the deployment
+		Flag    *EnvConfig
+		Records []DNSRecordConfig
+		// Egress replaces the lab egress profile for the instance if its mode is not the default one
+		Egress EgressProfile
+		// Segments are the lab segments the instance joins, at most one as the instance has a single network interface,
+		// the instance ip is from the segment and the segment policies admit it
+		Segments []string
+		// AllowFrom restricts the lab pods which can reach the instance, empty means the whole lab or segment
+		AllowFrom []NetworkRule
+		// AllowTo restricts the lab pods the instance can reach, empty means the whole lab. The lab DNS server is always reachable
+		AllowTo []NetworkRule
+		// IP pins the instance address in the network of its first segment or the lab, empty value means the next free address
+		IP string
+		// IPOffset pins the instance address at the host offset in the network, for example 10 is x.x.x.10 in a /24 network
+		IPOffset uint32
+		// HTTPPorts are published by the lab hostnames of the instance, the first port is served by the instance hostname
+		HTTPPorts []int32
+		// PublishedPorts are the TCP and UDP ports published by the NodePort or LoadBalancer service of the instance
+		PublishedPorts []PortConfig
+		// Service is the in-cluster service of the instance, its name is the instance ID resolved by the lab DNS server.
+		// Nil value means the instance has no service of its own
+		Service *InstanceServiceConfig
+		// Privileged instances are allowed only in the labs of the groups with the privileged permission
+		Privileged bool
+		// CapAdds are the linux capabilities added to the instance container, for example NET_ADMIN
+		CapAdds        []string
+		ReadinessProbe *Probe
+		LivenessProbe  *Probe
+		// Containers are the sidecars of the instance container sharing its network, for example the bot of the vulnerable service
+		Containers []ContainerConfig
+		// InitContainers run in order before the instance containers start, for example to seed the database
+		InitContainers []ContainerConfig
+		// Command replaces the image entrypoint of the instance container, empty value means the image one
+		Command []string
+		Args    []string
+		// WorkingDir is the working directory of the instance container, empty value means the image one
+		WorkingDir string
+		// ContainerPorts are the ports declared by the instance container
+		ContainerPorts []PortConfig
+		// RunAs is the user and the group of the instance container, nil value means the image ones
+		RunAs *RunAsConfig
+		// Hostname is the pod hostname of the instance, empty value means the generated pod name
+		Hostname string
+	}
+
+	RunAsConfig struct {
+		UserID  int64
+		GroupID int64
+	}
+
+	// ContainerConfig is the additional container of the instance pod, the name must be unique in the instance
+	ContainerConfig struct {
+		Name      string
+		Image     string
+		Envs      []EnvConfig
+		Resources ResourcesConfig
+		// Command replaces the image entrypoint, empty value means the image one
+		Command []string
+		Args    []string
+	}
+
+	InstanceServiceConfig struct {
+		// Headless service has no cluster ip, the instance ID is resolved to the instance ip
+		Headless bool
+		Ports    []PortConfig
+	}
+
+	// NetworkRule matches the traffic to or from the lab instances on the ports, empty instances match any lab pod and empty ports match any port
+	NetworkRule struct {
+		InstanceIDs []string
+		Ports       []PortConfig
+	}
+
+	PortConfig struct {
+		Port int32
+		// Protocol is TCP, UDP or SCTP, empty value means TCP
+		Protocol string
 	}
 
 	ResourcesConfig struct {
@@ -20,8 +97,9 @@ type (
 	}
 
 	ResourceConfig struct {
-		Memory int64
-		CPU    int64
+		Memory           int64
+		CPU              int64
+		EphemeralStorage int64
 	}
 
 	EnvConfig struct {
@@ -29,6 +107,23 @@ type (
 		Value string
 	}
 
+	// PublicURL is the URL of the published instance port
+	PublicURL struct {
+		ChallengeID string
+		InstanceID  string
+		URL         string
+	}
+
+	// PublishedPort is the public address of the published instance port
+	PublishedPort struct {
+		ChallengeID string
+		InstanceID  string
+		Port        int32
+		Protocol    string
+		// Address is the host:port of the published port, empty value means the load balancer has no address yet
+		Address string
+	}
+
 	DNSRecordConfig struct {
 		Type string
 		Name string
@@ -0,0 +1,24 @@
+package model
+
+const (
+	// Egress modes, the default one allows the internet for the lab and inherits the lab profile for the instance
+	EgressModeDefault = iota
+	EgressModeInternet
+	EgressModeNone
+	EgressModeCIDRs
+	EgressModeDomains
+)
+
+type (
+	// EgressMode defines which addresses outside the cluster the pods can reach
+	EgressMode int32
+
+	EgressProfile struct {
+		Mode EgressMode
+		// CIDRs are the allowed networks in the CIDRs mode
+		CIDRs []string
+		// Domains are the allowed domains in the domains mode, they are resolved by the lab DNS server only.
+		// The domain addresses are allowed as resolved when the profile is applied, the profile is applied again to refresh them
+		Domains []string
+	}
+)
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+type (
+	// LabsGroup holds the defaults and limits shared by all labs of the group, zero values mean no limit
+	LabsGroup struct {
+		ID          uuid.UUID
+		Name        string
+		Description string
+		StartsAt    time.Time
+		EndsAt      time.Time
+		CIDRMask    uint32
+		// CIDRPool is the pool of the group labs networks, empty value means the default pool
+		CIDRPool      string
+		Quota         ResourceQuotaConfig
+		MaxLabs       int32
+		Labels        map[string]string
+		IsolationMode IsolationMode
+		// AllowPrivileged permits the privileged instances in the group labs
+		AllowPrivileged bool
+		// Overcommit ratios reduce the requests of the instances without the requests, zero value means no overcommit
+		CPUOvercommitRatio    float64
+		MemoryOvercommitRatio float64
+		CreatedAt             time.Time
+	}
+)
+
+// IsStarted reports whether the group event has started at the given time
+func (g *LabsGroup) IsStarted(now time.Time) bool {
+	return g.StartsAt.IsZero() || !now.Before(g.StartsAt)
+}
+
+// IsEnded reports whether the group event has ended at the given time
+func (g *LabsGroup) IsEnded(now time.Time) bool {
+	return !g.EndsAt.IsZero() && !now.Before(g.EndsAt)
+}
@@ -2,42 +2,141 @@ package model
 
 type (
 	ApplyDeploymentConfig struct {
-		Name           string
-		LabID          string
-		Labels         map[string]string
-		ReplicaCount   int32
-		Image          string
-		IP             string
-		DNS            string
-		Resources      ResourcesConfig
-		Envs           []EnvConfig
-		Args           []string
+		Name         string
+		LabID        string
+		Labels       map[string]string
+		ReplicaCount int32
+		Image        string
+		IP           string
+		IPv6         string
+		DNS          string
+		Resources    ResourcesConfig
+		Envs         []EnvConfig
+		// SecretEnvs are the env variables with the values from the lab secrets
+		SecretEnvs []SecretEnvConfig
+		Command    []string
+		Args       []string
+		WorkingDir string
+		// ContainerPorts protocols are in the kubernetes form
+		ContainerPorts []PortConfig
+		RunAs          *RunAsConfig
+		Hostname       string
+		// Sidecars run next to the main container in the same pod network, InitContainers run before them in order
+		Sidecars       []ContainerConfig
+		InitContainers []ContainerConfig
 		Volumes        []Volume
 		Privileged     bool
-		UsePublicDNS   bool
 		CapAdds        []string
+		// Sysctls are the pod namespaced kernel parameters, the unsafe ones must be allowed by the kubelet
+		Sysctls        map[string]string
 		ReadinessProbe *Probe
+		LivenessProbe  *Probe
+		// Service is the service of the deployment pods with the deployment name, nil value means the deployment has no service
+		Service *DeploymentServiceConfig
+	}
+
+	SecretEnvConfig struct {
+		Name       string
+		SecretName string
+		Key        string
+	}
+
+	DeploymentServiceConfig struct {
+		// Headless service has no cluster ip, its name is resolved to the pod ip
+		Headless bool
+		Ports    []ServicePort
 	}
 
 	Volume struct {
 		Name          string
 		ConfigMapName string
+		SecretName    string
 		MountPath     string
 	}
 
+	ApplyServiceConfig struct {
+		Name   string
+		LabID  string
+		Labels map[string]string
+		// Selector selects the pods of the service
+		Selector map[string]string
+		// Type is the kubernetes service type, empty value means ClusterIP
+		Type string
+		// Headless service has no cluster ip, it is applied only for the ClusterIP type
+		Headless bool
+		Ports    []ServicePort
+	}
+
+	ServicePort struct {
+		Name     string
+		Protocol string
+		Port     int32
+		// TargetPort is the pod port, zero value means the service port
+		TargetPort int32
+		// NodePort is assigned by kubernetes for the NodePort and LoadBalancer services if it is not set
+		NodePort int32
+	}
+
+	ServiceStatus struct {
+		Name      string
+		Type      string
+		ClusterIP string
+		Ports     []ServicePort
+		// ExternalAddresses are the load balancer ingress addresses
+		ExternalAddresses []string
+	}
+
+	ApplyIngressConfig struct {
+		Name        string
+		LabID       string
+		Labels      map[string]string
+		ServiceName string
+		Rules       []IngressRule
+		// BasicAuthSecret is the lab secret with the htpasswd auth file, empty value means the ingress has no auth
+		BasicAuthSecret string
+	}
+
+	// IngressRule routes the host to the service port
+	IngressRule struct {
+		Host string
+		Port int32
+	}
+
+	IngressStatus struct {
+		Name   string
+		Labels map[string]string
+		// URLs are the public URLs of the ingress hosts
+		URLs []string
+	}
+
+	// Probe checks the container by one of the exec command, the HTTP GET request or the TCP connection
 	Probe struct {
-		Cmd           []string
-		PeriodSeconds int32
+		Cmd []string
+		// HTTPPath and HTTPPort are the HTTP GET probe, the empty path is /
+		HTTPPath string
+		HTTPPort int32
+		TCPPort  int32
+		// zero values mean the kubernetes defaults
+		InitialDelaySeconds int32
+		PeriodSeconds       int32
+		FailureThreshold    int32
 	}
 
 	DeploymentStatus struct {
 		Name   string
 		Labels map[string]string
 		IP     string
+		IPv6   string
 		Status Status
 		Reason string
 	}
 
+	ResourceQuotaStatus struct {
+		Labels map[string]string
+		Hard   ResourceQuotaConfig
+		Used   ResourceQuotaConfig
+	}
+
 	PodMetrics struct {
 		Labels    map[string]string
 		Resources ResourceConfig
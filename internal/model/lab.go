@@ -16,29 +16,115 @@ const (
 	StatusError
 )
 
+const (
+	// Isolation modes, the default one is resolved from the labs group and falls back to the isolated one
+	IsolationModeDefault = iota
+	IsolationModeIsolated
+	IsolationModeGroupShared
+	IsolationModePlatform
+)
+
+// DefaultSegment is the lab network of the pods without segments, it is used in the segment AllowFrom
+const DefaultSegment = "default"
+
 type (
 	Status int
 
+	// IsolationMode defines which pods outside the lab can reach the lab
+	IsolationMode int32
+
 	Lab struct {
 		ID          uuid.UUID
 		GroupID     uuid.UUID
 		CIDRManager *ipam.IPAManager
 		CIDR        netip.Prefix
+		// CIDRPool is the pool the lab network is acquired from
+		CIDRPool string
+		// IPv6CIDR is the IPv6 lab network of the dual-stack lab, it is not valid for the IPv4 only lab
+		IPv6CIDRManager *ipam.IPAManager
+		IPv6CIDR        netip.Prefix
+		Quota           ResourceQuotaConfig
+		// Metadata is stored with the lab and copied to the lab namespace labels
+		Metadata      map[string]string
+		IsolationMode IsolationMode
+		Egress        EgressProfile
+		Segments      []Segment
+		VPNGateway    VPNGateway
+		SSHBastion    bool
+		AttackBox     *AttackBoxConfig
+		// PublicURLs are the URLs of the published instance ports
+		PublicURLs []PublicURL
+		// PublishedPorts are the public addresses of the published instance TCP and UDP ports
+		PublishedPorts []PublishedPort
+	}
+
+	// Segment is a named lab subnet with its own child CIDR, the members of the segments from AllowFrom can reach the segment members
+	Segment struct {
+		Name        string
+		CIDR        netip.Prefix
+		CIDRManager *ipam.IPAManager `json:"-"`
+		AllowFrom   []string
+	}
+
+	LabConfig struct {
+		CIDRMask uint32
+		// CIDRPool is the pool of the lab network, empty value means the labs group pool or the default one
+		CIDRPool string
+		// CIDR is the exact lab network, it replaces the CIDRMask, empty value means the network is acquired by the mask
+		CIDR string
+		// IPv6CIDRMask is the IPv6 lab subnet mask, zero value means the lab has no IPv6 network
+		IPv6CIDRMask uint32
+		Quota        ResourceQuotaConfig
+		// Labels are added to the lab namespace
+		Labels        map[string]string
+		Metadata      map[string]string
+		IsolationMode IsolationMode
+		Egress        EgressProfile
+		Segments      []SegmentConfig
+		// VPNGateway exposes the lab network to the players by the WireGuard gateway, zero value means the lab has no gateway
+		VPNGateway VPNGateway
+		// SSHBastion deploys the lab bastion the players reach through the shared SSH router
+		SSHBastion bool
+		// AttackBox deploys the lab attack box reached by the authenticated lab URL, nil value means the lab has no attack box
+		AttackBox *AttackBoxConfig
+	}
+
+	SegmentConfig struct {
+		Name string
+		// CIDRMask is the segment subnet mask, zero value means the lab one
+		CIDRMask  uint32
+		AllowFrom []string
 	}
+
+	// ResourceQuotaConfig is the resource limit of the whole lab, zero value means no limit
+	ResourceQuotaConfig struct {
+		CPU              int64
+		Memory           int64
+		Pods             int64
+		EphemeralStorage int64
+	}
+
 	LabStatus struct {
 		ID        uuid.UUID
 		GroupID   uuid.UUID
 		CIDR      string
 		DNS       *DNSStatus
+		Quota     *QuotaStatus
 		Instances []InstanceStatus
 	}
 
+	QuotaStatus struct {
+		Hard ResourceQuotaConfig
+		Used ResourceQuotaConfig
+	}
+
 	InstanceStatus struct {
 		ID          uuid.UUID
 		ChallengeID uuid.UUID
 		Status      Status
 		Resources   ResourceConfig
 		Reason      string
+		URLs        []string
 	}
 
 	DNSStatus struct {
@@ -54,3 +140,31 @@ type (
 		Labels    map[string]string
 	}
 )
+
+// GetSegment returns the lab segment by its name, or nil if the lab has no such segment
+func (l *Lab) GetSegment(name string) *Segment {
+	for i := range l.Segments {
+		if l.Segments[i].Name == name {
+			return &l.Segments[i]
+		}
+	}
+	return nil
+}
+
+// GetCIDRManagerByIP returns the manager of the lab network the ip belongs to
+func (l *Lab) GetCIDRManagerByIP(ip string) *ipam.IPAManager {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return l.CIDRManager
+	}
+
+	for _, segment := range l.Segments {
+		if segment.CIDR.Contains(addr) {
+			return segment.CIDRManager
+		}
+	}
+	if l.IPv6CIDRManager != nil && l.IPv6CIDR.Contains(addr) {
+		return l.IPv6CIDRManager
+	}
+	return l.CIDRManager
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"github.com/gofrs/uuid"
+	"net/netip"
+	"time"
+)
+
+// DefaultCIDRPool is the pool of the labs subnet from the service config
+const DefaultCIDRPool = "default"
+
+type (
+	// CIDRPool is the named parent subnet the lab networks are acquired from
+	CIDRPool struct {
+		Name      string
+		CIDR      netip.Prefix
+		CreatedAt time.Time
+	}
+
+	// CIDRPoolUsage is the child networks acquired from the pool
+	CIDRPoolUsage struct {
+		Pool       CIDRPool
+		ChildCIDRs []netip.Prefix
+		// Utilization is the percentage of the pool addresses in the child networks
+		Utilization float64
+	}
+
+	// NetworkUsage is the addresses acquired in the network, the network and broadcast addresses reserved by IPAM are not counted
+	NetworkUsage struct {
+		CIDR    netip.Prefix
+		UsedIPs []string
+		// Utilization is the percentage of the usable network addresses
+		Utilization float64
+	}
+
+	// LabIPAMReport is the lab networks usage cross-checked against the lab deployments and the DNS server
+	LabIPAMReport struct {
+		ID       uuid.UUID
+		Networks []NetworkUsage
+		// LeakedIPs are held in IPAM, but no deployment uses them
+		LeakedIPs []string
+		// UnallocatedIPs are used by the deployments, but not held in IPAM
+		UnallocatedIPs []string
+		// Released is set when the leaked addresses are released
+		Released bool
+	}
+)
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+type (
+	LabTemplate struct {
+		ID         uuid.UUID
+		Name       string
+		Version    int32
+		Challenges []ChallengeConfig
+		CreatedAt  time.Time
+	}
+)
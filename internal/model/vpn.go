@@ -0,0 +1,23 @@
+package model
+
+import "net/netip"
+
+const (
+	// VPN gateway types, the disabled one means the lab has no gateway
+	VPNGatewayDisabled = iota
+	VPNGatewayNodePort
+	VPNGatewayLoadBalancer
+)
+
+type (
+	// VPNGateway defines how the lab WireGuard gateway is exposed outside the cluster
+	VPNGateway int32
+
+	// VPNPeer is the player peer of the lab gateway, the peer private key is only returned in the client config
+	VPNPeer struct {
+		ID           string
+		PublicKey    string
+		PresharedKey string
+		Address      netip.Addr
+	}
+)
@@ -0,0 +1,252 @@
+package attackbox
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"github.com/cybericebox/agent/internal/config"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+)
+
+const (
+	attackBoxName     = "attack-box"
+	attackBoxAuthName = "attack-box-auth"
+	username          = "player"
+
+	// authFile is the secret key the ingress controller reads the htpasswd file from
+	authFile     = "auth"
+	usernameFile = "username"
+	passwordFile = "password"
+
+	passwordLength = 18
+)
+
+type (
+	IInfrastructure interface {
+		ApplyDeployment(ctx context.Context, config model.ApplyDeploymentConfig) error
+		ScaleDeployment(ctx context.Context, name, labID string, scale int32) error
+		GetDeploymentsInNamespaceBySelector(ctx context.Context, labID string, selector ...string) ([]model.DeploymentStatus, error)
+
+		ApplySecret(ctx context.Context, name, namespace string, labels, data map[string]string) error
+		GetSecretData(ctx context.Context, name, namespace string) (map[string]string, error)
+
+		ApplyService(ctx context.Context, cfg model.ApplyServiceConfig) error
+		ApplyIngress(ctx context.Context, cfg model.ApplyIngressConfig) error
+
+		ApplyIngressPolicy(ctx context.Context, labID, name string, podSelector map[string]string, ports []int32) error
+	}
+
+	Dependencies struct {
+		Infrastructure IInfrastructure
+		// BaseDomain is the base domain of the lab hostnames, empty value disables the attack boxes
+		BaseDomain string
+		// Defaults are the image, the port and the resources of the attack box without them
+		Defaults config.AttackBoxConfig
+	}
+
+	AttackBoxService struct {
+		infrastructure IInfrastructure
+		baseDomain     string
+		defaults       config.AttackBoxConfig
+	}
+)
+
+func NewAttackBoxService(deps Dependencies) *AttackBoxService {
+	return &AttackBoxService{
+		infrastructure: deps.Infrastructure,
+		baseDomain:     deps.BaseDomain,
+		defaults:       deps.Defaults,
+	}
+}
+
+// ValidateAttackBox checks the attack box can be exposed by the lab hostname, the nil config means the lab has no attack box
+func (s *AttackBoxService) ValidateAttackBox(cfg *model.AttackBoxConfig) error {
+	if cfg == nil {
+		return nil
+	}
+
+	if s.baseDomain == "" {
+		return appError.ErrLabAttackBoxDisabled.Err()
+	}
+
+	if cfg.Port < 0 || cfg.Port > 65535 {
+		return appError.ErrLabAttackBoxInvalidConfig.WithMessageF("Lab attack box port %d is invalid", cfg.Port).Err()
+	}
+
+	if cfg.Resources.Limit.CPU < 0 || cfg.Resources.Limit.Memory < 0 || cfg.Resources.Requests.CPU < 0 || cfg.Resources.Requests.Memory < 0 {
+		return appError.ErrLabAttackBoxInvalidConfig.WithMessage("Lab attack box resources must not be negative").Err()
+	}
+
+	return nil
+}
+
+// CreateAttackBox creates the lab attack box with the reserved lab address and exposes it by the lab hostname with the new credentials
+func (s *AttackBoxService) CreateAttackBox(ctx context.Context, labID, ip, dns string, cfg model.AttackBoxConfig) error {
+	labAttackBoxErr := appError.ErrLabAttackBox.WithContext("labID", labID)
+
+	cfg = s.withDefaults(cfg)
+
+	password, err := newPassword()
+	if err != nil {
+		return labAttackBoxErr.WithError(err).WithMessage("Failed to generate password").Err()
+	}
+
+	if err = s.infrastructure.ApplySecret(ctx, attackBoxAuthName, labID, map[string]string{
+		config.PlatformLabel: config.LabWebAuth,
+		config.LabIDLabel:    labID,
+	}, map[string]string{
+		authFile:     htpasswd(username, password),
+		usernameFile: username,
+		passwordFile: password,
+	}); err != nil {
+		return labAttackBoxErr.WithError(err).WithMessage("Failed to apply attack box auth secret").Err()
+	}
+
+	selector := map[string]string{
+		config.PlatformLabel: config.LabAttackBox,
+	}
+
+	if err = s.infrastructure.ApplyIngressPolicy(ctx, labID, attackBoxName, selector, []int32{cfg.Port}); err != nil {
+		return labAttackBoxErr.WithError(err).WithMessage("Failed to apply attack box network policy").Err()
+	}
+
+	if err = s.infrastructure.ApplyDeployment(ctx, model.ApplyDeploymentConfig{
+		Name:  attackBoxName,
+		LabID: labID,
+		Image: cfg.Image,
+		IP:    ip,
+		DNS:   dns,
+		Labels: map[string]string{
+			config.PlatformLabel: config.LabAttackBox,
+			config.LabIDLabel:    labID,
+		},
+		Resources:    cfg.Resources,
+		ReplicaCount: 1,
+	}); err != nil {
+		return labAttackBoxErr.WithError(err).WithMessage("Failed to apply attack box deployment").Err()
+	}
+
+	if err = s.infrastructure.ApplyService(ctx, model.ApplyServiceConfig{
+		Name:  attackBoxName,
+		LabID: labID,
+		Labels: map[string]string{
+			config.PlatformLabel: config.LabAttackBox,
+			config.LabIDLabel:    labID,
+		},
+		Selector: selector,
+		Ports: []model.ServicePort{{
+			Name: "web",
+			Port: cfg.Port,
+		}},
+	}); err != nil {
+		return labAttackBoxErr.WithError(err).WithMessage("Failed to apply attack box service").Err()
+	}
+
+	if err = s.infrastructure.ApplyIngress(ctx, model.ApplyIngressConfig{
+		Name:  attackBoxName,
+		LabID: labID,
+		Labels: map[string]string{
+			config.PlatformLabel: config.LabAttackBox,
+			config.LabIDLabel:    labID,
+		},
+		ServiceName: attackBoxName,
+		Rules: []model.IngressRule{{
+			Host: s.host(labID),
+			Port: cfg.Port,
+		}},
+		BasicAuthSecret: attackBoxAuthName,
+	}); err != nil {
+		return labAttackBoxErr.WithError(err).WithMessage("Failed to apply attack box ingress").Err()
+	}
+
+	return nil
+}
+
+// GetAttackBoxAccess returns the lab URL of the attack box with the credentials and the attack box status
+func (s *AttackBoxService) GetAttackBoxAccess(ctx context.Context, labID string) (*model.AttackBoxAccess, error) {
+	labAttackBoxErr := appError.ErrLabAttackBox.WithContext("labID", labID)
+
+	data, err := s.infrastructure.GetSecretData(ctx, attackBoxAuthName, labID)
+	if err != nil {
+		return nil, labAttackBoxErr.WithError(err).WithMessage("Failed to get attack box auth secret").Err()
+	}
+
+	deployments, err := s.infrastructure.GetDeploymentsInNamespaceBySelector(ctx, labID, fmt.Sprintf("%s=%s", config.PlatformLabel, config.LabAttackBox))
+	if err != nil {
+		return nil, labAttackBoxErr.WithError(err).WithMessage("Failed to get attack box deployment").Err()
+	}
+
+	status := model.Status(model.StatusUnknown)
+	if len(deployments) > 0 {
+		status = deployments[0].Status
+	}
+
+	return &model.AttackBoxAccess{
+		URL:      fmt.Sprintf("https://%s", s.host(labID)),
+		Username: data[usernameFile],
+		Password: data[passwordFile],
+		Status:   status,
+	}, nil
+}
+
+func (s *AttackBoxService) StartAttackBox(ctx context.Context, labID string) error {
+	if err := s.infrastructure.ScaleDeployment(ctx, attackBoxName, labID, 1); err != nil {
+		return appError.ErrLabAttackBox.WithError(err).WithMessage("Failed to scale attack box up").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+func (s *AttackBoxService) StopAttackBox(ctx context.Context, labID string) error {
+	if err := s.infrastructure.ScaleDeployment(ctx, attackBoxName, labID, 0); err != nil {
+		return appError.ErrLabAttackBox.WithError(err).WithMessage("Failed to scale attack box down").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+func (s *AttackBoxService) withDefaults(cfg model.AttackBoxConfig) model.AttackBoxConfig {
+	if cfg.Image == "" {
+		cfg.Image = s.defaults.Image
+	}
+	if cfg.Port == 0 {
+		cfg.Port = s.defaults.Port
+	}
+	if cfg.Resources.Limit.CPU == 0 || cfg.Resources.Limit.Memory == 0 {
+		cfg.Resources = model.ResourcesConfig{
+			Requests: model.ResourceConfig{
+				Memory: s.defaults.Memory,
+				CPU:    s.defaults.CPU,
+			},
+			Limit: model.ResourceConfig{
+				Memory: s.defaults.Memory,
+				CPU:    s.defaults.CPU,
+			},
+		}
+	}
+
+	return cfg
+}
+
+// host is the lab hostname of the attack box, it is a single label under the base domain, so the wildcard certificate of the base domain covers it
+func (s *AttackBoxService) host(labID string) string {
+	return fmt.Sprintf("%s.%s", labID, s.baseDomain)
+}
+
+func newPassword() (string, error) {
+	b := make([]byte, passwordLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// htpasswd returns the auth file line in the SHA format, the ingress controller reads it without the external tools
+func htpasswd(user, password string) string {
+	hash := sha1.Sum([]byte(password))
+	return fmt.Sprintf("%s:{SHA}%s\n", user, base64.StdEncoding.EncodeToString(hash[:]))
+}
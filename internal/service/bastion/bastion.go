@@ -0,0 +1,253 @@
+package bastion
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"github.com/cybericebox/agent/internal/config"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	bastionName     = "ssh-bastion"
+	bastionKeysName = "ssh-bastion-keys"
+	// routerAccessName is the role and the role binding of the router to the bastion keys
+	routerAccessName = "ssh-router-access"
+	bastionUser      = "player"
+	// privateKeyFile is the secret key the router reads the private key from
+	privateKeyFile = "ssh-privatekey"
+
+	Mb64   = 64 * 1024 * 1024
+	Cpu50m = 50
+	image  = "linuxserver/openssh-server:version-9.7_p1-r4"
+
+	port = 2222
+)
+
+type (
+	IInfrastructure interface {
+		ApplyDeployment(ctx context.Context, config model.ApplyDeploymentConfig) error
+
+		ApplySecret(ctx context.Context, name, namespace string, labels, data map[string]string) error
+		ApplySecretReaderRole(ctx context.Context, name, labID, secretName, serviceAccountNamespace, serviceAccount string, labels map[string]string) error
+
+		ApplySSHPipe(ctx context.Context, labID string, pipe model.SSHPipe) error
+		GetSSHPipe(ctx context.Context, labID, name string) (*model.SSHPipe, error)
+		DeleteSSHPipe(ctx context.Context, labID, name string) error
+
+		ApplySSHBastionPolicy(ctx context.Context, labID, routerNamespace string, port int32) error
+	}
+
+	Dependencies struct {
+		Infrastructure IInfrastructure
+		// RouterNamespace is the namespace of the shared SSH router, empty value disables the bastions
+		RouterNamespace string
+		// RouterEndpoint is the public address of the shared SSH router
+		RouterEndpoint string
+		// RouterServiceAccount is the service account of the shared SSH router
+		RouterServiceAccount string
+	}
+
+	BastionService struct {
+		infrastructure  IInfrastructure
+		routerNamespace string
+		routerEndpoint  string
+		routerAccount   string
+	}
+)
+
+func NewBastionService(deps Dependencies) *BastionService {
+	return &BastionService{
+		infrastructure:  deps.Infrastructure,
+		routerNamespace: deps.RouterNamespace,
+		routerEndpoint:  deps.RouterEndpoint,
+		routerAccount:   deps.RouterServiceAccount,
+	}
+}
+
+// ValidateSSHBastion checks the lab bastion can be reached through the shared SSH router
+func (s *BastionService) ValidateSSHBastion(enabled bool) error {
+	if enabled && s.routerNamespace == "" {
+		return appError.ErrLabSSHDisabled.Err()
+	}
+
+	return nil
+}
+
+// CreateSSHBastion creates the lab SSH bastion with the reserved lab address, the bastion authorizes only the router key of the lab
+func (s *BastionService) CreateSSHBastion(ctx context.Context, labID, ip, dns string) error {
+	labSSHErr := appError.ErrLabSSH.WithContext("labID", labID)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return labSSHErr.WithError(err).WithMessage("Failed to generate router key").Err()
+	}
+
+	privateKeyBlock, err := ssh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		return labSSHErr.WithError(err).WithMessage("Failed to marshal router private key").Err()
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return labSSHErr.WithError(err).WithMessage("Failed to marshal router public key").Err()
+	}
+
+	if err = s.infrastructure.ApplySecret(ctx, bastionKeysName, labID, map[string]string{
+		config.PlatformLabel: config.LabSSHKeys,
+		config.LabIDLabel:    labID,
+	}, map[string]string{
+		privateKeyFile: string(pem.EncodeToMemory(privateKeyBlock)),
+	}); err != nil {
+		return labSSHErr.WithError(err).WithMessage("Failed to apply bastion keys secret").Err()
+	}
+
+	// the router reads the keys of the lab bastion only, it has no access to the other lab secrets
+	if err = s.infrastructure.ApplySecretReaderRole(ctx, routerAccessName, labID, bastionKeysName, s.routerNamespace, s.routerAccount, map[string]string{
+		config.PlatformLabel: config.LabSSHAccess,
+		config.LabIDLabel:    labID,
+	}); err != nil {
+		return labSSHErr.WithError(err).WithMessage("Failed to apply router access to bastion keys").Err()
+	}
+
+	if err = s.infrastructure.ApplySSHBastionPolicy(ctx, labID, s.routerNamespace, port); err != nil {
+		return labSSHErr.WithError(err).WithMessage("Failed to apply bastion network policy").Err()
+	}
+
+	if err = s.infrastructure.ApplyDeployment(ctx, model.ApplyDeploymentConfig{
+		Name:  bastionName,
+		LabID: labID,
+		Image: image,
+		IP:    ip,
+		DNS:   dns,
+		Labels: map[string]string{
+			config.PlatformLabel: config.LabSSH,
+			config.LabIDLabel:    labID,
+		},
+		Resources: model.ResourcesConfig{
+			Requests: model.ResourceConfig{
+				Memory: Mb64,
+				CPU:    Cpu50m,
+			},
+			Limit: model.ResourceConfig{
+				Memory: Mb64,
+				CPU:    Cpu50m,
+			},
+		},
+		ReplicaCount: 1,
+		Envs: []model.EnvConfig{
+			{Name: "USER_NAME", Value: bastionUser},
+			{Name: "PUBLIC_KEY", Value: strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPublicKey)))},
+			{Name: "PASSWORD_ACCESS", Value: "false"},
+		},
+	}); err != nil {
+		return labSSHErr.WithError(err).WithMessage("Failed to apply bastion deployment").Err()
+	}
+
+	return nil
+}
+
+// AddSSHKey authorizes the player public key in the router pipe of the lab bastion, the pipe is created with the first player key
+func (s *BastionService) AddSSHKey(ctx context.Context, labID, ip, playerID, publicKey string) (*model.SSHAccess, error) {
+	labSSHErr := appError.ErrLabSSH.WithContext("labID", labID).WithContext("playerID", playerID)
+
+	if errs := validation.IsDNS1123Label(playerID); len(errs) != 0 {
+		return nil, appError.ErrLabSSHInvalidPlayer.WithMessageF("Lab SSH player id %s is invalid: %s", playerID, strings.Join(errs, "; ")).Err()
+	}
+
+	key, err := parsePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe, err := s.infrastructure.GetSSHPipe(ctx, labID, playerID)
+	if err != nil {
+		return nil, labSSHErr.WithError(err).WithMessage("Failed to get player pipe").Err()
+	}
+
+	if pipe == nil {
+		pipe = &model.SSHPipe{Name: playerID}
+	}
+	// the bastion address and the router key are always set, so the pipe follows the recreated bastion
+	pipe.Username = username(labID, playerID)
+	pipe.Host = net.JoinHostPort(ip, strconv.Itoa(port))
+	pipe.UpstreamUsername = bastionUser
+	pipe.PrivateKeySecret = bastionKeysName
+
+	if !slices.Contains(pipe.AuthorizedKeys, key) {
+		pipe.AuthorizedKeys = append(pipe.AuthorizedKeys, key)
+	}
+
+	if err = s.infrastructure.ApplySSHPipe(ctx, labID, *pipe); err != nil {
+		return nil, labSSHErr.WithError(err).WithMessage("Failed to apply player pipe").Err()
+	}
+
+	return &model.SSHAccess{
+		Username: pipe.Username,
+		Endpoint: s.routerEndpoint,
+	}, nil
+}
+
+// RemoveSSHKey removes the player public key from the router pipe of the lab bastion, the empty key removes all player keys
+func (s *BastionService) RemoveSSHKey(ctx context.Context, labID, playerID, publicKey string) error {
+	labSSHErr := appError.ErrLabSSH.WithContext("labID", labID).WithContext("playerID", playerID)
+
+	pipe, err := s.infrastructure.GetSSHPipe(ctx, labID, playerID)
+	if err != nil {
+		return labSSHErr.WithError(err).WithMessage("Failed to get player pipe").Err()
+	}
+
+	if pipe == nil {
+		return appError.ErrLabSSHKeyNotFound.WithContext("labID", labID).WithContext("playerID", playerID).Err()
+	}
+
+	if publicKey != "" {
+		key, err := parsePublicKey(publicKey)
+		if err != nil {
+			return err
+		}
+
+		idx := slices.Index(pipe.AuthorizedKeys, key)
+		if idx == -1 {
+			return appError.ErrLabSSHKeyNotFound.WithContext("labID", labID).WithContext("playerID", playerID).Err()
+		}
+		pipe.AuthorizedKeys = slices.Delete(pipe.AuthorizedKeys, idx, idx+1)
+	}
+
+	// the pipe without keys can not be used, so it is removed
+	if publicKey == "" || len(pipe.AuthorizedKeys) == 0 {
+		if err = s.infrastructure.DeleteSSHPipe(ctx, labID, playerID); err != nil {
+			return labSSHErr.WithError(err).WithMessage("Failed to delete player pipe").Err()
+		}
+		return nil
+	}
+
+	if err = s.infrastructure.ApplySSHPipe(ctx, labID, *pipe); err != nil {
+		return labSSHErr.WithError(err).WithMessage("Failed to apply player pipe").Err()
+	}
+
+	return nil
+}
+
+// parsePublicKey returns the key in the authorized keys format without the comment, so the same key is matched with any comment
+func parsePublicKey(publicKey string) (string, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return "", appError.ErrLabSSHInvalidPublicKey.WithError(err).Err()
+	}
+
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))), nil
+}
+
+// username is the router username of the player, the player id is a DNS label, so the lab id is split by the first dot
+func username(labID, playerID string) string {
+	return playerID + "." + labID
+}
@@ -2,14 +2,48 @@ package challenge
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"github.com/cybericebox/agent/internal/config"
+	"github.com/cybericebox/agent/internal/delivery/repository/postgres"
 	"github.com/cybericebox/agent/internal/model"
 	"github.com/cybericebox/agent/internal/tools"
 	"github.com/cybericebox/agent/pkg/appError"
+	"github.com/cybericebox/lib/pkg/ipam"
+	"github.com/gofrs/uuid"
 	"github.com/hashicorp/go-multierror"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
 )
 
+const (
+	flagSecretKey = "flag"
+
+	nodePortService     = "NodePort"
+	loadBalancerService = "LoadBalancer"
+
+	// allocatePublicPortAttempts limits the allocations of the free public port taken by the concurrent allocation
+	allocatePublicPortAttempts = 5
+)
+
+// capabilities are the linux capabilities the instance container can add, the ones out of the unprivileged capabilities
+// require the privileged permission of the lab group
+var capabilities = []string{
+	"AUDIT_CONTROL", "AUDIT_READ", "AUDIT_WRITE", "BLOCK_SUSPEND", "BPF", "CHECKPOINT_RESTORE", "CHOWN", "DAC_OVERRIDE",
+	"DAC_READ_SEARCH", "FOWNER", "FSETID", "IPC_LOCK", "IPC_OWNER", "KILL", "LEASE", "LINUX_IMMUTABLE", "MAC_ADMIN",
+	"MAC_OVERRIDE", "MKNOD", "NET_ADMIN", "NET_BIND_SERVICE", "NET_BROADCAST", "NET_RAW", "PERFMON", "SETFCAP", "SETGID",
+	"SETPCAP", "SETUID", "SYS_ADMIN", "SYS_BOOT", "SYS_CHROOT", "SYS_MODULE", "SYS_NICE", "SYS_PACCT", "SYS_PTRACE",
+	"SYS_RAWIO", "SYS_RESOURCE", "SYS_TIME", "SYS_TTY_CONFIG", "SYSLOG", "WAKE_ALARM",
+}
+
 type (
 	IInfrastructure interface {
 		DeploymentExists(ctx context.Context, name, namespace string) (bool, error)
@@ -18,24 +52,80 @@ type (
 		ResetDeployment(ctx context.Context, name, namespace string) error
 		ScaleDeployment(ctx context.Context, name, namespace string, scale int32) error
 		DeleteDeployment(ctx context.Context, name, namespace string) error
+
+		GetResourceQuotasBySelector(ctx context.Context, namespace string, selector ...string) ([]model.ResourceQuotaStatus, error)
+
+		ApplyInstanceEgressPolicy(ctx context.Context, labID, instanceID string, profile model.EgressProfile) error
+		DeleteInstanceEgressPolicy(ctx context.Context, labID, instanceID string) error
+		ApplyInstanceNetworkPolicy(ctx context.Context, labID, instanceID string, allowFrom, allowTo []model.NetworkRule) error
+		DeleteInstanceNetworkPolicy(ctx context.Context, labID, instanceID string) error
+
+		ApplyService(ctx context.Context, cfg model.ApplyServiceConfig) error
+		GetService(ctx context.Context, name, namespace string) (*model.ServiceStatus, error)
+		DeleteService(ctx context.Context, name, namespace string) error
+		ApplySecret(ctx context.Context, name, labID string, labels, data map[string]string) error
+		DeleteSecret(ctx context.Context, name, labID string) error
+		ApplyIngress(ctx context.Context, cfg model.ApplyIngressConfig) error
+		DeleteIngress(ctx context.Context, name, namespace string) error
+		ApplyIngressPolicy(ctx context.Context, labID, name string, podSelector map[string]string, ports []int32) error
+		DeleteIngressPolicy(ctx context.Context, labID, name string) error
+		ApplyPublicPortsPolicy(ctx context.Context, labID, name string, podSelector map[string]string, ports []model.PortConfig) error
+		DeletePublicPortsPolicy(ctx context.Context, labID, name string) error
+	}
+
+	// IRepository keeps the public ports allocated to the published instance ports and reads the lab group overcommit ratios
+	IRepository interface {
+		AllocatePublishedPort(ctx context.Context, arg postgres.AllocatePublishedPortParams) (int32, error)
+		GetPublishedPorts(ctx context.Context, labID uuid.UUID) ([]postgres.PublishedPort, error)
+		DeleteInstancePublishedPorts(ctx context.Context, arg postgres.DeleteInstancePublishedPortsParams) (int64, error)
+		GetLabGroup(ctx context.Context, id uuid.UUID) (postgres.LabGroup, error)
+	}
+
+	// iIPAM reserves the pinned instance addresses
+	iIPAM interface {
+		AcquireSpecificIP(ctx context.Context, cidr, ip string) error
 	}
 
 	ChallengeService struct {
 		infrastructure IInfrastructure
+		repository     IRepository
+		ipam           iIPAM
+		baseDomain     string
+		publish        config.PublishConfig
 	}
 
 	Dependencies struct {
 		Infrastructure IInfrastructure
+		Repository     IRepository
+		IPAM           iIPAM
+		// BaseDomain is the base domain of the lab hostnames, empty value disables the published HTTP ports
+		BaseDomain string
+		// Publish is the service type and the public ports range of the published TCP and UDP ports
+		Publish config.PublishConfig
 	}
 )
 
 func NewChallengeService(deps Dependencies) *ChallengeService {
 	return &ChallengeService{
 		infrastructure: deps.Infrastructure,
+		repository:     deps.Repository,
+		ipam:           deps.IPAM,
+		baseDomain:     deps.BaseDomain,
+		publish:        deps.Publish,
 	}
 }
 
 func (s *ChallengeService) CreateChallenge(ctx context.Context, lab *model.Lab, challengeConfig model.ChallengeConfig) (records []model.DNSRecordConfig, errs error) {
+	quota, err := s.getLabQuota(ctx, lab.ID.String())
+	if err != nil {
+		return nil, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to get lab resource quota").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).Err()
+	}
+
+	cpuRatio, memoryRatio, err := s.getLabOvercommit(ctx, lab)
+	if err != nil {
+		return nil, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to get lab overcommit ratios").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).Err()
+	}
+
 	for _, inst := range challengeConfig.Instances {
 		// check if the instance is already deployed
 		ex, err := s.infrastructure.DeploymentExists(ctx, inst.ID, lab.ID.String())
@@ -48,56 +138,452 @@ func (s *ChallengeService) CreateChallenge(ctx context.Context, lab *model.Lab,
 			continue
 		}
 
-		ip, err := lab.CIDRManager.AcquireSingleIP(ctx)
+		if err = tools.ValidateEgressProfile(inst.Egress); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessageF("Instance egress profile is invalid: %s", err.Error()).WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			continue
+		}
+
+		if err = tools.ValidateNetworkRules(slices.Concat(inst.AllowFrom, inst.AllowTo)); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessageF("Instance network rules are invalid: %s", err.Error()).WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			continue
+		}
+
+		if err = s.validateHTTPPorts(lab.ID.String(), inst.ID, inst.HTTPPorts); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessageF("Instance HTTP ports are invalid: %s", err.Error()).WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			continue
+		}
+
+		if err = validateService(inst.Service, inst.HTTPPorts); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessageF("Instance service is invalid: %s", err.Error()).WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			continue
+		}
+
+		if err = validateCapabilities(inst.CapAdds); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessageF("Instance capabilities are invalid: %s", err.Error()).WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			continue
+		}
+
+		if err = validateContainers(inst); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessageF("Instance containers are invalid: %s", err.Error()).WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			continue
+		}
+
+		if err = validateContainerSpec(inst); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessageF("Instance container is invalid: %s", err.Error()).WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			continue
+		}
+
+		if err = validateProbes(inst.ReadinessProbe, inst.LivenessProbe); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessageF("Instance probes are invalid: %s", err.Error()).WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			continue
+		}
+
+		if err = validateResources(inst); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessageF("Instance resources are invalid: %s", err.Error()).WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			continue
+		}
+
+		inst = withOvercommit(inst, cpuRatio, memoryRatio)
+
+		if err = s.validatePublishedPorts(inst.PublishedPorts); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessageF("Instance published ports are invalid: %s", err.Error()).WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			continue
+		}
+
+		requests := podRequests(inst)
+		if err = reserveQuota(quota, requests); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Instance does not fit into lab resource quota").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			continue
+		}
+
+		// the instance ip is from its segment
+		CIDRManager := lab.CIDRManager
+		if len(inst.Segments) > 0 {
+			if err = validateInstanceSegments(lab, inst.Segments); err != nil {
+				errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessageF("Instance segments are invalid: %s", err.Error()).WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				releaseQuota(quota, requests)
+				continue
+			}
+			CIDRManager = lab.GetSegment(inst.Segments[0]).CIDRManager
+		}
+
+		ip, err := s.acquireInstanceIP(ctx, CIDRManager, inst)
 		if err != nil {
 			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to acquire ip for instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			releaseQuota(quota, requests)
 			continue
 		}
 
+		// the IPv6 address is acquired after the instance ports are published, the empty one is not released by the rollback
+		var ipv6 string
+
 		dns, err := lab.CIDRManager.GetFirstIP()
 		if err != nil {
 			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to get dns ip for instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
-			if err = lab.CIDRManager.ReleaseSingleIP(ctx, ip); err != nil {
-				errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to release ip for instance in get dns: [%w]").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			if err = s.rollbackInstance(ctx, lab, CIDRManager, inst.ID, ip, ipv6); err != nil {
+				errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to roll back instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
 			}
+			releaseQuota(quota, requests)
 			continue
 		}
 
-		if err = s.infrastructure.ApplyDeployment(ctx, model.ApplyDeploymentConfig{
-			Name:  inst.ID,
-			LabID: lab.ID.String(),
-			Labels: map[string]string{
-				config.PlatformLabel:    config.Challenge,
+		labels := map[string]string{
+			config.PlatformLabel:    config.Challenge,
+			config.LabIDLabel:       lab.ID.String(),
+			config.ChallengeIDLabel: challengeConfig.ID,
+			config.InstanceIDLabel:  inst.ID,
+			config.RecordsListLabel: tools.RecordsToStr(inst.Records),
+		}
+		if inst.Service != nil {
+			labels[config.ServiceLabel] = config.ClusterIPService
+			if inst.Service.Headless {
+				labels[config.ServiceLabel] = config.HeadlessService
+			}
+		}
+		if tools.IsPrivilegedInstance(inst) {
+			labels[config.PrivilegedLabel] = config.PrivilegedTrue
+		}
+		if len(inst.Segments) > 0 {
+			labels[config.SegmentedLabel] = inst.Segments[0]
+			for _, segment := range inst.Segments {
+				labels[tools.SegmentMemberLabel(segment)] = segment
+			}
+		}
+
+		// the instance policy is applied before the instance starts
+		if inst.Egress.Mode != model.EgressModeDefault {
+			if err = s.infrastructure.ApplyInstanceEgressPolicy(ctx, lab.ID.String(), inst.ID, inst.Egress); err != nil {
+				errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to apply instance egress policy").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				if err = s.rollbackInstance(ctx, lab, CIDRManager, inst.ID, ip, ipv6); err != nil {
+					errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to roll back instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				}
+				releaseQuota(quota, requests)
+				continue
+			}
+			labels[config.EgressProfileLabel] = config.InstanceEgress
+		}
+
+		if len(inst.AllowFrom) > 0 || len(inst.AllowTo) > 0 {
+			if err = s.infrastructure.ApplyInstanceNetworkPolicy(ctx, lab.ID.String(), inst.ID, inst.AllowFrom, inst.AllowTo); err != nil {
+				errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to apply instance network policy").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				if err = s.rollbackInstance(ctx, lab, CIDRManager, inst.ID, ip, ipv6); err != nil {
+					errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to roll back instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				}
+				releaseQuota(quota, requests)
+				continue
+			}
+			if len(inst.AllowFrom) > 0 {
+				labels[config.IngressRulesLabel] = config.InstanceRules
+			}
+			if len(inst.AllowTo) > 0 {
+				labels[config.EgressRulesLabel] = config.InstanceRules
+			}
+		}
+
+		if len(inst.HTTPPorts) > 0 {
+			if err = s.publishHTTPPorts(ctx, lab.ID.String(), challengeConfig.ID, inst); err != nil {
+				errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to publish instance HTTP ports").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				if err = s.rollbackInstance(ctx, lab, CIDRManager, inst.ID, ip, ipv6); err != nil {
+					errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to roll back instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				}
+				releaseQuota(quota, requests)
+				continue
+			}
+		}
+
+		if len(inst.PublishedPorts) > 0 {
+			if err = s.publishPorts(ctx, lab.ID, challengeConfig.ID, inst); err != nil {
+				errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to publish instance ports").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				if err = s.rollbackInstance(ctx, lab, CIDRManager, inst.ID, ip, ipv6); err != nil {
+					errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to roll back instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				}
+				releaseQuota(quota, requests)
+				continue
+			}
+		}
+
+		// the segments are IPv4 only, so only the instances of the main lab network get the IPv6 address
+		if lab.IPv6CIDRManager != nil && len(inst.Segments) == 0 {
+			ipv6, err = lab.IPv6CIDRManager.AcquireSingleIP(ctx)
+			if err != nil {
+				errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to acquire IPv6 ip for instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				if err = s.rollbackInstance(ctx, lab, CIDRManager, inst.ID, ip, ipv6); err != nil {
+					errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to roll back instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				}
+				releaseQuota(quota, requests)
+				continue
+			}
+		}
+
+		// only the secret reference is written to the deployment, so the flag is not readable from the deployment spec
+		var secretEnvs []model.SecretEnvConfig
+		if inst.Flag != nil {
+			if err = s.infrastructure.ApplySecret(ctx, flagSecretName(inst.ID), lab.ID.String(), map[string]string{
+				config.PlatformLabel:    config.ChallengeFlag,
 				config.LabIDLabel:       lab.ID.String(),
 				config.ChallengeIDLabel: challengeConfig.ID,
 				config.InstanceIDLabel:  inst.ID,
-				config.RecordsListLabel: tools.RecordsToStr(inst.Records),
-			},
-			Image:        inst.Image,
-			IP:           ip,
-			DNS:          dns,
-			ReplicaCount: 1,
-			UsePublicDNS: true,
-			Resources:    inst.Resources,
-			Envs:         inst.Envs,
+			}, map[string]string{
+				flagSecretKey: inst.Flag.Value,
+			}); err != nil {
+				errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to apply instance flag secret").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				if err = s.rollbackInstance(ctx, lab, CIDRManager, inst.ID, ip, ipv6); err != nil {
+					errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to roll back instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				}
+				releaseQuota(quota, requests)
+				continue
+			}
+			secretEnvs = append(secretEnvs, model.SecretEnvConfig{
+				Name:       inst.Flag.Name,
+				SecretName: flagSecretName(inst.ID),
+				Key:        flagSecretKey,
+			})
+		}
+
+		if err = s.infrastructure.ApplyDeployment(ctx, model.ApplyDeploymentConfig{
+			Name:           inst.ID,
+			LabID:          lab.ID.String(),
+			Labels:         labels,
+			Image:          inst.Image,
+			IP:             ip,
+			IPv6:           ipv6,
+			DNS:            dns,
+			ReplicaCount:   1,
+			Resources:      inst.Resources,
+			Envs:           inst.Envs,
+			SecretEnvs:     secretEnvs,
+			Command:        inst.Command,
+			Args:           inst.Args,
+			WorkingDir:     inst.WorkingDir,
+			ContainerPorts: containerPorts(inst.ContainerPorts),
+			RunAs:          inst.RunAs,
+			Hostname:       inst.Hostname,
+			Privileged:     inst.Privileged,
+			CapAdds:        inst.CapAdds,
+			ReadinessProbe: inst.ReadinessProbe,
+			LivenessProbe:  inst.LivenessProbe,
+			Sidecars:       inst.Containers,
+			InitContainers: inst.InitContainers,
+			Service:        instanceService(inst),
 		}); err != nil {
 			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to apply deployment").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
-			if err = lab.CIDRManager.ReleaseSingleIP(ctx, ip); err != nil {
-				errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to release ip for instance in apply deployment: [%w]").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+			if err = s.rollbackInstance(ctx, lab, CIDRManager, inst.ID, ip, ipv6); err != nil {
+				errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to roll back instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
 			}
+			releaseQuota(quota, requests)
 			continue
 		}
 
+		// the instance ID of the instance with its own service is resolved to the service ip
+		if inst.Service != nil {
+			record, err := s.serviceRecord(ctx, lab.ID.String(), inst, ip)
+			if err != nil {
+				errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to get instance service record").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				// the instance without the DNS name is removed, so it is created again with the next attempt
+				if err = s.rollbackInstance(ctx, lab, CIDRManager, inst.ID, ip, ipv6); err != nil {
+					errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to roll back instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeConfig.ID).WithContext("instanceID", inst.ID).Err())
+				}
+				releaseQuota(quota, requests)
+				continue
+			}
+			records = append(records, record)
+		}
+
 		for _, r := range inst.Records {
 			if r.Type == "A" {
 				r.Data = ip
 			}
+			if r.Type == "AAAA" {
+				// the AAAA record of the instance without the IPv6 address is skipped
+				if ipv6 == "" {
+					continue
+				}
+				r.Data = ipv6
+			}
 			records = append(records, r)
 		}
 	}
 	return
 }
 
+// rollbackInstance removes the deployment, the service, the policies, the published ports and the flag secret of the instance
+// failed to be created and releases its addresses, the missing ones are skipped, so the next creation of the instance starts from scratch
+func (s *ChallengeService) rollbackInstance(ctx context.Context, lab *model.Lab, CIDRManager *ipam.IPAManager, instanceID, ip, ipv6 string) (errs error) {
+	if err := s.infrastructure.DeleteDeployment(ctx, instanceID, lab.ID.String()); err != nil {
+		errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete deployment").Err())
+	}
+
+	if err := s.infrastructure.DeleteService(ctx, instanceID, lab.ID.String()); err != nil {
+		errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete instance service").Err())
+	}
+
+	if err := s.infrastructure.DeleteInstanceEgressPolicy(ctx, lab.ID.String(), instanceID); err != nil {
+		errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete instance egress policy").Err())
+	}
+
+	if err := s.infrastructure.DeleteInstanceNetworkPolicy(ctx, lab.ID.String(), instanceID); err != nil {
+		errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete instance network policy").Err())
+	}
+
+	if err := s.unpublishHTTPPorts(ctx, lab.ID.String(), instanceID); err != nil {
+		errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to unpublish instance HTTP ports").Err())
+	}
+
+	if err := s.unpublishPorts(ctx, lab.ID, instanceID); err != nil {
+		errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to unpublish instance ports").Err())
+	}
+
+	if err := s.infrastructure.DeleteSecret(ctx, flagSecretName(instanceID), lab.ID.String()); err != nil {
+		errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete instance flag secret").Err())
+	}
+
+	if err := CIDRManager.ReleaseSingleIP(ctx, ip); err != nil {
+		errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to release ip for instance").Err())
+	}
+
+	if ipv6 != "" {
+		if err := lab.IPv6CIDRManager.ReleaseSingleIP(ctx, ipv6); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to release IPv6 ip for instance").Err())
+		}
+	}
+
+	return
+}
+
+// instanceService returns the service of the instance with the declared ports and the published HTTP ports,
+// nil value means the instance has neither its own service nor the HTTP ports
+func instanceService(inst model.InstanceConfig) *model.DeploymentServiceConfig {
+	if inst.Service == nil && len(inst.HTTPPorts) == 0 {
+		return nil
+	}
+
+	service := &model.DeploymentServiceConfig{}
+	if inst.Service != nil {
+		service.Headless = inst.Service.Headless
+		for _, port := range inst.Service.Ports {
+			protocol := portProtocol(port)
+			service.Ports = append(service.Ports, model.ServicePort{
+				Name:     fmt.Sprintf("%s-%d", strings.ToLower(protocol), port.Port),
+				Protocol: protocol,
+				Port:     port.Port,
+			})
+		}
+	}
+
+	for _, port := range inst.HTTPPorts {
+		// the HTTP port is already declared by the instance service
+		if slices.ContainsFunc(service.Ports, func(p model.ServicePort) bool {
+			return p.Port == port && p.Protocol == "TCP"
+		}) {
+			continue
+		}
+		service.Ports = append(service.Ports, model.ServicePort{
+			Name: fmt.Sprintf("http-%d", port),
+			Port: port,
+		})
+	}
+
+	return service
+}
+
+// serviceRecord returns the lab DNS record of the instance service, the headless service is resolved to the instance ip
+func (s *ChallengeService) serviceRecord(ctx context.Context, labID string, inst model.InstanceConfig, ip string) (model.DNSRecordConfig, error) {
+	record := model.DNSRecordConfig{
+		Type: "A",
+		Name: inst.ID,
+		Data: ip,
+	}
+
+	if inst.Service.Headless {
+		return record, nil
+	}
+
+	svc, err := s.infrastructure.GetService(ctx, inst.ID, labID)
+	if err != nil {
+		return model.DNSRecordConfig{}, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to get instance service").Err()
+	}
+
+	if svc.ClusterIP == "" {
+		return model.DNSRecordConfig{}, appError.ErrLabChallenge.WithMessage("Instance service has no cluster ip").Err()
+	}
+
+	record.Data = svc.ClusterIP
+	return record, nil
+}
+
+// acquireInstanceIP reserves the pinned ip or the host offset in the network, the next free ip is acquired if none of them is set
+func (s *ChallengeService) acquireInstanceIP(ctx context.Context, CIDRManager *ipam.IPAManager, inst model.InstanceConfig) (string, error) {
+	if inst.IP == "" && inst.IPOffset == 0 {
+		return CIDRManager.AcquireSingleIP(ctx)
+	}
+
+	if inst.IP != "" && inst.IPOffset != 0 {
+		return "", appError.ErrLabChallengeInvalidIP.WithMessage("Instance can have either the pinned ip or the host offset").Err()
+	}
+
+	network := netip.MustParsePrefix(CIDRManager.GetCIDR())
+
+	var addr netip.Addr
+	if inst.IPOffset != 0 {
+		var err error
+		if addr, err = hostIP(network, inst.IPOffset); err != nil {
+			return "", appError.ErrLabChallengeInvalidIP.WithError(err).WithMessageF("Instance host offset %d is invalid: %s", inst.IPOffset, err.Error()).Err()
+		}
+	} else {
+		var err error
+		if addr, err = netip.ParseAddr(inst.IP); err != nil {
+			return "", appError.ErrLabChallengeInvalidIP.WithError(err).WithMessageF("Pinned ip %s is invalid", inst.IP).Err()
+		}
+		if !network.Contains(addr) {
+			return "", appError.ErrLabChallengeInvalidIP.WithMessageF("Pinned ip %s is not in the instance network %s", inst.IP, network).Err()
+		}
+		// the pinned ip gets the same exclusions as the host offset
+		if addr.Is4() {
+			if offset := hostOffset(network, addr); offset == 0 {
+				return "", appError.ErrLabChallengeInvalidIP.WithMessageF("Pinned ip %s is the network address of %s", inst.IP, network).Err()
+			} else if _, err = hostIP(network, offset); err != nil {
+				return "", appError.ErrLabChallengeInvalidIP.WithError(err).WithMessageF("Pinned ip %s is the broadcast address of %s", inst.IP, network).Err()
+			}
+		}
+	}
+
+	// the IPAM manager accepts the already taken ip, so the ip is reserved directly
+	if err := s.ipam.AcquireSpecificIP(ctx, network.String(), addr.String()); err != nil {
+		if errors.Is(err, appError.ErrCIDRPoolIPTaken.Err()) {
+			return "", appError.ErrLabChallengeIPTaken.WithMessageF("Instance ip %s is already taken in the network %s", addr, network).Err()
+		}
+		return "", appError.ErrLabChallenge.WithError(err).WithMessageF("Failed to reserve instance ip %s", addr).Err()
+	}
+
+	return addr.String(), nil
+}
+
+// hostIP returns the address at the offset from the network address, the network and broadcast addresses are excluded
+func hostIP(network netip.Prefix, offset uint32) (netip.Addr, error) {
+	if !network.Addr().Is4() {
+		return netip.Addr{}, fmt.Errorf("network %s is not an IPv4 network", network)
+	}
+
+	size := uint64(1) << (32 - network.Bits())
+	if uint64(offset) >= size-1 {
+		return netip.Addr{}, fmt.Errorf("offset must be less than %d in the network %s", size-1, network)
+	}
+
+	base := network.Masked().Addr().As4()
+	ip := binary.BigEndian.Uint32(base[:]) + offset
+
+	var host [4]byte
+	binary.BigEndian.PutUint32(host[:], ip)
+	return netip.AddrFrom4(host), nil
+}
+
+// hostOffset returns the offset of the IPv4 address from the network address
+func hostOffset(network netip.Prefix, addr netip.Addr) uint32 {
+	base := network.Masked().Addr().As4()
+	host := addr.As4()
+	return binary.BigEndian.Uint32(host[:]) - binary.BigEndian.Uint32(base[:])
+}
+
 func (s *ChallengeService) DeleteChallenge(ctx context.Context, lab *model.Lab, challengeID string) (records []model.DNSRecordConfig, errs error) {
 	dps, err := s.infrastructure.GetDeploymentsInNamespaceBySelector(ctx, lab.ID.String(),
 		fmt.Sprintf("%s=%s", config.PlatformLabel, config.Challenge),
@@ -115,11 +601,55 @@ func (s *ChallengeService) DeleteChallenge(ctx context.Context, lab *model.Lab,
 			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete deployment").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeID).Err())
 		}
 
-		if err = lab.CIDRManager.ReleaseSingleIP(ctx, dp.IP); err != nil {
+		if err = s.infrastructure.DeleteInstanceEgressPolicy(ctx, lab.ID.String(), dp.Name); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete instance egress policy").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeID).Err())
+		}
+
+		if err = s.infrastructure.DeleteInstanceNetworkPolicy(ctx, lab.ID.String(), dp.Name); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete instance network policy").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeID).Err())
+		}
+
+		if err = s.infrastructure.DeleteService(ctx, dp.Name, lab.ID.String()); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete instance service").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeID).Err())
+		}
+
+		if err = s.infrastructure.DeleteSecret(ctx, flagSecretName(dp.Name), lab.ID.String()); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete instance flag secret").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeID).Err())
+		}
+
+		if err = s.unpublishHTTPPorts(ctx, lab.ID.String(), dp.Name); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to unpublish instance HTTP ports").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeID).Err())
+		}
+
+		if err = s.unpublishPorts(ctx, lab.ID, dp.Name); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to unpublish instance ports").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeID).Err())
+		}
+
+		if err = lab.GetCIDRManagerByIP(dp.IP).ReleaseSingleIP(ctx, dp.IP); err != nil {
 			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to release ip for instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeID).Err())
 		}
 
-		records = append(records, tools.RecordsFromStr(dp.Labels[config.RecordsListLabel])...)
+		if dp.IPv6 != "" && lab.IPv6CIDRManager != nil {
+			if err = lab.IPv6CIDRManager.ReleaseSingleIP(ctx, dp.IPv6); err != nil {
+				errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to release IPv6 ip for instance").WithContext("labID", lab.ID.String()).WithContext("challengeID", challengeID).Err())
+			}
+		}
+
+		for _, r := range tools.RecordsFromStr(dp.Labels[config.RecordsListLabel]) {
+			// the AAAA record is added only for the instance with the IPv6 address
+			if r.Type == "AAAA" && dp.IPv6 == "" {
+				continue
+			}
+			records = append(records, r)
+		}
+
+		// only the name and the type of the service record are required to delete it
+		if dp.Labels[config.ServiceLabel] != "" {
+			records = append(records, model.DNSRecordConfig{
+				Type: "A",
+				Name: dp.Name,
+			})
+		}
 	}
 
 	return
@@ -178,3 +708,611 @@ func (s *ChallengeService) ResetChallenge(ctx context.Context, labID, challengeI
 	}
 	return
 }
+
+// validateHTTPPorts checks that the published ports are valid and unique, the ports require the lab hostnames
+func (s *ChallengeService) validateHTTPPorts(labID, instanceID string, ports []int32) error {
+	if len(ports) == 0 {
+		return nil
+	}
+
+	if s.baseDomain == "" {
+		return appError.ErrLabChallengeInvalidPorts.WithMessage("HTTP ports require the ingress base domain").Err()
+	}
+
+	for i, port := range ports {
+		if port < 1 || port > 65535 {
+			return appError.ErrLabChallengeInvalidPorts.WithMessageF("HTTP port %d is out of range", port).Err()
+		}
+		if slices.Contains(ports[:i], port) {
+			return appError.ErrLabChallengeInvalidPorts.WithMessageF("HTTP port %d is published more than once", port).Err()
+		}
+		// the wildcard certificate of the base domain covers the single label hostnames only
+		if errs := validation.IsDNS1123Label(httpHostLabel(labID, instanceID, i, port)); len(errs) != 0 {
+			return appError.ErrLabChallengeInvalidPorts.WithMessageF("HTTP port %d hostname is invalid: %s", port, strings.Join(errs, "; ")).Err()
+		}
+	}
+
+	return nil
+}
+
+// publishHTTPPorts exposes the instance ports by the ingress of the instance lab hostnames, the ingress routes to the instance service.
+// The first port is served by <instance>-<lab>.<baseDomain> and the others by <instance>-<port>-<lab>.<baseDomain>
+func (s *ChallengeService) publishHTTPPorts(ctx context.Context, labID, challengeID string, inst model.InstanceConfig) error {
+	labels := map[string]string{
+		config.PlatformLabel:    config.Challenge,
+		config.LabIDLabel:       labID,
+		config.ChallengeIDLabel: challengeID,
+		config.InstanceIDLabel:  inst.ID,
+	}
+	selector := map[string]string{
+		config.InstanceIDLabel: inst.ID,
+	}
+
+	if err := s.infrastructure.ApplyIngressPolicy(ctx, labID, inst.ID, selector, inst.HTTPPorts); err != nil {
+		return appError.ErrLabChallenge.WithError(err).WithMessage("Failed to apply instance ingress policy").Err()
+	}
+
+	rules := make([]model.IngressRule, 0, len(inst.HTTPPorts))
+	for i, port := range inst.HTTPPorts {
+		rules = append(rules, model.IngressRule{
+			Host: fmt.Sprintf("%s.%s", httpHostLabel(labID, inst.ID, i, port), s.baseDomain),
+			Port: port,
+		})
+	}
+
+	if err := s.infrastructure.ApplyIngress(ctx, model.ApplyIngressConfig{
+		Name:        inst.ID,
+		LabID:       labID,
+		Labels:      labels,
+		ServiceName: inst.ID,
+		Rules:       rules,
+	}); err != nil {
+		return appError.ErrLabChallenge.WithError(err).WithMessage("Failed to apply instance ingress").Err()
+	}
+
+	return nil
+}
+
+// httpHostLabel returns the hostname label of the instance HTTP port, the first port is served without the port number
+func httpHostLabel(labID, instanceID string, i int, port int32) string {
+	if i == 0 {
+		return fmt.Sprintf("%s-%s", instanceID, labID)
+	}
+	return fmt.Sprintf("%s-%d-%s", instanceID, port, labID)
+}
+
+// unpublishHTTPPorts removes the ingress and the ingress policy of the instance, the missing ones are skipped
+func (s *ChallengeService) unpublishHTTPPorts(ctx context.Context, labID, instanceID string) (errs error) {
+	if err := s.infrastructure.DeleteIngress(ctx, instanceID, labID); err != nil {
+		errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete instance ingress").Err())
+	}
+
+	if err := s.infrastructure.DeleteIngressPolicy(ctx, labID, instanceID); err != nil {
+		errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete instance ingress policy").Err())
+	}
+
+	return
+}
+
+// validatePublishedPorts checks that the published ports are valid TCP or UDP ports published once, the NodePort services require the public host
+func (s *ChallengeService) validatePublishedPorts(ports []model.PortConfig) error {
+	if len(ports) == 0 {
+		return nil
+	}
+
+	switch s.publish.ServiceType {
+	case nodePortService:
+		if s.publish.Host == "" {
+			return appError.ErrLabChallengeInvalidPorts.WithMessage("Published ports require the public host of the NodePort services").Err()
+		}
+	case loadBalancerService:
+	default:
+		return appError.ErrLabChallengeInvalidPorts.WithMessageF("Published ports service type %s is not supported", s.publish.ServiceType).Err()
+	}
+
+	for i, port := range ports {
+		if port.Port < 1 || port.Port > 65535 {
+			return appError.ErrLabChallengeInvalidPorts.WithMessageF("Published port %d is out of range", port.Port).Err()
+		}
+		protocol := portProtocol(port)
+		if protocol != "TCP" && protocol != "UDP" {
+			return appError.ErrLabChallengeInvalidPorts.WithMessageF("Published port %d protocol %s is not TCP or UDP", port.Port, port.Protocol).Err()
+		}
+		if slices.ContainsFunc(ports[:i], func(p model.PortConfig) bool {
+			return p.Port == port.Port && portProtocol(p) == protocol
+		}) {
+			return appError.ErrLabChallengeInvalidPorts.WithMessageF("Published port %d/%s is published more than once", port.Port, protocol).Err()
+		}
+	}
+
+	return nil
+}
+
+// publishPorts allocates the public ports of the instance ports and exposes them by the public service of the instance,
+// the NodePort service uses them as the node ports and the LoadBalancer service as the service ports
+func (s *ChallengeService) publishPorts(ctx context.Context, labID uuid.UUID, challengeID string, inst model.InstanceConfig) (errs error) {
+	// the allocated public ports are released if the instance ports are not published
+	defer func() {
+		if errs == nil {
+			return
+		}
+		if _, err := s.repository.DeleteInstancePublishedPorts(ctx, postgres.DeleteInstancePublishedPortsParams{
+			LabID:      labID,
+			InstanceID: inst.ID,
+		}); err != nil {
+			errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to release instance public ports").Err())
+		}
+	}()
+
+	labels := map[string]string{
+		config.PlatformLabel:    config.Challenge,
+		config.LabIDLabel:       labID.String(),
+		config.ChallengeIDLabel: challengeID,
+		config.InstanceIDLabel:  inst.ID,
+	}
+	selector := map[string]string{
+		config.InstanceIDLabel: inst.ID,
+	}
+
+	ports := make([]model.ServicePort, 0, len(inst.PublishedPorts))
+	policyPorts := make([]model.PortConfig, 0, len(inst.PublishedPorts))
+	for _, port := range inst.PublishedPorts {
+		protocol := portProtocol(port)
+		policyPorts = append(policyPorts, model.PortConfig{Port: port.Port, Protocol: protocol})
+
+		publicPort, err := s.allocatePublicPort(ctx, postgres.AllocatePublishedPortParams{
+			LabID:       labID,
+			ChallengeID: challengeID,
+			InstanceID:  inst.ID,
+			Port:        port.Port,
+			Protocol:    protocol,
+			RangeStart:  s.publish.PortRangeStart,
+			RangeEnd:    s.publish.PortRangeEnd,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return appError.ErrLabChallengePortsExhausted.WithContext("range", fmt.Sprintf("%d-%d", s.publish.PortRangeStart, s.publish.PortRangeEnd)).Err()
+			}
+			return appError.ErrLabChallenge.WithError(err).WithMessageF("Failed to allocate public port for port %d/%s", port.Port, protocol).Err()
+		}
+
+		servicePort := model.ServicePort{
+			Name:       fmt.Sprintf("%s-%d", strings.ToLower(protocol), port.Port),
+			Protocol:   protocol,
+			Port:       publicPort,
+			TargetPort: port.Port,
+		}
+		if s.publish.ServiceType == nodePortService {
+			servicePort.Port = port.Port
+			servicePort.NodePort = publicPort
+		}
+		ports = append(ports, servicePort)
+	}
+
+	if err := s.infrastructure.ApplyPublicPortsPolicy(ctx, labID.String(), inst.ID, selector, policyPorts); err != nil {
+		return appError.ErrLabChallenge.WithError(err).WithMessage("Failed to apply instance public ports policy").Err()
+	}
+
+	if err := s.infrastructure.ApplyService(ctx, model.ApplyServiceConfig{
+		Name:     publicServiceName(inst.ID),
+		LabID:    labID.String(),
+		Labels:   labels,
+		Selector: selector,
+		Type:     s.publish.ServiceType,
+		Ports:    ports,
+	}); err != nil {
+		return appError.ErrLabChallenge.WithError(err).WithMessage("Failed to apply instance public service").Err()
+	}
+
+	return nil
+}
+
+// allocatePublicPort allocates the first free public port, the allocation is repeated
+// if the same port is allocated concurrently and the insert violates the unique public port
+func (s *ChallengeService) allocatePublicPort(ctx context.Context, arg postgres.AllocatePublishedPortParams) (publicPort int32, err error) {
+	for range allocatePublicPortAttempts {
+		publicPort, err = s.repository.AllocatePublishedPort(ctx, arg)
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) || pgErr.Code != pgerrcode.UniqueViolation {
+			return
+		}
+	}
+
+	return
+}
+
+// unpublishPorts removes the public service and the public ports policy of the instance and releases its public ports, the missing ones are skipped
+func (s *ChallengeService) unpublishPorts(ctx context.Context, labID uuid.UUID, instanceID string) (errs error) {
+	if err := s.infrastructure.DeleteService(ctx, publicServiceName(instanceID), labID.String()); err != nil {
+		errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete instance public service").Err())
+	}
+
+	if err := s.infrastructure.DeletePublicPortsPolicy(ctx, labID.String(), instanceID); err != nil {
+		errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to delete instance public ports policy").Err())
+	}
+
+	if _, err := s.repository.DeleteInstancePublishedPorts(ctx, postgres.DeleteInstancePublishedPortsParams{
+		LabID:      labID,
+		InstanceID: instanceID,
+	}); err != nil {
+		errs = multierror.Append(errs, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to release instance public ports").Err())
+	}
+
+	return
+}
+
+// GetPublishedPorts returns the public addresses of the published instance ports of the lab,
+// the address is empty while the load balancer of the instance has no external address
+func (s *ChallengeService) GetPublishedPorts(ctx context.Context, labID string) ([]model.PublishedPort, error) {
+	publishedPorts, err := s.repository.GetPublishedPorts(ctx, uuid.FromStringOrNil(labID))
+	if err != nil {
+		return nil, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to get published ports").WithContext("labID", labID).Err()
+	}
+
+	// the load balancer addresses of the instances, the instance without the public service has no address
+	hosts := make(map[string]string)
+	ports := make([]model.PublishedPort, 0, len(publishedPorts))
+	for _, p := range publishedPorts {
+		host := s.publish.Host
+		if s.publish.ServiceType == loadBalancerService {
+			var ok bool
+			if host, ok = hosts[p.InstanceID]; !ok {
+				if svc, err := s.infrastructure.GetService(ctx, publicServiceName(p.InstanceID), labID); err == nil && len(svc.ExternalAddresses) > 0 {
+					host = svc.ExternalAddresses[0]
+				}
+				hosts[p.InstanceID] = host
+			}
+		}
+
+		var address string
+		if host != "" {
+			address = net.JoinHostPort(host, strconv.Itoa(int(p.PublicPort)))
+		}
+
+		ports = append(ports, model.PublishedPort{
+			ChallengeID: p.ChallengeID,
+			InstanceID:  p.InstanceID,
+			Port:        p.Port,
+			Protocol:    p.Protocol,
+			Address:     address,
+		})
+	}
+
+	return ports, nil
+}
+
+// flagSecretName is the name of the instance flag secret, the flag is stored under flagSecretKey
+func flagSecretName(instanceID string) string {
+	return fmt.Sprintf("%s-flag", instanceID)
+}
+
+func publicServiceName(instanceID string) string {
+	return fmt.Sprintf("%s-public", instanceID)
+}
+
+// portProtocol returns the protocol of the port, empty protocol is TCP
+func portProtocol(port model.PortConfig) string {
+	if port.Protocol == "" {
+		return "TCP"
+	}
+	return strings.ToUpper(port.Protocol)
+}
+
+// validateService checks that the service ports are valid and declared once, the cluster ip service requires at least one port
+func validateService(service *model.InstanceServiceConfig, httpPorts []int32) error {
+	if service == nil {
+		return nil
+	}
+
+	if !service.Headless && len(service.Ports) == 0 && len(httpPorts) == 0 {
+		return appError.ErrLabChallengeInvalidPorts.WithMessage("Cluster IP service requires at least one port").Err()
+	}
+
+	for i, port := range service.Ports {
+		if port.Port < 1 || port.Port > 65535 {
+			return appError.ErrLabChallengeInvalidPorts.WithMessageF("Service port %d is out of range", port.Port).Err()
+		}
+		protocol := portProtocol(port)
+		if !slices.Contains([]string{"TCP", "UDP", "SCTP"}, protocol) {
+			return appError.ErrLabChallengeInvalidPorts.WithMessageF("Service port %d protocol %s is not TCP, UDP or SCTP", port.Port, port.Protocol).Err()
+		}
+		if slices.ContainsFunc(service.Ports[:i], func(p model.PortConfig) bool {
+			return p.Port == port.Port && portProtocol(p) == protocol
+		}) {
+			return appError.ErrLabChallengeInvalidPorts.WithMessageF("Service port %d/%s is declared more than once", port.Port, protocol).Err()
+		}
+	}
+
+	return nil
+}
+
+// validateCapabilities checks that the capabilities are the linux capability names without the CAP_ prefix
+func validateCapabilities(capAdds []string) error {
+	for i, capAdd := range capAdds {
+		// ALL is not accepted, the instance with all the capabilities is the privileged one
+		if !slices.Contains(capabilities, capAdd) {
+			return appError.ErrLabChallengeInvalidCapability.WithMessageF("Capability %s is not a linux capability name like NET_ADMIN", capAdd).Err()
+		}
+		if slices.Contains(capAdds[:i], capAdd) {
+			return appError.ErrLabChallengeInvalidCapability.WithMessageF("Capability %s is added more than once", capAdd).Err()
+		}
+	}
+	return nil
+}
+
+// validateContainers checks that the additional containers have the images and the names unique in the pod,
+// the instance container is named by the instance ID
+func validateContainers(inst model.InstanceConfig) error {
+	names := []string{inst.ID}
+	for _, c := range slices.Concat(inst.Containers, inst.InitContainers) {
+		if errs := validation.IsDNS1123Label(c.Name); len(errs) != 0 {
+			return appError.ErrLabChallengeInvalidContainer.WithMessageF("Container name %s is invalid: %s", c.Name, strings.Join(errs, "; ")).Err()
+		}
+		if slices.Contains(names, c.Name) {
+			return appError.ErrLabChallengeInvalidContainer.WithMessageF("Container name %s is used more than once", c.Name).Err()
+		}
+		if c.Image == "" {
+			return appError.ErrLabChallengeInvalidContainer.WithMessageF("Container %s has no image", c.Name).Err()
+		}
+		names = append(names, c.Name)
+	}
+	return nil
+}
+
+// validateContainerSpec checks the declared ports, the user and the group and the hostname of the instance container
+func validateContainerSpec(inst model.InstanceConfig) error {
+	for i, port := range inst.ContainerPorts {
+		if port.Port < 1 || port.Port > 65535 {
+			return appError.ErrLabChallengeInvalidContainer.WithMessageF("Container port %d is out of range", port.Port).Err()
+		}
+		protocol := portProtocol(port)
+		if !slices.Contains([]string{"TCP", "UDP", "SCTP"}, protocol) {
+			return appError.ErrLabChallengeInvalidContainer.WithMessageF("Container port %d protocol %s is not TCP, UDP or SCTP", port.Port, port.Protocol).Err()
+		}
+		if slices.ContainsFunc(inst.ContainerPorts[:i], func(p model.PortConfig) bool {
+			return p.Port == port.Port && portProtocol(p) == protocol
+		}) {
+			return appError.ErrLabChallengeInvalidContainer.WithMessageF("Container port %d/%s is declared more than once", port.Port, protocol).Err()
+		}
+	}
+
+	if inst.RunAs != nil && (inst.RunAs.UserID < 0 || inst.RunAs.GroupID < 0) {
+		return appError.ErrLabChallengeInvalidContainer.WithMessage("Container user and group IDs must not be negative").Err()
+	}
+
+	if inst.Hostname != "" {
+		if errs := validation.IsDNS1123Label(inst.Hostname); len(errs) != 0 {
+			return appError.ErrLabChallengeInvalidContainer.WithMessageF("Hostname %s is invalid: %s", inst.Hostname, strings.Join(errs, "; ")).Err()
+		}
+	}
+
+	return nil
+}
+
+// containerPorts returns the ports with the protocols in the kubernetes form
+func containerPorts(ports []model.PortConfig) []model.PortConfig {
+	converted := make([]model.PortConfig, 0, len(ports))
+	for _, port := range ports {
+		converted = append(converted, model.PortConfig{
+			Port:     port.Port,
+			Protocol: portProtocol(port),
+		})
+	}
+	return converted
+}
+
+// validateResources checks that the resources of the instance containers are not negative and the requests do not exceed the set limits
+func validateResources(inst model.InstanceConfig) error {
+	check := func(name string, resources model.ResourcesConfig) error {
+		req, lim := resources.Requests, resources.Limit
+		if min(req.CPU, req.Memory, req.EphemeralStorage, lim.CPU, lim.Memory, lim.EphemeralStorage) < 0 {
+			return appError.ErrLabChallengeInvalidResources.WithMessageF("Container %s resources must not be negative", name).Err()
+		}
+		if (lim.CPU != 0 && req.CPU > lim.CPU) || (lim.Memory != 0 && req.Memory > lim.Memory) || (lim.EphemeralStorage != 0 && req.EphemeralStorage > lim.EphemeralStorage) {
+			return appError.ErrLabChallengeInvalidResources.WithMessageF("Container %s requests exceed its limits", name).Err()
+		}
+		return nil
+	}
+
+	if err := check(inst.ID, inst.Resources); err != nil {
+		return err
+	}
+	for _, c := range slices.Concat(inst.Containers, inst.InitContainers) {
+		if err := check(c.Name, c.Resources); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withOvercommit returns the instance with the unset CPU and memory requests of its containers set to the limits divided by the ratios
+func withOvercommit(inst model.InstanceConfig, cpuRatio, memoryRatio float64) model.InstanceConfig {
+	overcommit := func(resources model.ResourcesConfig) model.ResourcesConfig {
+		if resources.Requests.CPU == 0 && resources.Limit.CPU != 0 {
+			resources.Requests.CPU = max(1, int64(float64(resources.Limit.CPU)/max(1, cpuRatio)))
+		}
+		if resources.Requests.Memory == 0 && resources.Limit.Memory != 0 {
+			resources.Requests.Memory = max(1, int64(float64(resources.Limit.Memory)/max(1, memoryRatio)))
+		}
+		return resources
+	}
+
+	inst.Resources = overcommit(inst.Resources)
+
+	// the containers are copied, the challenge config is shared by the labs
+	containers := make([]model.ContainerConfig, 0, len(inst.Containers))
+	for _, c := range inst.Containers {
+		c.Resources = overcommit(c.Resources)
+		containers = append(containers, c)
+	}
+	inst.Containers = containers
+
+	initContainers := make([]model.ContainerConfig, 0, len(inst.InitContainers))
+	for _, c := range inst.InitContainers {
+		c.Resources = overcommit(c.Resources)
+		initContainers = append(initContainers, c)
+	}
+	inst.InitContainers = initContainers
+
+	return inst
+}
+
+// getLabOvercommit returns the overcommit ratios of the lab group, zero ratios are returned for the lab without the group
+func (s *ChallengeService) getLabOvercommit(ctx context.Context, lab *model.Lab) (float64, float64, error) {
+	if lab.GroupID.IsNil() {
+		return 0, 0, nil
+	}
+
+	group, err := s.repository.GetLabGroup(ctx, lab.GroupID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, 0, nil
+		}
+		return 0, 0, appError.ErrLabChallenge.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to get lab group from db").WithContext("labID", lab.ID.String()).Err()
+	}
+
+	return group.CpuOvercommitRatio, group.MemoryOvercommitRatio, nil
+}
+
+// podRequests returns the resources requested by the instance pod, the init containers run one by one before the others,
+// so the pod needs the largest of them or the sum of the instance containers
+func podRequests(inst model.InstanceConfig) model.ResourceConfig {
+	// a container without a request gets its limit as the request
+	requests := func(resources model.ResourcesConfig) model.ResourceConfig {
+		req := resources.Requests
+		if req.CPU == 0 {
+			req.CPU = resources.Limit.CPU
+		}
+		if req.Memory == 0 {
+			req.Memory = resources.Limit.Memory
+		}
+		if req.EphemeralStorage == 0 {
+			req.EphemeralStorage = resources.Limit.EphemeralStorage
+		}
+		return req
+	}
+
+	pod := requests(inst.Resources)
+	for _, c := range inst.Containers {
+		req := requests(c.Resources)
+		pod.CPU += req.CPU
+		pod.Memory += req.Memory
+		pod.EphemeralStorage += req.EphemeralStorage
+	}
+	for _, c := range inst.InitContainers {
+		req := requests(c.Resources)
+		pod.CPU = max(pod.CPU, req.CPU)
+		pod.Memory = max(pod.Memory, req.Memory)
+		pod.EphemeralStorage = max(pod.EphemeralStorage, req.EphemeralStorage)
+	}
+	return pod
+}
+
+// validateProbes checks that each probe has exactly one of the exec command, the HTTP port or the TCP port
+func validateProbes(probes ...*model.Probe) error {
+	for _, p := range probes {
+		if p == nil {
+			continue
+		}
+
+		handlers := 0
+		if len(p.Cmd) > 0 {
+			handlers++
+		}
+		if p.HTTPPort != 0 {
+			handlers++
+		}
+		if p.TCPPort != 0 {
+			handlers++
+		}
+		if handlers != 1 {
+			return appError.ErrLabChallengeInvalidProbe.WithMessage("Probe must have exactly one of the command, the HTTP port or the TCP port").Err()
+		}
+
+		if p.HTTPPort < 0 || p.HTTPPort > 65535 || p.TCPPort < 0 || p.TCPPort > 65535 {
+			return appError.ErrLabChallengeInvalidProbe.WithMessage("Probe port is out of range").Err()
+		}
+
+		if p.HTTPPath != "" && !strings.HasPrefix(p.HTTPPath, "/") {
+			return appError.ErrLabChallengeInvalidProbe.WithMessageF("Probe HTTP path %s must start with /", p.HTTPPath).Err()
+		}
+
+		if p.InitialDelaySeconds < 0 || p.PeriodSeconds < 0 || p.FailureThreshold < 0 {
+			return appError.ErrLabChallengeInvalidProbe.WithMessage("Probe timings must not be negative").Err()
+		}
+	}
+	return nil
+}
+
+// validateInstanceSegments checks that the instance joins one existing lab segment, the pod has a single network
+// interface, so the segment membership is the address of the instance and the network policies admitting it
+func validateInstanceSegments(lab *model.Lab, segments []string) error {
+	if len(segments) > 1 {
+		return fmt.Errorf("instance joins %d segments, only one segment is supported", len(segments))
+	}
+	for _, segment := range segments {
+		if lab.GetSegment(segment) == nil {
+			return fmt.Errorf("lab has no segment %s", segment)
+		}
+	}
+	return nil
+}
+
+// getLabQuota returns the lab resource quota status, or nil if the lab has no quota
+func (s *ChallengeService) getLabQuota(ctx context.Context, labID string) (*model.QuotaStatus, error) {
+	quotas, err := s.infrastructure.GetResourceQuotasBySelector(ctx, labID, fmt.Sprintf("%s=%s", config.PlatformLabel, config.LabQuota))
+	if err != nil {
+		return nil, appError.ErrLabChallenge.WithError(err).WithMessage("Failed to get resource quotas").WithContext("labID", labID).Err()
+	}
+
+	if len(quotas) == 0 {
+		return nil, nil
+	}
+
+	return &model.QuotaStatus{
+		Hard: quotas[0].Hard,
+		Used: quotas[0].Used,
+	}, nil
+}
+
+// reserveQuota checks that the instance fits into the remaining quota and marks its resources as used
+func reserveQuota(quota *model.QuotaStatus, resources model.ResourceConfig) error {
+	if quota == nil {
+		return nil
+	}
+
+	if quota.Hard.Pods != 0 && quota.Used.Pods+1 > quota.Hard.Pods {
+		return appError.ErrLabChallengeQuotaExceeded.WithMessageF("Pods quota exceeded: %d of %d pods are used", quota.Used.Pods, quota.Hard.Pods).Err()
+	}
+
+	if quota.Hard.CPU != 0 && quota.Used.CPU+resources.CPU > quota.Hard.CPU {
+		return appError.ErrLabChallengeQuotaExceeded.WithMessageF("CPU quota exceeded: requested %dm, available %dm of %dm", resources.CPU, quota.Hard.CPU-quota.Used.CPU, quota.Hard.CPU).Err()
+	}
+
+	if quota.Hard.Memory != 0 && quota.Used.Memory+resources.Memory > quota.Hard.Memory {
+		return appError.ErrLabChallengeQuotaExceeded.WithMessageF("Memory quota exceeded: requested %d bytes, available %d of %d bytes", resources.Memory, quota.Hard.Memory-quota.Used.Memory, quota.Hard.Memory).Err()
+	}
+
+	if quota.Hard.EphemeralStorage != 0 && quota.Used.EphemeralStorage+resources.EphemeralStorage > quota.Hard.EphemeralStorage {
+		return appError.ErrLabChallengeQuotaExceeded.WithMessageF("Ephemeral storage quota exceeded: requested %d bytes, available %d of %d bytes", resources.EphemeralStorage, quota.Hard.EphemeralStorage-quota.Used.EphemeralStorage, quota.Hard.EphemeralStorage).Err()
+	}
+
+	quota.Used.Pods++
+	quota.Used.CPU += resources.CPU
+	quota.Used.Memory += resources.Memory
+	quota.Used.EphemeralStorage += resources.EphemeralStorage
+
+	return nil
+}
+
+// releaseQuota returns the resources of the instance failed to be created to the remaining quota
+func releaseQuota(quota *model.QuotaStatus, resources model.ResourceConfig) {
+	if quota == nil {
+		return
+	}
+
+	quota.Used.Pods--
+	quota.Used.CPU -= resources.CPU
+	quota.Used.Memory -= resources.Memory
+	quota.Used.EphemeralStorage -= resources.EphemeralStorage
+}
@@ -3,14 +3,17 @@ package dns
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/cybericebox/agent/internal/config"
 	"github.com/cybericebox/agent/internal/model"
 	"github.com/cybericebox/agent/internal/tools"
 	"github.com/cybericebox/agent/pkg/appError"
 	"github.com/hashicorp/go-multierror"
+	"net/netip"
 	"slices"
 	"strconv"
+	"strings"
 	"text/template"
 	"time"
 )
@@ -24,17 +27,24 @@ const (
 	Cpu10m = 10
 	image  = "cybericebox/coredns:1.12.0"
 
-	coreFile = "Corefile"
-	zoneFile = "zonefile"
+	coreFile   = "Corefile"
+	zoneFile   = "zonefile"
+	egressFile = "egress"
 
+	// the filtered server resolves only the lab records and the allowed domains
 	coreFileContent = `. {
 errors         # show errors
 file /dns/zonefile {
 	reload 5s	
 }
-alternate original NXDOMAIN . 8.8.8.8 1.1.1.1
+{{if not .Filtered}}alternate original NXDOMAIN . {{.Upstreams}}
+{{end}}}
+{{range .Domains}}
+{{.}} {
+errors
+forward . {{$.Upstreams}}
 }
-`
+{{end}}`
 	zonePrefixContent = `$ORIGIN .
 @   3600 IN SOA sns.dns.icann.org. noc.dns.icann.org. (
                 {{.Serial}} ; serial
@@ -55,47 +65,86 @@ type (
 		Records []model.DNSRecordConfig
 	}
 
+	coreConfig struct {
+		Filtered  bool
+		Upstreams string
+		Domains   []string
+	}
+
+	// egressConfig is the lab egress state stored with the DNS config
+	egressConfig struct {
+		// Filtered is set when the lab egress profile does not allow the internet
+		Filtered bool
+		// Lab are the domains allowed by the lab egress profile
+		Lab []string
+		// Challenges are the domains allowed by the challenge instances egress profiles
+		Challenges map[string][]string
+	}
+
 	IInfrastructure interface {
 		ApplyDeployment(ctx context.Context, config model.ApplyDeploymentConfig) error
 		ResetDeployment(ctx context.Context, name, namespace string) error
 
 		ApplyConfigMap(ctx context.Context, name, namespace string, data map[string]string) error
 		GetConfigMapData(ctx context.Context, name, namespace string) (map[string]string, error)
+
+		ApplyDNSEgressPolicy(ctx context.Context, labID string, upstreams []string) error
 	}
 	DNSService struct {
 		infrastructure IInfrastructure
+		upstreams      []string
 	}
 
 	DNSServer struct {
 		labID          string
+		upstreams      []string
 		records        []model.DNSRecordConfig
+		egress         egressConfig
 		infrastructure IInfrastructure
 	}
 )
 
-func NewDNSService(infrastructure IInfrastructure) *DNSService {
+// NewDNSService returns the DNS service, the upstreams must be IP addresses because the DNS server egress policy allows only them
+func NewDNSService(infrastructure IInfrastructure, upstreams []string) (*DNSService, error) {
+	if len(upstreams) == 0 {
+		return nil, fmt.Errorf("at least one DNS upstream is required")
+	}
+
+	for _, upstream := range upstreams {
+		if _, err := netip.ParseAddr(upstream); err != nil {
+			return nil, fmt.Errorf("DNS upstream %s must be an IP address: %w", upstream, err)
+		}
+	}
+
 	return &DNSService{
 		infrastructure: infrastructure,
-	}
+		upstreams:      upstreams,
+	}, nil
 }
 
-// CreateDNSServer creates a new DNS server for the lab
-func (dns *DNSService) CreateDNSServer(ctx context.Context, labID, ip string) error {
+// CreateDNSServer creates a new DNS server for the lab, the egress profile defines which domains the server resolves
+func (dns *DNSService) CreateDNSServer(ctx context.Context, labID, ip string, egress model.EgressProfile) error {
 	labDNSErr := appError.ErrLabDNS.WithContext("labID", labID)
-	newDNS := newDNSServer(dns.infrastructure, labID)
+	newDNS := newDNSServer(dns.infrastructure, labID, dns.upstreams)
+	newDNS.setLabEgress(egress)
 
 	if err := newDNS.setConfig(ctx); err != nil {
 		return labDNSErr.WithError(err).WithMessage("Failed to set config").Err()
 	}
 
+	if err := dns.infrastructure.ApplyDNSEgressPolicy(ctx, labID, dns.upstreams); err != nil {
+		return labDNSErr.WithError(err).WithMessage("Failed to apply egress policy").Err()
+	}
+
 	if err := dns.infrastructure.ApplyDeployment(ctx, model.ApplyDeploymentConfig{
 		Name:  dnsName,
 		LabID: labID,
 		Image: image,
 		IP:    ip,
 		Labels: map[string]string{
-			config.PlatformLabel: config.LabDNSServer,
-			config.LabIDLabel:    labID,
+			config.PlatformLabel:      config.LabDNSServer,
+			config.LabIDLabel:         labID,
+			config.EgressProfileLabel: config.DNSServerEgress,
 		},
 		Resources: model.ResourcesConfig{
 			Requests: model.ResourceConfig{
@@ -124,10 +173,10 @@ func (dns *DNSService) CreateDNSServer(ctx context.Context, labID, ip string) er
 func (dns *DNSService) RefreshDNSRecords(ctx context.Context, labID string, records []model.DNSRecordConfig, isAddingRecords bool) error {
 	labDNSErr := appError.ErrLabDNS.WithContext("labID", labID)
 
-	newDNS := newDNSServer(dns.infrastructure, labID)
+	newDNS := newDNSServer(dns.infrastructure, labID, dns.upstreams)
 
-	if err := newDNS.getRecords(ctx); err != nil {
-		return labDNSErr.WithError(err).WithMessage("Failed to get records").Err()
+	if err := newDNS.getConfig(ctx); err != nil {
+		return labDNSErr.WithError(err).WithMessage("Failed to get config").Err()
 	}
 
 	if isAddingRecords {
@@ -147,16 +196,115 @@ func (dns *DNSService) RefreshDNSRecords(ctx context.Context, labID string, reco
 	return nil
 }
 
+// SetLabEgress changes the domains resolved by the lab DNS server to the ones allowed by the lab egress profile
+func (dns *DNSService) SetLabEgress(ctx context.Context, labID string, egress model.EgressProfile) error {
+	return dns.updateEgress(ctx, labID, func(server *DNSServer) {
+		server.setLabEgress(egress)
+	})
+}
+
+// SetChallengeEgressDomains changes the domains allowed for the challenge instances, empty domains remove the challenge ones
+func (dns *DNSService) SetChallengeEgressDomains(ctx context.Context, labID, challengeID string, domains []string) error {
+	return dns.updateEgress(ctx, labID, func(server *DNSServer) {
+		if len(domains) == 0 {
+			delete(server.egress.Challenges, challengeID)
+			return
+		}
+		server.egress.Challenges[challengeID] = domains
+	})
+}
+
+// updateEgress updates the egress state of the lab DNS server and restarts the server if its config changed
+func (dns *DNSService) updateEgress(ctx context.Context, labID string, update func(server *DNSServer)) error {
+	labDNSErr := appError.ErrLabDNS.WithContext("labID", labID)
+
+	newDNS := newDNSServer(dns.infrastructure, labID, dns.upstreams)
+
+	if err := newDNS.getConfig(ctx); err != nil {
+		return labDNSErr.WithError(err).WithMessage("Failed to get config").Err()
+	}
+
+	previous, err := newDNS.generateCoreConfig()
+	if err != nil {
+		return labDNSErr.WithError(err).WithMessage("Failed to generate core config").Err()
+	}
+
+	update(newDNS)
+
+	current, err := newDNS.generateCoreConfig()
+	if err != nil {
+		return labDNSErr.WithError(err).WithMessage("Failed to generate core config").Err()
+	}
+
+	if err = newDNS.setConfig(ctx); err != nil {
+		return labDNSErr.WithError(err).WithMessage("Failed to set config").Err()
+	}
+
+	// the Corefile is read only on the server start
+	if previous != current {
+		if err = dns.infrastructure.ResetDeployment(ctx, dnsName, labID); err != nil {
+			return labDNSErr.WithError(err).WithMessage("Failed to reset deployment").Err()
+		}
+	}
+
+	return nil
+}
+
 // newDNSServer creates a new DNS server instance
 
-func newDNSServer(infrastructure IInfrastructure, labID string) *DNSServer {
+func newDNSServer(infrastructure IInfrastructure, labID string, upstreams []string) *DNSServer {
 	return &DNSServer{
 		labID:          labID,
+		upstreams:      upstreams,
 		infrastructure: infrastructure,
 		records:        make([]model.DNSRecordConfig, 0),
+		egress: egressConfig{
+			Challenges: make(map[string][]string),
+		},
 	}
 }
 
+func (dns *DNSServer) setLabEgress(egress model.EgressProfile) {
+	dns.egress.Filtered = egress.Mode != model.EgressModeDefault && egress.Mode != model.EgressModeInternet
+	dns.egress.Lab = nil
+	if egress.Mode == model.EgressModeDomains {
+		dns.egress.Lab = egress.Domains
+	}
+}
+
+// allowedDomains returns the sorted domains forwarded to the upstreams by the filtered server
+func (dns *DNSServer) allowedDomains() []string {
+	if !dns.egress.Filtered {
+		return nil
+	}
+
+	domains := slices.Clone(dns.egress.Lab)
+	for _, challengeDomains := range dns.egress.Challenges {
+		domains = append(domains, challengeDomains...)
+	}
+
+	slices.Sort(domains)
+	return slices.Compact(domains)
+}
+
+func (dns *DNSServer) generateCoreConfig() (string, error) {
+	var tpl bytes.Buffer
+
+	t, err := template.New("core").Parse(coreFileContent)
+	if err != nil {
+		return "", appError.ErrLabDNS.WithError(err).WithContext("labID", dns.labID).WithMessage("Failed to parse template").Err()
+	}
+	err = t.Execute(&tpl, coreConfig{
+		Filtered:  dns.egress.Filtered,
+		Upstreams: strings.Join(dns.upstreams, " "),
+		Domains:   dns.allowedDomains(),
+	})
+	if err != nil {
+		return "", appError.ErrLabDNS.WithError(err).WithContext("labID", dns.labID).WithMessage("Failed to execute template").Err()
+	}
+	return tpl.String(), nil
+}
+
 func (dns *DNSServer) generateZoneConfig() (string, error) {
 	var tpl bytes.Buffer
 
@@ -183,9 +331,20 @@ func (dns *DNSServer) setConfig(ctx context.Context) error {
 		return appError.ErrLabDNS.WithError(err).WithContext("labID", dns.labID).WithMessage("Failed to generate zone config").Err()
 	}
 
+	coreCfg, err := dns.generateCoreConfig()
+	if err != nil {
+		return appError.ErrLabDNS.WithError(err).WithContext("labID", dns.labID).WithMessage("Failed to generate core config").Err()
+	}
+
+	egress, err := json.Marshal(dns.egress)
+	if err != nil {
+		return appError.ErrLabDNS.WithError(err).WithContext("labID", dns.labID).WithMessage("Failed to marshal egress config").Err()
+	}
+
 	if err = dns.infrastructure.ApplyConfigMap(ctx, dnsConfigName, dns.labID, map[string]string{
-		coreFile:                coreFileContent,
+		coreFile:                coreCfg,
 		zoneFile:                cfg,
+		egressFile:              string(egress),
 		config.RecordsListLabel: tools.RecordsToStr(dns.records),
 	}); err != nil {
 		return appError.ErrLabDNS.WithError(err).WithContext("labID", dns.labID).WithMessage("Failed to apply config map").Err()
@@ -194,12 +353,22 @@ func (dns *DNSServer) setConfig(ctx context.Context) error {
 	return nil
 }
 
-func (dns *DNSServer) getRecords(ctx context.Context) error {
+func (dns *DNSServer) getConfig(ctx context.Context) error {
 	data, err := dns.infrastructure.GetConfigMapData(ctx, dnsConfigName, dns.labID)
 	if err != nil {
 		return appError.ErrLabDNS.WithError(err).WithContext("labID", dns.labID).WithMessage("Failed to get config map data").Err()
 	}
 
+	// the labs created before the egress profiles have no egress config
+	if len(data[egressFile]) != 0 {
+		if err = json.Unmarshal([]byte(data[egressFile]), &dns.egress); err != nil {
+			return appError.ErrLabDNS.WithError(err).WithContext("labID", dns.labID).WithMessage("Failed to unmarshal egress config").Err()
+		}
+		if dns.egress.Challenges == nil {
+			dns.egress.Challenges = make(map[string][]string)
+		}
+	}
+
 	if len(data[config.RecordsListLabel]) == 0 {
 		return nil
 	}
@@ -0,0 +1,338 @@
+package group
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/cybericebox/agent/internal/delivery/repository/postgres"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/internal/tools"
+	"github.com/cybericebox/agent/pkg/appError"
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"math"
+	"slices"
+	"time"
+)
+
+// maxOvercommitRatio caps the overcommit ratios, so the instance requests are not rounded down to nothing
+const maxOvercommitRatio = 100
+
+type (
+	IRepository interface {
+		CreateLabGroup(ctx context.Context, arg postgres.CreateLabGroupParams) (postgres.LabGroup, error)
+		UpdateLabGroup(ctx context.Context, arg postgres.UpdateLabGroupParams) (postgres.LabGroup, error)
+		GetLabGroup(ctx context.Context, id uuid.UUID) (postgres.LabGroup, error)
+		GetLabGroups(ctx context.Context) ([]postgres.LabGroup, error)
+		GetLabGroupsByLaboratories(ctx context.Context, laboratoryIds []uuid.UUID) ([]postgres.LabGroup, error)
+		CountPrivilegedLaboratories(ctx context.Context, laboratoryIds []uuid.UUID) (int64, error)
+		DeleteLabGroup(ctx context.Context, id uuid.UUID) (int64, error)
+		CountLaboratories(ctx context.Context, groupID uuid.UUID) (int64, error)
+	}
+
+	Dependencies struct {
+		Repository IRepository
+		// AdminNamespace is allowed to reach the labs in the platform isolation mode, empty value disables the mode
+		AdminNamespace string
+	}
+
+	GroupService struct {
+		repository     IRepository
+		adminNamespace string
+	}
+)
+
+func NewGroupService(deps Dependencies) *GroupService {
+	return &GroupService{
+		repository:     deps.Repository,
+		adminNamespace: deps.AdminNamespace,
+	}
+}
+
+func (s *GroupService) CreateLabsGroup(ctx context.Context, group model.LabsGroup) (*model.LabsGroup, error) {
+	if group.ID.IsNil() {
+		group.ID = uuid.Must(uuid.NewV7())
+	}
+
+	if err := validateGroup(group, s.adminNamespace); err != nil {
+		return nil, appError.ErrLabGroup.WithError(err).WithMessage("Invalid labs group").WithContext("groupID", group.ID.String()).Err()
+	}
+
+	if group.Labels == nil {
+		group.Labels = make(map[string]string)
+	}
+
+	labels, err := json.Marshal(group.Labels)
+	if err != nil {
+		return nil, appError.ErrLabGroup.WithError(err).WithMessage("Failed to marshal labs group labels").WithContext("groupID", group.ID.String()).Err()
+	}
+
+	created, err := s.repository.CreateLabGroup(ctx, postgres.CreateLabGroupParams{
+		ID:                    group.ID,
+		Name:                  group.Name,
+		Description:           group.Description,
+		StartsAt:              toTimestamptz(group.StartsAt),
+		EndsAt:                toTimestamptz(group.EndsAt),
+		CidrMask:              int32(group.CIDRMask),
+		CpuQuota:              group.Quota.CPU,
+		MemoryQuota:           group.Quota.Memory,
+		PodsQuota:             group.Quota.Pods,
+		EphemeralStorageQuota: group.Quota.EphemeralStorage,
+		MaxLabs:               group.MaxLabs,
+		Labels:                labels,
+		IsolationMode:         int32(group.IsolationMode),
+		CidrPool:              group.CIDRPool,
+		AllowPrivileged:       group.AllowPrivileged,
+		CpuOvercommitRatio:    group.CPUOvercommitRatio,
+		MemoryOvercommitRatio: group.MemoryOvercommitRatio,
+	})
+	if err != nil {
+		return nil, appError.ErrLabGroup.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to create labs group in db").WithContext("groupID", group.ID.String()).Err()
+	}
+
+	return toModelGroup(created)
+}
+
+func (s *GroupService) UpdateLabsGroup(ctx context.Context, group model.LabsGroup) (*model.LabsGroup, error) {
+	if err := validateGroup(group, s.adminNamespace); err != nil {
+		return nil, appError.ErrLabGroup.WithError(err).WithMessage("Invalid labs group").WithContext("groupID", group.ID.String()).Err()
+	}
+
+	if group.Labels == nil {
+		group.Labels = make(map[string]string)
+	}
+
+	labels, err := json.Marshal(group.Labels)
+	if err != nil {
+		return nil, appError.ErrLabGroup.WithError(err).WithMessage("Failed to marshal labs group labels").WithContext("groupID", group.ID.String()).Err()
+	}
+
+	updated, err := s.repository.UpdateLabGroup(ctx, postgres.UpdateLabGroupParams{
+		ID:                    group.ID,
+		Name:                  group.Name,
+		Description:           group.Description,
+		StartsAt:              toTimestamptz(group.StartsAt),
+		EndsAt:                toTimestamptz(group.EndsAt),
+		CidrMask:              int32(group.CIDRMask),
+		CpuQuota:              group.Quota.CPU,
+		MemoryQuota:           group.Quota.Memory,
+		PodsQuota:             group.Quota.Pods,
+		EphemeralStorageQuota: group.Quota.EphemeralStorage,
+		MaxLabs:               group.MaxLabs,
+		Labels:                labels,
+		IsolationMode:         int32(group.IsolationMode),
+		CidrPool:              group.CIDRPool,
+		AllowPrivileged:       group.AllowPrivileged,
+		CpuOvercommitRatio:    group.CPUOvercommitRatio,
+		MemoryOvercommitRatio: group.MemoryOvercommitRatio,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appError.ErrLabGroupNotFound.WithContext("groupID", group.ID.String()).Err()
+		}
+		return nil, appError.ErrLabGroup.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to update labs group in db").WithContext("groupID", group.ID.String()).Err()
+	}
+
+	return toModelGroup(updated)
+}
+
+// GetLabsGroup returns the group, nil is returned if the group does not exist
+func (s *GroupService) GetLabsGroup(ctx context.Context, groupID string) (*model.LabsGroup, error) {
+	parsedGroupID, err := uuid.FromString(groupID)
+	if err != nil {
+		return nil, appError.ErrLabGroup.WithError(err).WithMessage("Failed to parse labs group id").WithContext("groupID", groupID).Err()
+	}
+
+	group, err := s.repository.GetLabGroup(ctx, parsedGroupID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, appError.ErrLabGroup.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to get labs group from db").WithContext("groupID", groupID).Err()
+	}
+
+	return toModelGroup(group)
+}
+
+// GetLabsGroups returns the groups with the given ids, all groups are returned if ids are empty
+func (s *GroupService) GetLabsGroups(ctx context.Context, groupIDs []string) ([]*model.LabsGroup, error) {
+	groups, err := s.repository.GetLabGroups(ctx)
+	if err != nil {
+		return nil, appError.ErrLabGroup.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to get labs groups from db").Err()
+	}
+
+	result := make([]*model.LabsGroup, 0, len(groups))
+	for _, group := range groups {
+		if len(groupIDs) != 0 && !slices.Contains(groupIDs, group.ID.String()) {
+			continue
+		}
+		g, err := toModelGroup(group)
+		if err != nil {
+			return nil, appError.ErrLabGroup.WithError(err).WithMessage("Failed to convert labs group").Err()
+		}
+		result = append(result, g)
+	}
+
+	return result, nil
+}
+
+// GetLabsGroupsByLabs returns the groups of the given labs, labs without a stored group are skipped
+func (s *GroupService) GetLabsGroupsByLabs(ctx context.Context, labIDs []string) ([]*model.LabsGroup, error) {
+	parsedLabIDs := make([]uuid.UUID, 0, len(labIDs))
+	for _, labID := range labIDs {
+		parsedLabID, err := uuid.FromString(labID)
+		if err != nil {
+			return nil, appError.ErrLabGroup.WithError(err).WithMessage("Failed to parse lab id").WithContext("labID", labID).Err()
+		}
+		parsedLabIDs = append(parsedLabIDs, parsedLabID)
+	}
+
+	groups, err := s.repository.GetLabGroupsByLaboratories(ctx, parsedLabIDs)
+	if err != nil {
+		return nil, appError.ErrLabGroup.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to get labs groups by labs from db").Err()
+	}
+
+	result := make([]*model.LabsGroup, 0, len(groups))
+	for _, group := range groups {
+		g, err := toModelGroup(group)
+		if err != nil {
+			return nil, appError.ErrLabGroup.WithError(err).WithMessage("Failed to convert labs group").Err()
+		}
+		result = append(result, g)
+	}
+
+	return result, nil
+}
+
+// CheckLabsPrivileged rejects the labs whose groups do not allow the privileged instances, the labs without a stored group are rejected too
+func (s *GroupService) CheckLabsPrivileged(ctx context.Context, labIDs []string) error {
+	parsedLabIDs := make([]uuid.UUID, 0, len(labIDs))
+	for _, labID := range labIDs {
+		parsedLabID, err := uuid.FromString(labID)
+		if err != nil {
+			return appError.ErrLabGroup.WithError(err).WithMessage("Failed to parse lab id").WithContext("labID", labID).Err()
+		}
+		parsedLabIDs = append(parsedLabIDs, parsedLabID)
+	}
+
+	count, err := s.repository.CountPrivilegedLaboratories(ctx, parsedLabIDs)
+	if err != nil {
+		return appError.ErrLabGroup.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to count privileged labs in db").Err()
+	}
+
+	if count < int64(len(parsedLabIDs)) {
+		return appError.ErrLabGroupPrivilegedNotAllowed.WithMessageF("%d of %d labs are not in the groups allowing the privileged instances", int64(len(parsedLabIDs))-count, len(parsedLabIDs)).Err()
+	}
+
+	return nil
+}
+
+func (s *GroupService) CountLabsGroupLabs(ctx context.Context, groupID uuid.UUID) (int64, error) {
+	count, err := s.repository.CountLaboratories(ctx, groupID)
+	if err != nil {
+		return 0, appError.ErrLabGroup.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to count labs group labs in db").WithContext("groupID", groupID.String()).Err()
+	}
+
+	return count, nil
+}
+
+// DeleteLabsGroup deletes the group, groups with labs can not be deleted
+func (s *GroupService) DeleteLabsGroup(ctx context.Context, groupID string) error {
+	parsedGroupID, err := uuid.FromString(groupID)
+	if err != nil {
+		return appError.ErrLabGroup.WithError(err).WithMessage("Failed to parse labs group id").WithContext("groupID", groupID).Err()
+	}
+
+	count, err := s.CountLabsGroupLabs(ctx, parsedGroupID)
+	if err != nil {
+		return appError.ErrLabGroup.WithError(err).WithMessage("Failed to count labs group labs").WithContext("groupID", groupID).Err()
+	}
+
+	if count != 0 {
+		return appError.ErrLabGroupHasLabs.WithContext("groupID", groupID).WithContext("labsCount", count).Err()
+	}
+
+	affected, err := s.repository.DeleteLabGroup(ctx, parsedGroupID)
+	if err != nil {
+		return appError.ErrLabGroup.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to delete labs group from db").WithContext("groupID", groupID).Err()
+	}
+
+	if affected == 0 {
+		return appError.ErrLabGroupNotFound.WithContext("groupID", groupID).Err()
+	}
+
+	return nil
+}
+
+func validateGroup(group model.LabsGroup, adminNamespace string) error {
+	if group.Name == "" {
+		return appError.ErrLabGroupNameRequired.Err()
+	}
+
+	if !group.StartsAt.IsZero() && !group.EndsAt.IsZero() && !group.EndsAt.After(group.StartsAt) {
+		return appError.ErrLabGroupInvalidEventWindow.Err()
+	}
+
+	if group.IsolationMode < model.IsolationModeDefault || group.IsolationMode > model.IsolationModePlatform {
+		return appError.ErrLabGroupInvalidIsolationMode.WithMessageF("Unknown isolation mode %d", group.IsolationMode).Err()
+	}
+
+	if group.IsolationMode == model.IsolationModePlatform && adminNamespace == "" {
+		return appError.ErrLabGroupInvalidIsolationMode.WithMessage("Platform isolation mode requires the admin namespace").Err()
+	}
+
+	if !validOvercommitRatio(group.CPUOvercommitRatio) || !validOvercommitRatio(group.MemoryOvercommitRatio) {
+		return appError.ErrLabGroupInvalidOvercommit.WithMessageF("Overcommit ratios must be 0 or from 1 to %d, got CPU %g and memory %g", maxOvercommitRatio, group.CPUOvercommitRatio, group.MemoryOvercommitRatio).Err()
+	}
+
+	if err := tools.ValidateLabels(group.Labels); err != nil {
+		return appError.ErrLabGroupInvalidLabel.WithError(err).WithMessageF("Labs group label is invalid: %s", err.Error()).Err()
+	}
+
+	return nil
+}
+
+func toTimestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: !t.IsZero()}
+}
+
+func toModelGroup(group postgres.LabGroup) (*model.LabsGroup, error) {
+	labels := make(map[string]string)
+	if err := json.Unmarshal(group.Labels, &labels); err != nil {
+		return nil, appError.ErrLabGroup.WithError(err).WithMessage("Failed to unmarshal labs group labels").WithContext("groupID", group.ID.String()).Err()
+	}
+
+	return &model.LabsGroup{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		StartsAt:    group.StartsAt.Time,
+		EndsAt:      group.EndsAt.Time,
+		CIDRMask:    uint32(group.CidrMask),
+		CIDRPool:    group.CidrPool,
+		Quota: model.ResourceQuotaConfig{
+			CPU:              group.CpuQuota,
+			Memory:           group.MemoryQuota,
+			Pods:             group.PodsQuota,
+			EphemeralStorage: group.EphemeralStorageQuota,
+		},
+		MaxLabs:               group.MaxLabs,
+		Labels:                labels,
+		IsolationMode:         model.IsolationMode(group.IsolationMode),
+		AllowPrivileged:       group.AllowPrivileged,
+		CPUOvercommitRatio:    group.CpuOvercommitRatio,
+		MemoryOvercommitRatio: group.MemoryOvercommitRatio,
+		CreatedAt:             group.CreatedAt,
+	}, nil
+}
+
+// validOvercommitRatio checks the ratio is unset or finite from one to the max ratio,
+// the ratio below one would request more than the limit and the NaN or infinite one would request nothing
+func validOvercommitRatio(ratio float64) bool {
+	if ratio == 0 {
+		return true
+	}
+
+	return !math.IsNaN(ratio) && !math.IsInf(ratio, 0) && ratio >= 1 && ratio <= maxOvercommitRatio
+}
@@ -2,13 +2,41 @@ package lab
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/cybericebox/agent/internal/config"
 	"github.com/cybericebox/agent/internal/delivery/repository/postgres"
 	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/internal/tools"
 	"github.com/cybericebox/agent/pkg/appError"
 	"github.com/cybericebox/lib/pkg/ipam"
 	"github.com/gofrs/uuid"
 	"github.com/hashicorp/go-multierror"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"net/netip"
+	"slices"
+	"strings"
+)
+
+const (
+	// maxSegmentNameLength keeps the segment network and label names within the kubernetes limits
+	maxSegmentNameLength = 24
+
+	// the IPv6 lab subnet is limited, because the released subnet addresses are walked one by one
+	minIPv6CIDRMask = 112
+	maxIPv6CIDRMask = 126
+
+	// the lab services take the last lab addresses before the broadcast one
+	vpnGatewayOffset = 1
+	sshBastionOffset = 2
+	attackBoxOffset  = 3
+
+	// maxReservedIPsCIDRMask keeps the reserved addresses apart from the DNS server and leaves the addresses for the instances
+	maxReservedIPsCIDRMask = 29
 )
 
 type (
@@ -17,20 +45,42 @@ type (
 		GetNetworkCIDR(ctx context.Context, name string) (string, error)
 		DeleteNetwork(ctx context.Context, name string) error
 
-		ApplyNamespace(ctx context.Context, name string, ipPoolName *string) error
+		ApplySegmentNetwork(ctx context.Context, labID, segment, cidr string, blockSize int) error
+		GetSegmentNetworks(ctx context.Context, labID string) (map[string]string, error)
+		DeleteSegmentNetwork(ctx context.Context, labID, segment string) error
+		ApplySegmentPolicy(ctx context.Context, labID, sharedGroupID string, segment model.Segment) error
+
+		ApplyIPv6Network(ctx context.Context, labID, cidr string, blockSize int) error
+		GetIPv6NetworkCIDR(ctx context.Context, labID string) (string, error)
+		DeleteIPv6Network(ctx context.Context, labID string) error
+
+		ApplyNamespace(ctx context.Context, name string, ipPoolName *string, extraLabels map[string]string) error
 		NamespaceExists(ctx context.Context, name string) (bool, error)
+		GetNamespaceLabels(ctx context.Context, name string) (map[string]string, error)
 		DeleteNamespace(ctx context.Context, name string) error
 
-		ApplyNetworkPolicy(ctx context.Context, labID string) error
+		ApplyNetworkPolicy(ctx context.Context, labID, groupID string, mode model.IsolationMode) error
+		ApplyLabEgressPolicy(ctx context.Context, labID string, profile model.EgressProfile) error
+		RefreshExternalPolicies(ctx context.Context, labID string) error
+
+		ApplyResourceQuota(ctx context.Context, labID string, quota model.ResourceQuotaConfig) error
+		GetResourceQuotasBySelector(ctx context.Context, namespace string, selector ...string) ([]model.ResourceQuotaStatus, error)
 
 		ScaleDeployment(ctx context.Context, name, namespace string, scale int32) error
 		GetDeploymentsInNamespaceBySelector(ctx context.Context, namespace string, selector ...string) ([]model.DeploymentStatus, error)
+
+		GetIngressesBySelector(ctx context.Context, namespace string, selector ...string) ([]model.IngressStatus, error)
 	}
 
 	IRepository interface {
 		GetLaboratories(ctx context.Context, groupID uuid.NullUUID) ([]postgres.Laboratory, error)
+		GetLaboratory(ctx context.Context, id uuid.UUID) (postgres.Laboratory, error)
 		CreateLaboratory(ctx context.Context, laboratory postgres.CreateLaboratoryParams) error
 		DeleteLaboratory(ctx context.Context, id uuid.UUID) (int64, error)
+		UpdateLaboratoryMetadata(ctx context.Context, arg postgres.UpdateLaboratoryMetadataParams) (int64, error)
+		UpdateLaboratoryGroup(ctx context.Context, arg postgres.UpdateLaboratoryGroupParams) (int64, error)
+		UpdateLaboratoryIsolationMode(ctx context.Context, arg postgres.UpdateLaboratoryIsolationModeParams) (int64, error)
+		UpdateLaboratoryEgressProfile(ctx context.Context, arg postgres.UpdateLaboratoryEgressProfileParams) (int64, error)
 	}
 
 	iIPAManager interface {
@@ -42,8 +92,10 @@ type (
 	}
 
 	iDNSService interface {
-		CreateDNSServer(ctx context.Context, labID, ip string) error
+		CreateDNSServer(ctx context.Context, labID, ip string, egress model.EgressProfile) error
 		RefreshDNSRecords(ctx context.Context, labID string, records []model.DNSRecordConfig, isAddRecords bool) error
+		SetLabEgress(ctx context.Context, labID string, egress model.EgressProfile) error
+		SetChallengeEgressDomains(ctx context.Context, labID, challengeID string, domains []string) error
 	}
 
 	iChallengeService interface {
@@ -52,40 +104,82 @@ type (
 		StartChallenge(ctx context.Context, labID, challengeID string) (errs error)
 		StopChallenge(ctx context.Context, labID, challengeID string) (errs error)
 		ResetChallenge(ctx context.Context, labID, challengeID string) (errs error)
+		GetPublishedPorts(ctx context.Context, labID string) ([]model.PublishedPort, error)
+	}
+
+	iVPNService interface {
+		ValidateVPNGateway(gateway model.VPNGateway) error
+		CreateVPNGateway(ctx context.Context, labID, ip string, gateway model.VPNGateway) error
+		AddVPNPeer(ctx context.Context, lab *model.Lab, peerID string) (string, error)
+		RevokeVPNPeer(ctx context.Context, labID, peerID string) error
+	}
+
+	iSSHService interface {
+		ValidateSSHBastion(enabled bool) error
+		CreateSSHBastion(ctx context.Context, labID, ip, dns string) error
+		AddSSHKey(ctx context.Context, labID, ip, playerID, publicKey string) (*model.SSHAccess, error)
+		RemoveSSHKey(ctx context.Context, labID, playerID, publicKey string) error
+	}
+
+	iAttackBoxService interface {
+		ValidateAttackBox(cfg *model.AttackBoxConfig) error
+		CreateAttackBox(ctx context.Context, labID, ip, dns string, cfg model.AttackBoxConfig) error
+		GetAttackBoxAccess(ctx context.Context, labID string) (*model.AttackBoxAccess, error)
+		StartAttackBox(ctx context.Context, labID string) error
+		StopAttackBox(ctx context.Context, labID string) error
 	}
 
 	iLabService interface {
 		iDNSService
 		iChallengeService
+		iVPNService
+		iSSHService
+		iAttackBoxService
+	}
+
+	// iCIDRPools returns the IPAM managers of the pools the lab networks are acquired from
+	iCIDRPools interface {
+		GetCIDRPoolManager(ctx context.Context, name string) (*ipam.IPAManager, error)
+		GetCIDRPoolManagerByCIDR(ctx context.Context, cidr string) (string, *ipam.IPAManager, error)
+		AcquireSpecificChildCIDR(ctx context.Context, poolName, cidr string) (*ipam.IPAManager, error)
+		AcquireSpecificIP(ctx context.Context, cidr, ip string) error
+		GetNetworksUsage(ctx context.Context, cidrs ...netip.Prefix) ([]model.NetworkUsage, error)
 	}
 
 	LabService struct {
 		infrastructure IInfrastructure
-		ipaManager     iIPAManager
+		cidrPools      iCIDRPools
+		ipv6Manager    iIPAManager
 		service        iLabService
 		repository     IRepository
+		adminNamespace string
 	}
 
 	Dependencies struct {
 		Infrastructure IInfrastructure
-		IPAManager     iIPAManager
-		Service        iLabService
-		Repository     IRepository
+		CIDRPools      iCIDRPools
+		// IPv6Manager manages the labs IPv6 subnet, nil value means the IPv6 lab networks are disabled
+		IPv6Manager iIPAManager
+		Service     iLabService
+		Repository  IRepository
+		// AdminNamespace is allowed to reach the labs in the platform isolation mode, empty value disables the mode
+		AdminNamespace string
 	}
 )
 
 func NewLabService(deps Dependencies) *LabService {
-	return &LabService{infrastructure: deps.Infrastructure, ipaManager: deps.IPAManager, service: deps.Service, repository: deps.Repository}
+	return &LabService{infrastructure: deps.Infrastructure, cidrPools: deps.CIDRPools, ipv6Manager: deps.IPv6Manager, service: deps.Service, repository: deps.Repository, adminNamespace: deps.AdminNamespace}
 }
 
-func (s *LabService) RestoreLabIfNeeded(ctx context.Context, lab model.Lab) error {
+// RestoreLabIfNeeded recreates the stored lab missing in the infrastructure, the extra labels are the lab group labels
+func (s *LabService) RestoreLabIfNeeded(ctx context.Context, lab model.Lab, extraLabels map[string]string) error {
 	exists, err := s.infrastructure.NamespaceExists(ctx, lab.ID.String())
 	if err != nil {
 		return appError.ErrPlatform.WithError(err).WithMessage("Failed to check if namespace exists").WithContext("labID", lab.ID.String()).Err()
 	}
 	if !exists {
 		// create the lab in the infrastructure
-		if err = s.createSpecificLab(ctx, uint32(lab.CIDR.Bits()), lab.ID, lab.CIDR); err != nil {
+		if err = s.createSpecificLab(ctx, uint32(lab.CIDR.Bits()), lab, extraLabels); err != nil {
 			return appError.ErrPlatform.WithError(err).WithMessage("Failed to create lab in infrastructure").WithContext("labID", lab.ID.String()).Err()
 		}
 	}
@@ -105,16 +199,12 @@ func (s *LabService) GetStoredLabs(ctx context.Context, labsGroupID string) ([]m
 
 	storedLabs := make([]model.Lab, 0, len(labs))
 	for _, lab := range labs {
-		CIDRManager, err := s.ipaManager.GetChildCIDR(ctx, lab.Cidr.String())
+		storedLab, err := s.toModelLab(ctx, lab)
 		if err != nil {
-			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to get child cidr").WithContext("labID", lab.ID.String()).Err())
+			errs = multierror.Append(errs, err)
 			continue
 		}
-		storedLabs = append(storedLabs, model.Lab{
-			ID:          lab.ID,
-			CIDR:        lab.Cidr,
-			CIDRManager: CIDRManager,
-		})
+		storedLabs = append(storedLabs, storedLab)
 	}
 
 	if errs != nil {
@@ -124,6 +214,94 @@ func (s *LabService) GetStoredLabs(ctx context.Context, labsGroupID string) ([]m
 	return storedLabs, nil
 }
 
+func (s *LabService) getStoredLab(ctx context.Context, labID uuid.UUID) (model.Lab, error) {
+	lab, err := s.repository.GetLaboratory(ctx, labID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Lab{}, appError.ErrLabNotFound.WithContext("labID", labID.String()).Err()
+		}
+		return model.Lab{}, appError.ErrLab.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to get lab from db").WithContext("labID", labID.String()).Err()
+	}
+
+	return s.toModelLab(ctx, lab)
+}
+
+func (s *LabService) toModelLab(ctx context.Context, lab postgres.Laboratory) (model.Lab, error) {
+	ipaManager, err := s.cidrPools.GetCIDRPoolManager(ctx, lab.CidrPool)
+	if err != nil {
+		return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to get lab CIDR pool").WithContext("labID", lab.ID.String()).WithContext("pool", lab.CidrPool).Err()
+	}
+
+	CIDRManager, err := ipaManager.GetChildCIDR(ctx, lab.Cidr.String())
+	if err != nil {
+		return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to get child cidr").WithContext("labID", lab.ID.String()).Err()
+	}
+
+	metadata := make(map[string]string)
+	if err = json.Unmarshal(lab.Metadata, &metadata); err != nil {
+		return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to unmarshal lab metadata").WithContext("labID", lab.ID.String()).Err()
+	}
+
+	var egress model.EgressProfile
+	if err = json.Unmarshal(lab.EgressProfile, &egress); err != nil {
+		return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to unmarshal lab egress profile").WithContext("labID", lab.ID.String()).Err()
+	}
+
+	segments := make([]model.Segment, 0)
+	if err = json.Unmarshal(lab.Segments, &segments); err != nil {
+		return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to unmarshal lab segments").WithContext("labID", lab.ID.String()).Err()
+	}
+
+	for i := range segments {
+		segments[i].CIDRManager, err = ipaManager.GetChildCIDR(ctx, segments[i].CIDR.String())
+		if err != nil {
+			return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to get segment child cidr").WithContext("labID", lab.ID.String()).WithContext("segment", segments[i].Name).Err()
+		}
+	}
+
+	var attackBox *model.AttackBoxConfig
+	if lab.AttackBox != nil {
+		if err = json.Unmarshal(lab.AttackBox, &attackBox); err != nil {
+			return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to unmarshal lab attack box").WithContext("labID", lab.ID.String()).Err()
+		}
+	}
+
+	result := model.Lab{
+		ID:          lab.ID,
+		GroupID:     lab.GroupID,
+		CIDR:        lab.Cidr,
+		CIDRManager: CIDRManager,
+		CIDRPool:    lab.CidrPool,
+		Quota: model.ResourceQuotaConfig{
+			CPU:              lab.CpuQuota,
+			Memory:           lab.MemoryQuota,
+			Pods:             lab.PodsQuota,
+			EphemeralStorage: lab.EphemeralStorageQuota,
+		},
+		Metadata:      metadata,
+		IsolationMode: model.IsolationMode(lab.IsolationMode),
+		Egress:        egress,
+		Segments:      segments,
+		VPNGateway:    model.VPNGateway(lab.VpnGateway),
+		SSHBastion:    lab.SshBastion,
+		AttackBox:     attackBox,
+	}
+
+	if lab.Ipv6Cidr != nil {
+		if s.ipv6Manager == nil {
+			return model.Lab{}, appError.ErrLab.WithMessage("Lab has IPv6 network, but the IPv6 lab networks are disabled").WithContext("labID", lab.ID.String()).Err()
+		}
+
+		result.IPv6CIDR = *lab.Ipv6Cidr
+		result.IPv6CIDRManager, err = s.ipv6Manager.GetChildCIDR(ctx, lab.Ipv6Cidr.String())
+		if err != nil {
+			return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to get IPv6 child cidr").WithContext("labID", lab.ID.String()).Err()
+		}
+	}
+
+	return result, nil
+}
+
 func (s *LabService) GetLab(ctx context.Context, labID string) (*model.Lab, error) {
 	parsedLabID, err := uuid.FromString(labID)
 	if err != nil {
@@ -140,34 +318,201 @@ func (s *LabService) GetLab(ctx context.Context, labID string) (*model.Lab, erro
 		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to parse cidr").WithContext("labID", labID).Err()
 	}
 
+	labels, err := s.infrastructure.GetNamespaceLabels(ctx, labID)
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get lab namespace labels").WithContext("labID", labID).Err()
+	}
+
 	lab := &model.Lab{
-		ID:   parsedLabID,
-		CIDR: parsedCIDR,
+		ID:      parsedLabID,
+		GroupID: uuid.FromStringOrNil(labels[config.LabGroupIDLabel]),
+		CIDR:    parsedCIDR,
+	}
+
+	// the lab pool is the one containing the lab network
+	poolName, ipaManager, err := s.cidrPools.GetCIDRPoolManagerByCIDR(ctx, cidr)
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get lab CIDR pool").WithContext("labID", labID).Err()
 	}
+	lab.CIDRPool = poolName
 
-	lab.CIDRManager, err = s.ipaManager.GetChildCIDR(ctx, cidr)
+	lab.CIDRManager, err = ipaManager.GetChildCIDR(ctx, cidr)
 	if err != nil {
 		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get child cidr").WithContext("labID", labID).Err()
 	}
 
+	// the segments reachability is kept only in the state
+	segmentNetworks, err := s.infrastructure.GetSegmentNetworks(ctx, labID)
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get lab segment networks").WithContext("labID", labID).Err()
+	}
+
+	for name, segmentCIDR := range segmentNetworks {
+		segment := model.Segment{Name: name}
+
+		segment.CIDR, err = netip.ParsePrefix(segmentCIDR)
+		if err != nil {
+			return nil, appError.ErrLab.WithError(err).WithMessage("Failed to parse segment cidr").WithContext("labID", labID).WithContext("segment", name).Err()
+		}
+
+		segment.CIDRManager, err = ipaManager.GetChildCIDR(ctx, segmentCIDR)
+		if err != nil {
+			return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get segment child cidr").WithContext("labID", labID).WithContext("segment", name).Err()
+		}
+
+		lab.Segments = append(lab.Segments, segment)
+	}
+
+	slices.SortFunc(lab.Segments, func(a, b model.Segment) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	ipv6CIDR, err := s.infrastructure.GetIPv6NetworkCIDR(ctx, labID)
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get lab IPv6 cidr").WithContext("labID", labID).Err()
+	}
+
+	if ipv6CIDR != "" && s.ipv6Manager != nil {
+		lab.IPv6CIDR, err = netip.ParsePrefix(ipv6CIDR)
+		if err != nil {
+			return nil, appError.ErrLab.WithError(err).WithMessage("Failed to parse IPv6 cidr").WithContext("labID", labID).Err()
+		}
+
+		lab.IPv6CIDRManager, err = s.ipv6Manager.GetChildCIDR(ctx, ipv6CIDR)
+		if err != nil {
+			return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get IPv6 child cidr").WithContext("labID", labID).Err()
+		}
+	}
+
+	ingresses, err := s.infrastructure.GetIngressesBySelector(ctx, labID, fmt.Sprintf("%s=%s", config.PlatformLabel, config.Challenge))
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get lab ingresses").WithContext("labID", labID).Err()
+	}
+
+	for _, ingress := range ingresses {
+		for _, url := range ingress.URLs {
+			lab.PublicURLs = append(lab.PublicURLs, model.PublicURL{
+				ChallengeID: ingress.Labels[config.ChallengeIDLabel],
+				InstanceID:  ingress.Labels[config.InstanceIDLabel],
+				URL:         url,
+			})
+		}
+	}
+
+	if lab.PublishedPorts, err = s.service.GetPublishedPorts(ctx, labID); err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get lab published ports").WithContext("labID", labID).Err()
+	}
+
 	return lab, nil
 }
 
-func (s *LabService) CreateLab(ctx context.Context, subnetMask uint32, labsGroupID string) (*model.Lab, error) {
+func (s *LabService) CreateLab(ctx context.Context, labsGroupID string, cfg model.LabConfig) (*model.Lab, error) {
 	var err error
 
+	subnetMask := cfg.CIDRMask
+
 	lab := &model.Lab{
-		ID: uuid.Must(uuid.NewV7()),
+		ID:            uuid.Must(uuid.NewV7()),
+		GroupID:       uuid.FromStringOrNil(labsGroupID),
+		Quota:         cfg.Quota,
+		Metadata:      cfg.Metadata,
+		IsolationMode: cfg.IsolationMode,
+		Egress:        cfg.Egress,
+		CIDRPool:      cfg.CIDRPool,
+		VPNGateway:    cfg.VPNGateway,
+		SSHBastion:    cfg.SSHBastion,
+		AttackBox:     cfg.AttackBox,
+	}
+
+	if lab.IsolationMode == model.IsolationModeDefault {
+		lab.IsolationMode = model.IsolationModeIsolated
+	}
+
+	if err = validateIsolationMode(lab.IsolationMode, lab.GroupID, s.adminNamespace); err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Invalid lab isolation mode").Err()
 	}
 
-	lab.CIDRManager, err = s.ipaManager.AcquireChildCIDR(ctx, subnetMask)
+	if err = tools.ValidateLabels(lab.Metadata); err != nil {
+		return nil, appError.ErrLabInvalidMetadata.WithError(err).WithMessageF("Lab metadata is invalid: %s", err.Error()).Err()
+	}
+
+	if lab.Metadata == nil {
+		lab.Metadata = make(map[string]string)
+	}
+
+	metadata, err := json.Marshal(lab.Metadata)
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to marshal lab metadata").Err()
+	}
+
+	if err = tools.ValidateEgressProfile(lab.Egress); err != nil {
+		return nil, appError.ErrLabInvalidEgressProfile.WithError(err).WithMessageF("Lab egress profile is invalid: %s", err.Error()).Err()
+	}
+
+	egress, err := json.Marshal(lab.Egress)
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to marshal lab egress profile").Err()
+	}
+
+	if err = validateSegments(cfg.Segments); err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Invalid lab segments").Err()
+	}
+
+	if err = s.validateIPv6CIDRMask(cfg.IPv6CIDRMask); err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Invalid lab IPv6 network").Err()
+	}
+
+	if err = s.service.ValidateVPNGateway(lab.VPNGateway); err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Invalid lab VPN gateway").Err()
+	}
+
+	if err = s.service.ValidateSSHBastion(lab.SSHBastion); err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Invalid lab SSH bastion").Err()
+	}
+
+	if err = s.service.ValidateAttackBox(lab.AttackBox); err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Invalid lab attack box").Err()
+	}
+
+	reservedMask := subnetMask
+	if prefix, err := netip.ParsePrefix(cfg.CIDR); err == nil {
+		reservedMask = uint32(prefix.Bits())
+	}
+	if err = validateReservedIPsMask(lab, reservedMask); err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Invalid lab network mask").Err()
+	}
+
+	var ipaManager iIPAManager
+	switch {
+	case lab.CIDRPool == "" && cfg.CIDR != "":
+		// the exact network is acquired from the pool containing it
+		lab.CIDRPool, ipaManager, err = s.cidrPools.GetCIDRPoolManagerByCIDR(ctx, cfg.CIDR)
+	case lab.CIDRPool == "":
+		lab.CIDRPool = model.DefaultCIDRPool
+		fallthrough
+	default:
+		ipaManager, err = s.cidrPools.GetCIDRPoolManager(ctx, lab.CIDRPool)
+	}
 	if err != nil {
-		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to acquire child cidr").WithContext("subnetMask", subnetMask).Err()
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get lab CIDR pool").WithContext("pool", lab.CIDRPool).Err()
+	}
+
+	if cfg.CIDR != "" {
+		lab.CIDRManager, err = s.cidrPools.AcquireSpecificChildCIDR(ctx, lab.CIDRPool, cfg.CIDR)
+		if err != nil {
+			return nil, appError.ErrLab.WithError(err).WithMessage("Failed to acquire specific child cidr").WithContext("cidr", cfg.CIDR).Err()
+		}
+		subnetMask = uint32(netip.MustParsePrefix(lab.CIDRManager.GetCIDR()).Bits())
+	} else {
+		lab.CIDRManager, err = ipaManager.AcquireChildCIDR(ctx, subnetMask)
+		if err != nil {
+			return nil, appError.ErrLab.WithError(err).WithMessage("Failed to acquire child cidr").WithContext("subnetMask", subnetMask).Err()
+		}
 	}
 
 	// create network
 	if err = s.infrastructure.ApplyNetwork(ctx, lab.ID.String(), lab.CIDRManager.GetCIDR(), int(subnetMask)); err != nil {
-		if err1 := s.ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+		if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
 			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in apply network").Err()
 		}
 		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to apply network").WithContext("labID", lab.ID.String()).Err()
@@ -175,30 +520,60 @@ func (s *LabService) CreateLab(ctx context.Context, subnetMask uint32, labsGroup
 
 	labPool := lab.ID.String()
 	// create namespace
-	if err = s.infrastructure.ApplyNamespace(ctx, lab.ID.String(), &labPool); err != nil {
+	if err = s.infrastructure.ApplyNamespace(ctx, lab.ID.String(), &labPool, namespaceLabels(lab.GroupID, cfg.Labels, lab.Metadata)); err != nil {
 		if err1 := s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err1 != nil {
 			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete network in apply namespace").WithContext("labID", lab.ID.String()).Err()
 		}
-		if err1 := s.ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+		if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
 			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in apply namespace").WithContext("labID", lab.ID.String()).Err()
 		}
 		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to apply namespace").WithContext("labID", lab.ID.String()).Err()
 	}
 
 	// set network policy
-	if err = s.infrastructure.ApplyNetworkPolicy(ctx, lab.ID.String()); err != nil {
+	if err = s.infrastructure.ApplyNetworkPolicy(ctx, lab.ID.String(), groupLabelValue(lab.GroupID), lab.IsolationMode); err != nil {
 		if err1 := s.infrastructure.DeleteNamespace(ctx, lab.ID.String()); err1 != nil {
 			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete namespace in apply network policy").WithContext("labID", lab.ID.String()).Err()
 		}
 		if err1 := s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err1 != nil {
 			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete network in apply network policy").WithContext("labID", lab.ID.String()).Err()
 		}
-		if err1 := s.ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+		if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
 			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in apply network policy").WithContext("labID", lab.ID.String()).Err()
 		}
 		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to apply network policy").WithContext("labID", lab.ID.String()).Err()
 	}
 
+	// set egress policy
+	if err = s.infrastructure.ApplyLabEgressPolicy(ctx, lab.ID.String(), lab.Egress); err != nil {
+		if err1 := s.infrastructure.DeleteNamespace(ctx, lab.ID.String()); err1 != nil {
+			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete namespace in apply egress policy").WithContext("labID", lab.ID.String()).Err()
+		}
+		if err1 := s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err1 != nil {
+			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete network in apply egress policy").WithContext("labID", lab.ID.String()).Err()
+		}
+		if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in apply egress policy").WithContext("labID", lab.ID.String()).Err()
+		}
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to apply egress policy").WithContext("labID", lab.ID.String()).Err()
+	}
+
+	// set resource quota
+	if lab.Quota != (model.ResourceQuotaConfig{}) {
+		if err = s.infrastructure.ApplyResourceQuota(ctx, lab.ID.String(), lab.Quota); err != nil {
+			if err1 := s.infrastructure.DeleteNamespace(ctx, lab.ID.String()); err1 != nil {
+				return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete namespace in apply resource quota").WithContext("labID", lab.ID.String()).Err()
+			}
+			if err1 := s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err1 != nil {
+				return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete network in apply resource quota").WithContext("labID", lab.ID.String()).Err()
+			}
+			if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+				return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in apply resource quota").WithContext("labID", lab.ID.String()).Err()
+			}
+			return nil, appError.ErrLab.WithError(err).WithMessage("Failed to apply resource quota").WithContext("labID", lab.ID.String()).Err()
+		}
+	}
+
 	singleIP, err := lab.CIDRManager.AcquireSingleIP(ctx)
 	if err != nil {
 		if err1 := s.infrastructure.DeleteNamespace(ctx, lab.ID.String()); err1 != nil {
@@ -207,25 +582,76 @@ func (s *LabService) CreateLab(ctx context.Context, subnetMask uint32, labsGroup
 		if err1 := s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err1 != nil {
 			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete network in acquire single ip").WithContext("labID", lab.ID.String()).Err()
 		}
-		if err1 := s.ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+		if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
 			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in acquire single ip").WithContext("labID", lab.ID.String()).Err()
 		}
 		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to acquire single ip").WithContext("labID", lab.ID.String()).Err()
 	}
 
-	if err = s.service.CreateDNSServer(ctx, lab.ID.String(), singleIP); err != nil {
+	if err = s.service.CreateDNSServer(ctx, lab.ID.String(), singleIP, lab.Egress); err != nil {
 		if err1 := s.infrastructure.DeleteNamespace(ctx, lab.ID.String()); err1 != nil {
 			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete namespace in acquire single ip").WithContext("labID", lab.ID.String()).Err()
 		}
 		if err1 := s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err1 != nil {
 			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete network in acquire single ip").WithContext("labID", lab.ID.String()).Err()
 		}
-		if err1 := s.ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+		if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
 			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in acquire single ip").WithContext("labID", lab.ID.String()).Err()
 		}
 		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to create dns server").WithContext("labID", lab.ID.String()).Err()
 	}
 
+	if err = s.createSegments(ctx, ipaManager, lab, cfg.Segments, subnetMask); err != nil {
+		if err1 := s.DeleteLab(ctx, lab.ID.String()); err1 != nil {
+			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete lab in create segments").WithContext("labID", lab.ID.String()).Err()
+		}
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to create lab segments").WithContext("labID", lab.ID.String()).Err()
+	}
+
+	if cfg.IPv6CIDRMask != 0 {
+		if err = s.createIPv6Network(ctx, lab, cfg.IPv6CIDRMask); err != nil {
+			if err1 := s.DeleteLab(ctx, lab.ID.String()); err1 != nil {
+				return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete lab in create IPv6 network").WithContext("labID", lab.ID.String()).Err()
+			}
+			return nil, appError.ErrLab.WithError(err).WithMessage("Failed to create lab IPv6 network").WithContext("labID", lab.ID.String()).Err()
+		}
+	}
+
+	if lab.VPNGateway != model.VPNGatewayDisabled {
+		if err = s.createVPNGateway(ctx, lab, false); err != nil {
+			if err1 := s.DeleteLab(ctx, lab.ID.String()); err1 != nil {
+				return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete lab in create VPN gateway").WithContext("labID", lab.ID.String()).Err()
+			}
+			return nil, appError.ErrLab.WithError(err).WithMessage("Failed to create lab VPN gateway").WithContext("labID", lab.ID.String()).Err()
+		}
+	}
+
+	if lab.SSHBastion {
+		if err = s.createSSHBastion(ctx, lab, false); err != nil {
+			if err1 := s.DeleteLab(ctx, lab.ID.String()); err1 != nil {
+				return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete lab in create SSH bastion").WithContext("labID", lab.ID.String()).Err()
+			}
+			return nil, appError.ErrLab.WithError(err).WithMessage("Failed to create lab SSH bastion").WithContext("labID", lab.ID.String()).Err()
+		}
+	}
+
+	if lab.AttackBox != nil {
+		if err = s.createAttackBox(ctx, lab, false); err != nil {
+			if err1 := s.DeleteLab(ctx, lab.ID.String()); err1 != nil {
+				return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete lab in create attack box").WithContext("labID", lab.ID.String()).Err()
+			}
+			return nil, appError.ErrLab.WithError(err).WithMessage("Failed to create lab attack box").WithContext("labID", lab.ID.String()).Err()
+		}
+	}
+
+	segments, err := json.Marshal(lab.Segments)
+	if err != nil {
+		if err1 := s.DeleteLab(ctx, lab.ID.String()); err1 != nil {
+			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete lab in marshal segments").WithContext("labID", lab.ID.String()).Err()
+		}
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to marshal lab segments").WithContext("labID", lab.ID.String()).Err()
+	}
+
 	// save lab to db
 
 	cidr, err := netip.ParsePrefix(lab.CIDRManager.GetCIDR())
@@ -233,10 +659,39 @@ func (s *LabService) CreateLab(ctx context.Context, subnetMask uint32, labsGroup
 		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to parse cidr").WithContext("labID", lab.ID.String()).Err()
 	}
 
+	var ipv6CIDR *netip.Prefix
+	if lab.IPv6CIDR.IsValid() {
+		ipv6CIDR = &lab.IPv6CIDR
+	}
+
+	var attackBox []byte
+	if lab.AttackBox != nil {
+		attackBox, err = json.Marshal(lab.AttackBox)
+		if err != nil {
+			if err1 := s.DeleteLab(ctx, lab.ID.String()); err1 != nil {
+				return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete lab in marshal attack box").WithContext("labID", lab.ID.String()).Err()
+			}
+			return nil, appError.ErrLab.WithError(err).WithMessage("Failed to marshal attack box").WithContext("labID", lab.ID.String()).Err()
+		}
+	}
+
 	if err = s.repository.CreateLaboratory(ctx, postgres.CreateLaboratoryParams{
-		ID:      lab.ID,
-		Cidr:    cidr,
-		GroupID: uuid.FromStringOrNil(labsGroupID),
+		ID:                    lab.ID,
+		Cidr:                  cidr,
+		GroupID:               lab.GroupID,
+		CpuQuota:              lab.Quota.CPU,
+		MemoryQuota:           lab.Quota.Memory,
+		PodsQuota:             lab.Quota.Pods,
+		EphemeralStorageQuota: lab.Quota.EphemeralStorage,
+		Metadata:              metadata,
+		IsolationMode:         int32(lab.IsolationMode),
+		EgressProfile:         egress,
+		Segments:              segments,
+		Ipv6Cidr:              ipv6CIDR,
+		CidrPool:              lab.CIDRPool,
+		VpnGateway:            int32(lab.VPNGateway),
+		SshBastion:            lab.SSHBastion,
+		AttackBox:             attackBox,
 	}); err != nil {
 		if err1 := s.DeleteLab(ctx, lab.ID.String()); err1 != nil {
 			return nil, appError.ErrLab.WithError(err1).WithMessage("Failed to delete lab in create lab").WithContext("labID", lab.ID.String()).Err()
@@ -247,21 +702,24 @@ func (s *LabService) CreateLab(ctx context.Context, subnetMask uint32, labsGroup
 	return lab, nil
 }
 
-func (s *LabService) createSpecificLab(ctx context.Context, subnetMask uint32, labID uuid.UUID, cidr netip.Prefix) error {
+func (s *LabService) createSpecificLab(ctx context.Context, subnetMask uint32, stored model.Lab, extraLabels map[string]string) error {
 	var err error
 
-	lab := &model.Lab{
-		ID: labID,
+	lab := &stored
+
+	ipaManager, err := s.cidrPools.GetCIDRPoolManager(ctx, lab.CIDRPool)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab CIDR pool").WithContext("labID", lab.ID.String()).WithContext("pool", lab.CIDRPool).Err()
 	}
 
-	lab.CIDRManager, err = s.ipaManager.GetChildCIDR(ctx, cidr.String())
+	lab.CIDRManager, err = ipaManager.GetChildCIDR(ctx, lab.CIDR.String())
 	if err != nil {
-		return appError.ErrLab.WithError(err).WithMessage("Failed to get child cidr").WithContext("labID", labID.String()).Err()
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get child cidr").WithContext("labID", lab.ID.String()).Err()
 	}
 
 	// create network
 	if err = s.infrastructure.ApplyNetwork(ctx, lab.ID.String(), lab.CIDRManager.GetCIDR(), int(subnetMask)); err != nil {
-		if err1 := s.ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+		if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
 			return appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in apply network").WithContext("labID", lab.ID.String()).Err()
 		}
 		return appError.ErrLab.WithError(err).WithMessage("Failed to apply network").WithContext("labID", lab.ID.String()).Err()
@@ -269,30 +727,60 @@ func (s *LabService) createSpecificLab(ctx context.Context, subnetMask uint32, l
 
 	labPool := lab.ID.String()
 	// create namespace
-	if err = s.infrastructure.ApplyNamespace(ctx, lab.ID.String(), &labPool); err != nil {
+	if err = s.infrastructure.ApplyNamespace(ctx, lab.ID.String(), &labPool, namespaceLabels(lab.GroupID, extraLabels, lab.Metadata)); err != nil {
 		if err1 := s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err1 != nil {
 			return appError.ErrLab.WithError(err1).WithMessage("Failed to delete network in apply namespace").WithContext("labID", lab.ID.String()).Err()
 		}
-		if err1 := s.ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+		if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
 			return appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in apply namespace").WithContext("labID", lab.ID.String()).Err()
 		}
 		return appError.ErrLab.WithError(err).WithMessage("Failed to apply namespace").WithContext("labID", lab.ID.String()).Err()
 	}
 
 	// set network policy
-	if err = s.infrastructure.ApplyNetworkPolicy(ctx, lab.ID.String()); err != nil {
+	if err = s.infrastructure.ApplyNetworkPolicy(ctx, lab.ID.String(), groupLabelValue(lab.GroupID), lab.IsolationMode); err != nil {
 		if err1 := s.infrastructure.DeleteNamespace(ctx, lab.ID.String()); err1 != nil {
 			return appError.ErrLab.WithError(err1).WithMessage("Failed to delete namespace in apply network policy").WithContext("labID", lab.ID.String()).Err()
 		}
 		if err1 := s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err1 != nil {
 			return appError.ErrLab.WithError(err1).WithMessage("Failed to delete network in apply network policy").WithContext("labID", lab.ID.String()).Err()
 		}
-		if err1 := s.ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+		if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
 			return appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in apply network policy").WithContext("labID", lab.ID.String()).Err()
 		}
 		return appError.ErrLab.WithError(err).WithMessage("Failed to apply network policy").WithContext("labID", lab.ID.String()).Err()
 	}
 
+	// set egress policy
+	if err = s.infrastructure.ApplyLabEgressPolicy(ctx, lab.ID.String(), lab.Egress); err != nil {
+		if err1 := s.infrastructure.DeleteNamespace(ctx, lab.ID.String()); err1 != nil {
+			return appError.ErrLab.WithError(err1).WithMessage("Failed to delete namespace in apply egress policy").WithContext("labID", lab.ID.String()).Err()
+		}
+		if err1 := s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err1 != nil {
+			return appError.ErrLab.WithError(err1).WithMessage("Failed to delete network in apply egress policy").WithContext("labID", lab.ID.String()).Err()
+		}
+		if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+			return appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in apply egress policy").WithContext("labID", lab.ID.String()).Err()
+		}
+		return appError.ErrLab.WithError(err).WithMessage("Failed to apply egress policy").WithContext("labID", lab.ID.String()).Err()
+	}
+
+	// set resource quota
+	if lab.Quota != (model.ResourceQuotaConfig{}) {
+		if err = s.infrastructure.ApplyResourceQuota(ctx, lab.ID.String(), lab.Quota); err != nil {
+			if err1 := s.infrastructure.DeleteNamespace(ctx, lab.ID.String()); err1 != nil {
+				return appError.ErrLab.WithError(err1).WithMessage("Failed to delete namespace in apply resource quota").WithContext("labID", lab.ID.String()).Err()
+			}
+			if err1 := s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err1 != nil {
+				return appError.ErrLab.WithError(err1).WithMessage("Failed to delete network in apply resource quota").WithContext("labID", lab.ID.String()).Err()
+			}
+			if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+				return appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in apply resource quota").WithContext("labID", lab.ID.String()).Err()
+			}
+			return appError.ErrLab.WithError(err).WithMessage("Failed to apply resource quota").WithContext("labID", lab.ID.String()).Err()
+		}
+	}
+
 	singleIP, err := lab.CIDRManager.GetFirstIP()
 	if err != nil {
 		if err1 := s.infrastructure.DeleteNamespace(ctx, lab.ID.String()); err1 != nil {
@@ -301,139 +789,973 @@ func (s *LabService) createSpecificLab(ctx context.Context, subnetMask uint32, l
 		if err1 := s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err1 != nil {
 			return appError.ErrLab.WithError(err1).WithMessage("Failed to delete network in acquire single ip").WithContext("labID", lab.ID.String()).Err()
 		}
-		if err1 := s.ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+		if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
 			return appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in acquire single ip").WithContext("labID", lab.ID.String()).Err()
 		}
 		return appError.ErrLab.WithError(err).WithMessage("Failed to acquire single ip").WithContext("labID", lab.ID.String()).Err()
 	}
 
-	if err = s.service.CreateDNSServer(ctx, lab.ID.String(), singleIP); err != nil {
+	if err = s.service.CreateDNSServer(ctx, lab.ID.String(), singleIP, lab.Egress); err != nil {
 		if err1 := s.infrastructure.DeleteNamespace(ctx, lab.ID.String()); err1 != nil {
 			return appError.ErrLab.WithError(err1).WithMessage("Failed to delete namespace in acquire single ip").WithContext("labID", lab.ID.String()).Err()
 		}
 		if err1 := s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err1 != nil {
 			return appError.ErrLab.WithError(err1).WithMessage("Failed to delete network in acquire single ip").WithContext("labID", lab.ID.String()).Err()
 		}
-		if err1 := s.ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
+		if err1 := ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err1 != nil {
 			return appError.ErrLab.WithError(err1).WithMessage("Failed to release child cidr in acquire single ip").WithContext("labID", lab.ID.String()).Err()
 		}
 		return appError.ErrLab.WithError(err).WithMessage("Failed to create dns server").WithContext("labID", lab.ID.String()).Err()
 	}
 
-	return nil
-}
-
-func (s *LabService) DeleteLab(ctx context.Context, labID string) error {
-	lab, err := s.GetLab(ctx, labID)
-	if err != nil {
-		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+	if err = s.restoreSegments(ctx, lab); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to restore lab segments").WithContext("labID", lab.ID.String()).Err()
 	}
 
-	if err = s.infrastructure.DeleteNamespace(ctx, lab.ID.String()); err != nil {
-		return appError.ErrLab.WithError(err).WithMessage("Failed to delete namespace").WithContext("labID", labID).Err()
+	if lab.IPv6CIDR.IsValid() {
+		if err = s.infrastructure.ApplyIPv6Network(ctx, lab.ID.String(), lab.IPv6CIDR.String(), lab.IPv6CIDR.Bits()); err != nil {
+			return appError.ErrLab.WithError(err).WithMessage("Failed to apply IPv6 network").WithContext("labID", lab.ID.String()).Err()
+		}
 	}
 
-	if err = s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err != nil {
-		return appError.ErrLab.WithError(err).WithMessage("Failed to delete network").WithContext("labID", labID).Err()
+	if lab.VPNGateway != model.VPNGatewayDisabled {
+		if err = s.createVPNGateway(ctx, lab, true); err != nil {
+			return appError.ErrLab.WithError(err).WithMessage("Failed to create VPN gateway").WithContext("labID", lab.ID.String()).Err()
+		}
 	}
 
-	if err = s.ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err != nil {
-		return appError.ErrLab.WithError(err).WithMessage("Failed to release child cidr").WithContext("labID", labID).Err()
+	if lab.SSHBastion {
+		if err = s.createSSHBastion(ctx, lab, true); err != nil {
+			return appError.ErrLab.WithError(err).WithMessage("Failed to create SSH bastion").WithContext("labID", lab.ID.String()).Err()
+		}
 	}
 
-	// delete lab from db
-	if _, err = s.repository.DeleteLaboratory(ctx, lab.ID); err != nil {
-		return appError.ErrLab.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to delete lab from db").WithContext("labID", labID).Err()
+	if lab.AttackBox != nil {
+		if err = s.createAttackBox(ctx, lab, true); err != nil {
+			return appError.ErrLab.WithError(err).WithMessage("Failed to create attack box").WithContext("labID", lab.ID.String()).Err()
+		}
 	}
 
 	return nil
 }
 
-func (s *LabService) StartLab(ctx context.Context, labID string) error {
-	// get all deployments in the lab
-	deployments, err := s.infrastructure.GetDeploymentsInNamespaceBySelector(ctx, labID)
-	if err != nil {
-		return appError.ErrLab.WithError(err).WithMessage("Failed to get deployments in namespace by selector").WithContext("labID", labID).Err()
-	}
+// createSegments acquires the segments child CIDRs and applies their networks and policies, the created segments are added to the lab
+func (s *LabService) createSegments(ctx context.Context, ipaManager iIPAManager, lab *model.Lab, cfgs []model.SegmentConfig, labSubnetMask uint32) error {
+	for _, cfg := range cfgs {
+		subnetMask := cfg.CIDRMask
+		if subnetMask == 0 {
+			subnetMask = labSubnetMask
+		}
 
-	var errs error
+		CIDRManager, err := ipaManager.AcquireChildCIDR(ctx, subnetMask)
+		if err != nil {
+			return appError.ErrLab.WithError(err).WithMessage("Failed to acquire segment child cidr").WithContext("segment", cfg.Name).WithContext("subnetMask", subnetMask).Err()
+		}
 
-	// scale all deployments to 1
-	for _, deployment := range deployments {
-		if err = s.infrastructure.ScaleDeployment(ctx, deployment.Name, labID, 1); err != nil {
-			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to scale deployment").WithContext("labID", labID).WithContext("deploymentName", deployment.Name).Err())
+		if err = s.infrastructure.ApplySegmentNetwork(ctx, lab.ID.String(), cfg.Name, CIDRManager.GetCIDR(), int(subnetMask)); err != nil {
+			if err1 := ipaManager.ReleaseChildCIDR(ctx, CIDRManager.GetCIDR()); err1 != nil {
+				return appError.ErrLab.WithError(err1).WithMessage("Failed to release segment child cidr in apply segment network").WithContext("segment", cfg.Name).Err()
+			}
+			return appError.ErrLab.WithError(err).WithMessage("Failed to apply segment network").WithContext("segment", cfg.Name).Err()
+		}
+
+		cidr, err := netip.ParsePrefix(CIDRManager.GetCIDR())
+		if err != nil {
+			return appError.ErrLab.WithError(err).WithMessage("Failed to parse segment cidr").WithContext("segment", cfg.Name).Err()
 		}
+
+		lab.Segments = append(lab.Segments, model.Segment{
+			Name:        cfg.Name,
+			CIDR:        cidr,
+			CIDRManager: CIDRManager,
+			AllowFrom:   cfg.AllowFrom,
+		})
 	}
 
-	if errs != nil {
-		return appError.ErrLab.WithError(errs).WithMessage("Failed to start lab").WithContext("labID", labID).Err()
+	return s.applySegmentPolicies(ctx, *lab, lab.IsolationMode)
+}
+
+// applySegmentPolicies applies the policies of the lab segments, the group shared labs reach the segments allowing the default segment
+func (s *LabService) applySegmentPolicies(ctx context.Context, lab model.Lab, mode model.IsolationMode) error {
+	sharedGroupID := ""
+	if mode == model.IsolationModeGroupShared {
+		sharedGroupID = groupLabelValue(lab.GroupID)
+	}
+
+	for _, segment := range lab.Segments {
+		if err := s.infrastructure.ApplySegmentPolicy(ctx, lab.ID.String(), sharedGroupID, segment); err != nil {
+			return appError.ErrLab.WithError(err).WithMessage("Failed to apply segment network policy").WithContext("segment", segment.Name).Err()
+		}
 	}
 
 	return nil
 }
 
-func (s *LabService) StopLab(ctx context.Context, labID string) error {
-	// get all deployments in the lab
-	deployments, err := s.infrastructure.GetDeploymentsInNamespaceBySelector(ctx, labID)
+// createIPv6Network acquires the IPv6 child CIDR and applies the lab IPv6 network, the created network is added to the lab
+func (s *LabService) createIPv6Network(ctx context.Context, lab *model.Lab, subnetMask uint32) error {
+	CIDRManager, err := s.ipv6Manager.AcquireChildCIDR(ctx, subnetMask)
 	if err != nil {
-		return appError.ErrLab.WithError(err).WithMessage("Failed to get deployments in namespace by selector").WithContext("labID", labID).Err()
+		return appError.ErrLab.WithError(err).WithMessage("Failed to acquire IPv6 child cidr").WithContext("subnetMask", subnetMask).Err()
 	}
 
-	var errs error
-
-	// scale all deployments to 0
-	for _, deployment := range deployments {
-		if err = s.infrastructure.ScaleDeployment(ctx, deployment.Name, labID, 0); err != nil {
-			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to scale deployment").WithContext("labID", labID).WithContext("deploymentName", deployment.Name).Err())
+	if err = s.infrastructure.ApplyIPv6Network(ctx, lab.ID.String(), CIDRManager.GetCIDR(), int(subnetMask)); err != nil {
+		if err1 := s.ipv6Manager.ReleaseChildCIDR(ctx, CIDRManager.GetCIDR()); err1 != nil {
+			return appError.ErrLab.WithError(err1).WithMessage("Failed to release IPv6 child cidr in apply IPv6 network").Err()
 		}
+		return appError.ErrLab.WithError(err).WithMessage("Failed to apply IPv6 network").Err()
 	}
 
-	if errs != nil {
-		return appError.ErrLab.WithError(errs).WithMessage("Failed to stop lab").WithContext("labID", labID).Err()
+	lab.IPv6CIDR, err = netip.ParsePrefix(CIDRManager.GetCIDR())
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to parse IPv6 cidr").Err()
 	}
+	lab.IPv6CIDRManager = CIDRManager
 
 	return nil
 }
 
-// challenge methods
-
-func (s *LabService) AddLabChallenges(ctx context.Context, labID string, challengeConfigs []model.ChallengeConfig) (errs error) {
-	// get lab by cidr
-	lab, err := s.GetLab(ctx, labID)
+// createVPNGateway reserves the gateway address and creates the lab VPN gateway, the restored lab reserves the same address
+func (s *LabService) createVPNGateway(ctx context.Context, lab *model.Lab, restored bool) error {
+	ip, err := s.acquireReservedIP(ctx, lab, vpnGatewayOffset, restored)
 	if err != nil {
-		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+		return appError.ErrLab.WithError(err).WithMessage("Failed to acquire VPN gateway ip").Err()
 	}
 
-	labRecords := make([]model.DNSRecordConfig, 0)
-	for _, challengeConfig := range challengeConfigs {
-		records, err := s.service.CreateChallenge(ctx, lab, challengeConfig)
-		if err != nil {
-			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to create challenge").WithContext("labID", labID).WithContext("challengeID", challengeConfig.ID).Err())
-			continue
-		}
-
-		labRecords = append(labRecords, records...)
+	if err = s.service.CreateVPNGateway(ctx, lab.ID.String(), ip, lab.VPNGateway); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to create VPN gateway").Err()
 	}
 
-	if err = s.service.RefreshDNSRecords(ctx, lab.ID.String(), labRecords, true); err != nil {
-		errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to refresh DNS records").WithContext("labID", labID).Err())
-	}
-	return errs
+	return nil
 }
 
-func (s *LabService) DeleteLabChallenges(ctx context.Context, labID string, challengeIDs []string) (errs error) {
-	lab, err := s.GetLab(ctx, labID)
+// createSSHBastion reserves the bastion address and creates the lab SSH bastion, the restored lab reserves the same address
+func (s *LabService) createSSHBastion(ctx context.Context, lab *model.Lab, restored bool) error {
+	ip, err := s.acquireReservedIP(ctx, lab, sshBastionOffset, restored)
 	if err != nil {
-		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+		return appError.ErrLab.WithError(err).WithMessage("Failed to acquire SSH bastion ip").Err()
 	}
 
-	labRecords := make([]model.DNSRecordConfig, 0)
-	for _, challengeID := range challengeIDs {
+	dns, err := lab.CIDRManager.GetFirstIP()
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get dns server ip").Err()
+	}
+
+	if err = s.service.CreateSSHBastion(ctx, lab.ID.String(), ip, dns); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to create SSH bastion").Err()
+	}
+
+	return nil
+}
+
+// createAttackBox reserves the attack box address and creates the lab attack box, the restored lab reserves the same address
+func (s *LabService) createAttackBox(ctx context.Context, lab *model.Lab, restored bool) error {
+	ip, err := s.acquireReservedIP(ctx, lab, attackBoxOffset, restored)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to acquire attack box ip").Err()
+	}
+
+	dns, err := lab.CIDRManager.GetFirstIP()
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get dns server ip").Err()
+	}
+
+	if err = s.service.CreateAttackBox(ctx, lab.ID.String(), ip, dns, *lab.AttackBox); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to create attack box").Err()
+	}
+
+	return nil
+}
+
+// acquireReservedIP reserves the lab address of the lab service, the restored lab keeps the address acquired on the lab creation
+func (s *LabService) acquireReservedIP(ctx context.Context, lab *model.Lab, offset uint32, restored bool) (string, error) {
+	ip := reservedIP(lab.CIDR, offset)
+	if err := s.cidrPools.AcquireSpecificIP(ctx, lab.CIDRManager.GetCIDR(), ip); err != nil {
+		if errors.Is(err, appError.ErrCIDRPoolIPTaken.Err()) {
+			if restored {
+				return ip, nil
+			}
+			return "", appError.ErrLabReservedIPTaken.WithMessageF("Lab reserved ip %s is already taken", ip).WithContext("labID", lab.ID.String()).Err()
+		}
+		return "", appError.ErrLab.WithError(err).WithMessage("Failed to acquire reserved ip").WithContext("labID", lab.ID.String()).Err()
+	}
+
+	return ip, nil
+}
+
+// validateReservedIPsMask checks that the lab network with the lab services is large enough for their reserved addresses
+func validateReservedIPsMask(lab *model.Lab, mask uint32) error {
+	if lab.VPNGateway == model.VPNGatewayDisabled && !lab.SSHBastion && lab.AttackBox == nil {
+		return nil
+	}
+
+	if mask > maxReservedIPsCIDRMask {
+		return appError.ErrLabInvalidCIDRMask.WithMessageF("Lab network mask must be at most %d with the VPN gateway, SSH bastion or attack box", maxReservedIPsCIDRMask).Err()
+	}
+
+	return nil
+}
+
+// reservedIP is the lab address the offset before the broadcast one, so the addresses of the lab services are not stored
+func reservedIP(cidr netip.Prefix, offset uint32) string {
+	addr := cidr.Masked().Addr().As4()
+	broadcast := binary.BigEndian.Uint32(addr[:]) | (1<<(32-cidr.Bits()) - 1)
+	binary.BigEndian.PutUint32(addr[:], broadcast-offset)
+	return netip.AddrFrom4(addr).String()
+}
+
+// validateIPv6CIDRMask checks that the IPv6 lab networks are enabled and the mask is in the supported range, zero mask is always valid
+func (s *LabService) validateIPv6CIDRMask(mask uint32) error {
+	if mask == 0 {
+		return nil
+	}
+	if s.ipv6Manager == nil {
+		return appError.ErrLabInvalidIPv6Network.WithMessage("IPv6 lab networks are disabled").Err()
+	}
+	if mask < minIPv6CIDRMask || mask > maxIPv6CIDRMask {
+		return appError.ErrLabInvalidIPv6Network.WithMessageF("IPv6 subnet mask must be between %d and %d", minIPv6CIDRMask, maxIPv6CIDRMask).Err()
+	}
+	return nil
+}
+
+// restoreSegments applies the networks and policies of the stored lab segments
+func (s *LabService) restoreSegments(ctx context.Context, lab *model.Lab) error {
+	for _, segment := range lab.Segments {
+		if err := s.infrastructure.ApplySegmentNetwork(ctx, lab.ID.String(), segment.Name, segment.CIDR.String(), segment.CIDR.Bits()); err != nil {
+			return appError.ErrLab.WithError(err).WithMessage("Failed to apply segment network").WithContext("segment", segment.Name).Err()
+		}
+	}
+
+	return s.applySegmentPolicies(ctx, *lab, lab.IsolationMode)
+}
+
+// validateSegments checks that the segments names are unique and the AllowFrom refers to the lab segments
+func validateSegments(segments []model.SegmentConfig) error {
+	names := []string{model.DefaultSegment}
+	for _, segment := range segments {
+		if errs := validation.IsDNS1123Label(segment.Name); len(errs) != 0 {
+			return appError.ErrLabInvalidSegment.WithMessageF("Segment name %s is invalid: %s", segment.Name, strings.Join(errs, "; ")).Err()
+		}
+		if len(segment.Name) > maxSegmentNameLength {
+			return appError.ErrLabInvalidSegment.WithMessageF("Segment name %s is longer than %d characters", segment.Name, maxSegmentNameLength).Err()
+		}
+		if slices.Contains(names, segment.Name) {
+			return appError.ErrLabInvalidSegment.WithMessageF("Segment name %s is used more than once or reserved", segment.Name).Err()
+		}
+		names = append(names, segment.Name)
+	}
+
+	for _, segment := range segments {
+		for _, from := range segment.AllowFrom {
+			if !slices.Contains(names, from) {
+				return appError.ErrLabInvalidSegment.WithMessageF("Segment %s allows the unknown segment %s", segment.Name, from).Err()
+			}
+		}
+	}
+
+	return nil
+}
+
+// UpdateLabMetadata replaces the lab metadata and reapplies the lab namespace labels
+func (s *LabService) UpdateLabMetadata(ctx context.Context, labID string, metadata, extraLabels map[string]string) error {
+	parsedLabID, err := uuid.FromString(labID)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to parse lab id").WithContext("labID", labID).Err()
+	}
+
+	if err = tools.ValidateLabels(metadata); err != nil {
+		return appError.ErrLabInvalidMetadata.WithError(err).WithMessageF("Lab metadata is invalid: %s", err.Error()).WithContext("labID", labID).Err()
+	}
+
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to marshal lab metadata").WithContext("labID", labID).Err()
+	}
+
+	affected, err := s.repository.UpdateLaboratoryMetadata(ctx, postgres.UpdateLaboratoryMetadataParams{
+		ID:       parsedLabID,
+		Metadata: data,
+	})
+	if err != nil {
+		return appError.ErrLab.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to update lab metadata in db").WithContext("labID", labID).Err()
+	}
+
+	if affected == 0 {
+		return appError.ErrLabNotFound.WithContext("labID", labID).Err()
+	}
+
+	lab, err := s.getStoredLab(ctx, parsedLabID)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get stored lab").WithContext("labID", labID).Err()
+	}
+
+	labPool := labID
+	if err = s.infrastructure.ApplyNamespace(ctx, labID, &labPool, namespaceLabels(lab.GroupID, extraLabels, metadata)); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to apply namespace").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+// MoveLab moves the lab to the group, the quota replaces the lab resource quota if it is not nil.
+// The group labels replace the previous group labels of the lab namespace, a failed move is rolled back to the previous group
+func (s *LabService) MoveLab(ctx context.Context, labID, groupID string, quota *model.ResourceQuotaConfig, groupLabels map[string]string) error {
+	parsedLabID, err := uuid.FromString(labID)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to parse lab id").WithContext("labID", labID).Err()
+	}
+
+	parsedGroupID, err := uuid.FromString(groupID)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to parse labs group id").WithContext("groupID", groupID).Err()
+	}
+
+	previous, err := s.getStoredLab(ctx, parsedLabID)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get stored lab").WithContext("labID", labID).Err()
+	}
+
+	if quota != nil {
+		if err = s.checkLabQuotaUsage(ctx, labID, *quota); err != nil {
+			return appError.ErrLab.WithError(err).WithMessage("Failed to check lab resource quota usage").WithContext("labID", labID).Err()
+		}
+	}
+
+	// the previous group labels are kept from the namespace to restore them if the move fails
+	previousLabels, err := s.infrastructure.GetNamespaceLabels(ctx, labID)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab namespace labels").WithContext("labID", labID).Err()
+	}
+	delete(previousLabels, "kubernetes.io/metadata.name")
+
+	params := postgres.UpdateLaboratoryGroupParams{
+		ID:      parsedLabID,
+		GroupID: parsedGroupID,
+	}
+	if quota != nil {
+		params.CpuQuota = pgtype.Int8{Int64: quota.CPU, Valid: true}
+		params.MemoryQuota = pgtype.Int8{Int64: quota.Memory, Valid: true}
+		params.PodsQuota = pgtype.Int8{Int64: quota.Pods, Valid: true}
+		params.EphemeralStorageQuota = pgtype.Int8{Int64: quota.EphemeralStorage, Valid: true}
+	}
+
+	affected, err := s.repository.UpdateLaboratoryGroup(ctx, params)
+	if err != nil {
+		return appError.ErrLab.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to update lab group in db").WithContext("labID", labID).Err()
+	}
+
+	if affected == 0 {
+		return appError.ErrLabNotFound.WithContext("labID", labID).Err()
+	}
+
+	moved := previous
+	moved.GroupID = parsedGroupID
+	if err = s.applyLabGroup(ctx, moved, quota, groupLabels); err == nil {
+		return nil
+	}
+
+	// the lab is returned to the previous group in the infrastructure and in the db
+	var errs error
+	previousQuota := &previous.Quota
+	if quota == nil {
+		previousQuota = nil
+	}
+	if err1 := s.applyLabGroup(ctx, previous, previousQuota, previousLabels); err1 != nil {
+		errs = multierror.Append(errs, err1)
+	}
+
+	if _, err1 := s.repository.UpdateLaboratoryGroup(ctx, postgres.UpdateLaboratoryGroupParams{
+		ID:                    parsedLabID,
+		GroupID:               previous.GroupID,
+		CpuQuota:              pgtype.Int8{Int64: previous.Quota.CPU, Valid: true},
+		MemoryQuota:           pgtype.Int8{Int64: previous.Quota.Memory, Valid: true},
+		PodsQuota:             pgtype.Int8{Int64: previous.Quota.Pods, Valid: true},
+		EphemeralStorageQuota: pgtype.Int8{Int64: previous.Quota.EphemeralStorage, Valid: true},
+	}); err1 != nil {
+		errs = multierror.Append(errs, appError.ErrPostgres.WithError(err1).Err())
+	}
+
+	if errs != nil {
+		return appError.ErrLab.WithError(multierror.Append(err, errs)).WithMessage("Failed to move lab and to restore the previous group").WithContext("labID", labID).Err()
+	}
+
+	return appError.ErrLab.WithError(err).WithMessage("Failed to move lab").WithContext("labID", labID).Err()
+}
+
+// applyLabGroup applies the lab group to the lab namespace labels, resource quota and network policies
+func (s *LabService) applyLabGroup(ctx context.Context, lab model.Lab, quota *model.ResourceQuotaConfig, groupLabels map[string]string) error {
+	labID := lab.ID.String()
+
+	if quota != nil {
+		if err := s.infrastructure.ApplyResourceQuota(ctx, labID, *quota); err != nil {
+			return appError.ErrLab.WithError(err).WithMessage("Failed to apply resource quota").WithContext("labID", labID).Err()
+		}
+	}
+
+	// the group shared policy selects the labs by the group label of the namespace
+	labPool := labID
+	if err := s.infrastructure.ApplyNamespace(ctx, labID, &labPool, namespaceLabels(lab.GroupID, groupLabels, lab.Metadata)); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to apply namespace").WithContext("labID", labID).Err()
+	}
+
+	if err := s.infrastructure.ApplyNetworkPolicy(ctx, labID, groupLabelValue(lab.GroupID), lab.IsolationMode); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to apply network policy").WithContext("labID", labID).Err()
+	}
+
+	if err := s.applySegmentPolicies(ctx, lab, lab.IsolationMode); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to apply segment network policies").WithContext("labID", labID).Err()
+	}
+
+	// the labs created before the egress profiles got the internet egress from the default policy
+	if err := s.infrastructure.ApplyLabEgressPolicy(ctx, labID, lab.Egress); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to apply egress policy").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+// RefreshLabExternalPolicies excepts the networks of the current CIDR pools from the external peers of the lab network policies
+func (s *LabService) RefreshLabExternalPolicies(ctx context.Context, labID string) error {
+	if err := s.infrastructure.RefreshExternalPolicies(ctx, labID); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to refresh external network policies").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+// SetLabIsolationMode changes the network isolation mode of the lab
+func (s *LabService) SetLabIsolationMode(ctx context.Context, labID string, mode model.IsolationMode) error {
+	parsedLabID, err := uuid.FromString(labID)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to parse lab id").WithContext("labID", labID).Err()
+	}
+
+	lab, err := s.getStoredLab(ctx, parsedLabID)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get stored lab").WithContext("labID", labID).Err()
+	}
+
+	if err = validateIsolationMode(mode, lab.GroupID, s.adminNamespace); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Invalid lab isolation mode").WithContext("labID", labID).Err()
+	}
+
+	if err = s.infrastructure.ApplyNetworkPolicy(ctx, labID, groupLabelValue(lab.GroupID), mode); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to apply network policy").WithContext("labID", labID).Err()
+	}
+
+	if err = s.applySegmentPolicies(ctx, lab, mode); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to apply segment network policies").WithContext("labID", labID).Err()
+	}
+
+	// the labs created before the egress profiles got the internet egress from the default policy
+	if err = s.infrastructure.ApplyLabEgressPolicy(ctx, labID, lab.Egress); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to apply egress policy").WithContext("labID", labID).Err()
+	}
+
+	if _, err = s.repository.UpdateLaboratoryIsolationMode(ctx, postgres.UpdateLaboratoryIsolationModeParams{
+		ID:            parsedLabID,
+		IsolationMode: int32(mode),
+	}); err != nil {
+		return appError.ErrLab.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to update lab isolation mode in db").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+// SetLabEgressProfile changes the egress profile of the lab pods without their own profile and the domains resolved by the lab DNS server
+func (s *LabService) SetLabEgressProfile(ctx context.Context, labID string, profile model.EgressProfile) error {
+	parsedLabID, err := uuid.FromString(labID)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to parse lab id").WithContext("labID", labID).Err()
+	}
+
+	if err = tools.ValidateEgressProfile(profile); err != nil {
+		return appError.ErrLabInvalidEgressProfile.WithError(err).WithMessageF("Lab egress profile is invalid: %s", err.Error()).WithContext("labID", labID).Err()
+	}
+
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to marshal lab egress profile").WithContext("labID", labID).Err()
+	}
+
+	affected, err := s.repository.UpdateLaboratoryEgressProfile(ctx, postgres.UpdateLaboratoryEgressProfileParams{
+		ID:            parsedLabID,
+		EgressProfile: data,
+	})
+	if err != nil {
+		return appError.ErrLab.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to update lab egress profile in db").WithContext("labID", labID).Err()
+	}
+
+	if affected == 0 {
+		return appError.ErrLabNotFound.WithContext("labID", labID).Err()
+	}
+
+	if err = s.infrastructure.ApplyLabEgressPolicy(ctx, labID, profile); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to apply egress policy").WithContext("labID", labID).Err()
+	}
+
+	if err = s.service.SetLabEgress(ctx, labID, profile); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to set lab DNS egress").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+func validateIsolationMode(mode model.IsolationMode, groupID uuid.UUID, adminNamespace string) error {
+	switch mode {
+	case model.IsolationModeIsolated:
+		return nil
+	case model.IsolationModePlatform:
+		if adminNamespace == "" {
+			return appError.ErrLabInvalidIsolationMode.WithMessage("Platform isolation mode requires the admin namespace").Err()
+		}
+		return nil
+	case model.IsolationModeGroupShared:
+		if groupID.IsNil() {
+			return appError.ErrLabInvalidIsolationMode.WithMessage("Group shared isolation mode requires the lab to be in a labs group").Err()
+		}
+		return nil
+	case model.IsolationModeDefault:
+		return appError.ErrLabInvalidIsolationMode.WithMessage("Lab isolation mode is required").Err()
+	default:
+		return appError.ErrLabInvalidIsolationMode.WithMessageF("Unknown isolation mode %d", mode).Err()
+	}
+}
+
+func groupLabelValue(groupID uuid.UUID) string {
+	if groupID.IsNil() {
+		return ""
+	}
+	return groupID.String()
+}
+
+// checkLabQuotaUsage checks that the current lab usage fits into the quota, labs without a quota have no tracked usage
+func (s *LabService) checkLabQuotaUsage(ctx context.Context, labID string, quota model.ResourceQuotaConfig) error {
+	quotas, err := s.infrastructure.GetResourceQuotasBySelector(ctx, labID, fmt.Sprintf("%s=%s", config.PlatformLabel, config.LabQuota))
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab resource quota").WithContext("labID", labID).Err()
+	}
+
+	for _, q := range quotas {
+		if quota.CPU != 0 && q.Used.CPU > quota.CPU {
+			return appError.ErrLabQuotaExceeded.WithMessageF("Lab uses %dm of cpu, the new quota allows %dm", q.Used.CPU, quota.CPU).Err()
+		}
+		if quota.Memory != 0 && q.Used.Memory > quota.Memory {
+			return appError.ErrLabQuotaExceeded.WithMessageF("Lab uses %d bytes of memory, the new quota allows %d bytes", q.Used.Memory, quota.Memory).Err()
+		}
+		if quota.Pods != 0 && q.Used.Pods > quota.Pods {
+			return appError.ErrLabQuotaExceeded.WithMessageF("Lab runs %d pods, the new quota allows %d pods", q.Used.Pods, quota.Pods).Err()
+		}
+		if quota.EphemeralStorage != 0 && q.Used.EphemeralStorage > quota.EphemeralStorage {
+			return appError.ErrLabQuotaExceeded.WithMessageF("Lab uses %d bytes of ephemeral storage, the new quota allows %d bytes", q.Used.EphemeralStorage, quota.EphemeralStorage).Err()
+		}
+	}
+
+	return nil
+}
+
+func (s *LabService) DeleteLab(ctx context.Context, labID string) error {
+	lab, err := s.GetLab(ctx, labID)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+	}
+
+	ipaManager, err := s.cidrPools.GetCIDRPoolManager(ctx, lab.CIDRPool)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab CIDR pool").WithContext("labID", labID).WithContext("pool", lab.CIDRPool).Err()
+	}
+
+	// every release step is run, so the failed one does not leak the networks and addresses released after it
+	var errs error
+
+	if err = s.infrastructure.DeleteNamespace(ctx, lab.ID.String()); err != nil {
+		errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to delete namespace").WithContext("labID", labID).Err())
+	}
+
+	if err = s.infrastructure.DeleteNetwork(ctx, lab.ID.String()); err != nil {
+		errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to delete network").WithContext("labID", labID).Err())
+	}
+
+	if err = ipaManager.ReleaseChildCIDR(ctx, lab.CIDRManager.GetCIDR()); err != nil {
+		errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to release child cidr").WithContext("labID", labID).Err())
+	}
+
+	for _, segment := range lab.Segments {
+		if err = s.infrastructure.DeleteSegmentNetwork(ctx, lab.ID.String(), segment.Name); err != nil {
+			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to delete segment network").WithContext("labID", labID).WithContext("segment", segment.Name).Err())
+		}
+
+		if err = ipaManager.ReleaseChildCIDR(ctx, segment.CIDRManager.GetCIDR()); err != nil {
+			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to release segment child cidr").WithContext("labID", labID).WithContext("segment", segment.Name).Err())
+		}
+	}
+
+	if lab.IPv6CIDRManager != nil {
+		if err = s.infrastructure.DeleteIPv6Network(ctx, lab.ID.String()); err != nil {
+			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to delete IPv6 network").WithContext("labID", labID).Err())
+		}
+
+		if err = s.ipv6Manager.ReleaseChildCIDR(ctx, lab.IPv6CIDRManager.GetCIDR()); err != nil {
+			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to release IPv6 child cidr").WithContext("labID", labID).Err())
+		}
+	}
+
+	// delete lab from db
+	if _, err = s.repository.DeleteLaboratory(ctx, lab.ID); err != nil {
+		errs = multierror.Append(errs, appError.ErrLab.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to delete lab from db").WithContext("labID", labID).Err())
+	}
+
+	if errs != nil {
+		return appError.ErrLab.WithError(errs).WithMessage("Failed to delete lab").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+// CheckLabIPAM cross-checks the addresses held in IPAM against the lab deployments and the DNS server, the leaked addresses are released on request
+func (s *LabService) CheckLabIPAM(ctx context.Context, labID string, release bool) (*model.LabIPAMReport, error) {
+	lab, err := s.GetLab(ctx, labID)
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+	}
+
+	networks := []netip.Prefix{lab.CIDR}
+	for _, segment := range lab.Segments {
+		networks = append(networks, segment.CIDR)
+	}
+	if lab.IPv6CIDRManager != nil {
+		networks = append(networks, lab.IPv6CIDR)
+	}
+
+	usage, err := s.cidrPools.GetNetworksUsage(ctx, networks...)
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get lab networks usage").WithContext("labID", labID).Err()
+	}
+
+	deployments, err := s.infrastructure.GetDeploymentsInNamespaceBySelector(ctx, labID)
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get deployments in namespace by selector").WithContext("labID", labID).Err()
+	}
+
+	dnsIP, err := lab.CIDRManager.GetFirstIP()
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get dns server ip").WithContext("labID", labID).Err()
+	}
+
+	usedIPs := []string{dnsIP}
+	for _, dp := range deployments {
+		for _, ip := range []string{dp.IP, dp.IPv6} {
+			if ip != "" && !slices.Contains(usedIPs, ip) {
+				usedIPs = append(usedIPs, ip)
+			}
+		}
+	}
+
+	report := &model.LabIPAMReport{
+		ID:       lab.ID,
+		Networks: usage,
+	}
+
+	heldIPs := make([]string, 0)
+	for _, network := range usage {
+		for _, ip := range network.UsedIPs {
+			heldIPs = append(heldIPs, ip)
+			if !slices.Contains(usedIPs, ip) {
+				report.LeakedIPs = append(report.LeakedIPs, ip)
+			}
+		}
+	}
+
+	for _, ip := range usedIPs {
+		if !slices.Contains(heldIPs, ip) {
+			report.UnallocatedIPs = append(report.UnallocatedIPs, ip)
+		}
+	}
+
+	if !release || len(report.LeakedIPs) == 0 {
+		return report, nil
+	}
+
+	// the address of the instance being created is not annotated yet, so the caller must not change the lab challenges during the check
+	var errs error
+	for _, ip := range report.LeakedIPs {
+		if err = lab.GetCIDRManagerByIP(ip).ReleaseSingleIP(ctx, ip); err != nil {
+			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to release leaked ip").WithContext("labID", labID).WithContext("ip", ip).Err())
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	report.Released = true
+
+	return report, nil
+}
+
+// AddLabVPNPeer adds the player peer to the lab VPN gateway and returns the client config
+func (s *LabService) AddLabVPNPeer(ctx context.Context, labID, peerID string) (string, error) {
+	lab, err := s.getLabWithVPNGateway(ctx, labID)
+	if err != nil {
+		return "", appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+	}
+
+	clientConfig, err := s.service.AddVPNPeer(ctx, &lab, peerID)
+	if err != nil {
+		return "", appError.ErrLab.WithError(err).WithMessage("Failed to add VPN peer").WithContext("labID", labID).Err()
+	}
+
+	return clientConfig, nil
+}
+
+// RevokeLabVPNPeer removes the player peer from the lab VPN gateway
+func (s *LabService) RevokeLabVPNPeer(ctx context.Context, labID, peerID string) error {
+	if _, err := s.getLabWithVPNGateway(ctx, labID); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+	}
+
+	if err := s.service.RevokeVPNPeer(ctx, labID, peerID); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to revoke VPN peer").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+// AddLabSSHKey authorizes the player public key on the lab SSH bastion and returns the player login
+func (s *LabService) AddLabSSHKey(ctx context.Context, labID, playerID, publicKey string) (*model.SSHAccess, error) {
+	lab, err := s.getLabWithSSHBastion(ctx, labID)
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+	}
+
+	access, err := s.service.AddSSHKey(ctx, labID, reservedIP(lab.CIDR, sshBastionOffset), playerID, publicKey)
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to add SSH key").WithContext("labID", labID).Err()
+	}
+
+	return access, nil
+}
+
+// RemoveLabSSHKey removes the player public key from the lab SSH bastion, the empty key removes all player keys
+func (s *LabService) RemoveLabSSHKey(ctx context.Context, labID, playerID, publicKey string) error {
+	if _, err := s.getLabWithSSHBastion(ctx, labID); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+	}
+
+	if err := s.service.RemoveSSHKey(ctx, labID, playerID, publicKey); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to remove SSH key").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+// GetLabAttackBoxAccess returns the authenticated lab URL of the attack box
+func (s *LabService) GetLabAttackBoxAccess(ctx context.Context, labID string) (*model.AttackBoxAccess, error) {
+	if _, err := s.getLabWithAttackBox(ctx, labID); err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+	}
+
+	access, err := s.service.GetAttackBoxAccess(ctx, labID)
+	if err != nil {
+		return nil, appError.ErrLab.WithError(err).WithMessage("Failed to get attack box access").WithContext("labID", labID).Err()
+	}
+
+	return access, nil
+}
+
+// StartLabAttackBox starts the lab attack box without the lab challenges
+func (s *LabService) StartLabAttackBox(ctx context.Context, labID string) error {
+	if _, err := s.getLabWithAttackBox(ctx, labID); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+	}
+
+	if err := s.service.StartAttackBox(ctx, labID); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to start attack box").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+// StopLabAttackBox stops the lab attack box without the lab challenges
+func (s *LabService) StopLabAttackBox(ctx context.Context, labID string) error {
+	if _, err := s.getLabWithAttackBox(ctx, labID); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+	}
+
+	if err := s.service.StopAttackBox(ctx, labID); err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to stop attack box").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+// getLabWithAttackBox returns the stored lab, the lab must have the attack box
+func (s *LabService) getLabWithAttackBox(ctx context.Context, labID string) (model.Lab, error) {
+	parsedLabID, err := uuid.FromString(labID)
+	if err != nil {
+		return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to parse lab id").WithContext("labID", labID).Err()
+	}
+
+	lab, err := s.getStoredLab(ctx, parsedLabID)
+	if err != nil {
+		return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to get stored lab").WithContext("labID", labID).Err()
+	}
+
+	if lab.AttackBox == nil {
+		return model.Lab{}, appError.ErrLabAttackBoxNotEnabled.WithContext("labID", labID).Err()
+	}
+
+	return lab, nil
+}
+
+// getLabWithSSHBastion returns the stored lab, the lab must have the SSH bastion
+func (s *LabService) getLabWithSSHBastion(ctx context.Context, labID string) (model.Lab, error) {
+	parsedLabID, err := uuid.FromString(labID)
+	if err != nil {
+		return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to parse lab id").WithContext("labID", labID).Err()
+	}
+
+	lab, err := s.getStoredLab(ctx, parsedLabID)
+	if err != nil {
+		return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to get stored lab").WithContext("labID", labID).Err()
+	}
+
+	if !lab.SSHBastion {
+		return model.Lab{}, appError.ErrLabSSHNotEnabled.WithContext("labID", labID).Err()
+	}
+
+	return lab, nil
+}
+
+// getLabWithVPNGateway returns the stored lab, the lab must have the VPN gateway
+func (s *LabService) getLabWithVPNGateway(ctx context.Context, labID string) (model.Lab, error) {
+	parsedLabID, err := uuid.FromString(labID)
+	if err != nil {
+		return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to parse lab id").WithContext("labID", labID).Err()
+	}
+
+	lab, err := s.getStoredLab(ctx, parsedLabID)
+	if err != nil {
+		return model.Lab{}, appError.ErrLab.WithError(err).WithMessage("Failed to get stored lab").WithContext("labID", labID).Err()
+	}
+
+	if lab.VPNGateway == model.VPNGatewayDisabled {
+		return model.Lab{}, appError.ErrLabVPNNotEnabled.WithContext("labID", labID).Err()
+	}
+
+	return lab, nil
+}
+
+// HasLabPrivilegedInstances reports whether the lab runs the instances requiring the privileged permission of the lab group
+func (s *LabService) HasLabPrivilegedInstances(ctx context.Context, labID string) (bool, error) {
+	deployments, err := s.infrastructure.GetDeploymentsInNamespaceBySelector(ctx, labID,
+		fmt.Sprintf("%s=%s", config.PlatformLabel, config.Challenge),
+		fmt.Sprintf("%s=%s", config.PrivilegedLabel, config.PrivilegedTrue),
+	)
+	if err != nil {
+		return false, appError.ErrLab.WithError(err).WithMessage("Failed to get privileged instances").WithContext("labID", labID).Err()
+	}
+
+	return len(deployments) > 0, nil
+}
+
+func (s *LabService) StartLab(ctx context.Context, labID string) error {
+	// get all deployments in the lab, the attack box is started and stopped on its own
+	deployments, err := s.infrastructure.GetDeploymentsInNamespaceBySelector(ctx, labID, fmt.Sprintf("%s!=%s", config.PlatformLabel, config.LabAttackBox))
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get deployments in namespace by selector").WithContext("labID", labID).Err()
+	}
+
+	var errs error
+
+	// scale all deployments to 1
+	for _, deployment := range deployments {
+		if err = s.infrastructure.ScaleDeployment(ctx, deployment.Name, labID, 1); err != nil {
+			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to scale deployment").WithContext("labID", labID).WithContext("deploymentName", deployment.Name).Err())
+		}
+	}
+
+	if errs != nil {
+		return appError.ErrLab.WithError(errs).WithMessage("Failed to start lab").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+func (s *LabService) StopLab(ctx context.Context, labID string) error {
+	// get all deployments in the lab, the attack box is started and stopped on its own
+	deployments, err := s.infrastructure.GetDeploymentsInNamespaceBySelector(ctx, labID, fmt.Sprintf("%s!=%s", config.PlatformLabel, config.LabAttackBox))
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get deployments in namespace by selector").WithContext("labID", labID).Err()
+	}
+
+	var errs error
+
+	// scale all deployments to 0
+	for _, deployment := range deployments {
+		if err = s.infrastructure.ScaleDeployment(ctx, deployment.Name, labID, 0); err != nil {
+			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to scale deployment").WithContext("labID", labID).WithContext("deploymentName", deployment.Name).Err())
+		}
+	}
+
+	if errs != nil {
+		return appError.ErrLab.WithError(errs).WithMessage("Failed to stop lab").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+// challenge methods
+
+func (s *LabService) AddLabChallenges(ctx context.Context, labID string, challengeConfigs []model.ChallengeConfig) (errs error) {
+	// get lab by cidr
+	lab, err := s.GetLab(ctx, labID)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+	}
+
+	labRecords := make([]model.DNSRecordConfig, 0)
+	for _, challengeConfig := range challengeConfigs {
+		records, err := s.service.CreateChallenge(ctx, lab, challengeConfig)
+		if err != nil {
+			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to create challenge").WithContext("labID", labID).WithContext("challengeID", challengeConfig.ID).Err())
+			continue
+		}
+
+		if domains := challengeEgressDomains(challengeConfig); len(domains) > 0 {
+			if err = s.service.SetChallengeEgressDomains(ctx, lab.ID.String(), challengeConfig.ID, domains); err != nil {
+				errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to set challenge DNS egress").WithContext("labID", labID).WithContext("challengeID", challengeConfig.ID).Err())
+			}
+		}
+
+		labRecords = append(labRecords, records...)
+	}
+
+	if err = s.service.RefreshDNSRecords(ctx, lab.ID.String(), labRecords, true); err != nil {
+		errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to refresh DNS records").WithContext("labID", labID).Err())
+	}
+	return errs
+}
+
+func (s *LabService) DeleteLabChallenges(ctx context.Context, labID string, challengeIDs []string) (errs error) {
+	lab, err := s.GetLab(ctx, labID)
+	if err != nil {
+		return appError.ErrLab.WithError(err).WithMessage("Failed to get lab").WithContext("labID", labID).Err()
+	}
+
+	labRecords := make([]model.DNSRecordConfig, 0)
+	for _, challengeID := range challengeIDs {
 		records, err := s.service.DeleteChallenge(ctx, lab, challengeID)
 		if err != nil {
 			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to delete challenge").WithContext("labID", labID).WithContext("challengeID", challengeID).Err())
 		}
 
+		if err = s.service.SetChallengeEgressDomains(ctx, lab.ID.String(), challengeID, nil); err != nil {
+			errs = multierror.Append(errs, appError.ErrLab.WithError(err).WithMessage("Failed to remove challenge DNS egress").WithContext("labID", labID).WithContext("challengeID", challengeID).Err())
+		}
+
 		labRecords = append(labRecords, records...)
 	}
 
@@ -485,3 +1807,30 @@ func (s *LabService) ResetLabChallenges(ctx context.Context, labID string, chall
 
 	return nil
 }
+
+// challengeEgressDomains returns the domains allowed by the challenge instances egress profiles
+func challengeEgressDomains(challengeConfig model.ChallengeConfig) []string {
+	domains := make([]string, 0)
+	for _, inst := range challengeConfig.Instances {
+		if inst.Egress.Mode == model.EgressModeDomains {
+			domains = append(domains, inst.Egress.Domains...)
+		}
+	}
+	return domains
+}
+
+// namespaceLabels merges the lab metadata over the extra labels and adds the lab group label.
+// The policies select the group labs by the namespace label only, so the lab pods do not carry the group
+func namespaceLabels(groupID uuid.UUID, extraLabels, metadata map[string]string) map[string]string {
+	labels := make(map[string]string, len(extraLabels)+len(metadata)+1)
+	for key, value := range extraLabels {
+		labels[key] = value
+	}
+	for key, value := range metadata {
+		labels[key] = value
+	}
+	if !groupID.IsNil() {
+		labels[config.LabGroupIDLabel] = groupID.String()
+	}
+	return labels
+}
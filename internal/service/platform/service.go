@@ -2,6 +2,7 @@ package platform
 
 import (
 	"context"
+	"fmt"
 	"github.com/cybericebox/agent/internal/config"
 	"github.com/cybericebox/agent/internal/delivery/repository/postgres"
 	"github.com/cybericebox/agent/internal/model"
@@ -13,6 +14,8 @@ type (
 	IInfrastructure interface {
 		GetPodsMetrics(ctx context.Context, namespace string, selectors ...string) ([]model.PodMetrics, error)
 		GetDeploymentsInNamespaceBySelector(ctx context.Context, namespace string, selector ...string) ([]model.DeploymentStatus, error)
+		GetResourceQuotasBySelector(ctx context.Context, namespace string, selector ...string) ([]model.ResourceQuotaStatus, error)
+		GetIngressesBySelector(ctx context.Context, namespace string, selector ...string) ([]model.IngressStatus, error)
 	}
 
 	IRepository interface {
@@ -74,6 +77,18 @@ func (s *PlatformService) GetLabsStatus(ctx context.Context) ([]*model.LabStatus
 		}
 	}
 
+	ingresses, err := s.infrastructure.GetIngressesBySelector(ctx, "", fmt.Sprintf("%s=%s", config.PlatformLabel, config.Challenge))
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get all challenge ingresses").Err()
+	}
+
+	// the instance ids are unique only in the lab
+	urlsMap := make(map[string][]string)
+	for _, ingress := range ingresses {
+		key := ingress.Labels[config.LabIDLabel] + "/" + ingress.Labels[config.InstanceIDLabel]
+		urlsMap[key] = append(urlsMap[key], ingress.URLs...)
+	}
+
 	for _, dep := range deps {
 		t := dep.Labels[config.PlatformLabel]
 		if t == config.Challenge {
@@ -83,6 +98,7 @@ func (s *PlatformService) GetLabsStatus(ctx context.Context) ([]*model.LabStatus
 				Status:      dep.Status,
 				Resources:   podsMap[dep.Labels[config.InstanceIDLabel]].Resources,
 				Reason:      dep.Reason,
+				URLs:        urlsMap[dep.Labels[config.LabIDLabel]+"/"+dep.Labels[config.InstanceIDLabel]],
 			})
 		}
 		if t == config.LabDNSServer {
@@ -91,6 +107,20 @@ func (s *PlatformService) GetLabsStatus(ctx context.Context) ([]*model.LabStatus
 		}
 	}
 
+	quotas, err := s.infrastructure.GetResourceQuotasBySelector(ctx, "", fmt.Sprintf("%s=%s", config.PlatformLabel, config.LabQuota))
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get all lab resource quotas").Err()
+	}
+
+	for _, quota := range quotas {
+		if lab, ok := labsMap[quota.Labels[config.LabIDLabel]]; ok {
+			lab.Quota = &model.QuotaStatus{
+				Hard: quota.Hard,
+				Used: quota.Used,
+			}
+		}
+	}
+
 	labsStatus := make([]*model.LabStatus, 0)
 	for _, lab := range labsMap {
 		labsStatus = append(labsStatus, lab)
@@ -0,0 +1,426 @@
+package pool
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"github.com/cybericebox/agent/internal/delivery/repository/postgres"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+	"github.com/cybericebox/lib/pkg/ipam"
+	"github.com/jackc/pgx/v5"
+	goipam "github.com/metal-stack/go-ipam"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"math"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type (
+	IRepository interface {
+		CreateCIDRPool(ctx context.Context, arg postgres.CreateCIDRPoolParams) (postgres.CidrPool, error)
+		GetCIDRPool(ctx context.Context, name string) (postgres.CidrPool, error)
+		GetCIDRPools(ctx context.Context) ([]postgres.CidrPool, error)
+	}
+
+	IInfrastructure interface {
+		SetPodCIDRs(cidrs []string)
+	}
+
+	Dependencies struct {
+		Infrastructure IInfrastructure
+		Repository     IRepository
+		PostgresConfig ipam.PostgresConfig
+		// DefaultCIDR is the labs subnet of the default pool
+		DefaultCIDR string
+	}
+
+	// PoolService keeps the IPAM managers of the CIDR pools, the pools added by the other agent replicas are loaded on demand
+	PoolService struct {
+		infrastructure IInfrastructure
+		repository     IRepository
+		postgresConfig ipam.PostgresConfig
+		defaultPool    model.CIDRPool
+		// ipamer shares the storage with the IPAM managers, it reserves the specific networks and addresses the managers do not support
+		ipamer goipam.Ipamer
+
+		mutex    sync.RWMutex
+		managers map[string]*ipam.IPAManager
+	}
+)
+
+// maxProbedIPs limits the addresses probed in a network, IPAM acquires the addresses from the network start
+const maxProbedIPs = 1 << 16
+
+func NewPoolService(deps Dependencies) (*PoolService, error) {
+	defaultCIDR, err := netip.ParsePrefix(deps.DefaultCIDR)
+	if err != nil {
+		return nil, appError.ErrCIDRPoolInvalidCIDR.WithError(err).WithContext("cidr", deps.DefaultCIDR).Err()
+	}
+
+	defaultManager, err := ipam.NewIPAManager(ipam.Dependencies{
+		PostgresConfig: deps.PostgresConfig,
+		CIDR:           deps.DefaultCIDR,
+	})
+	if err != nil {
+		return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to create default pool IPAManager").Err()
+	}
+
+	storage, err := goipam.NewPostgresStorage(
+		deps.PostgresConfig.Host,
+		deps.PostgresConfig.Port,
+		deps.PostgresConfig.Username,
+		deps.PostgresConfig.Password,
+		deps.PostgresConfig.Database,
+		goipam.SSLMode(deps.PostgresConfig.SSLMode),
+	)
+	if err != nil {
+		return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to create IPAM postgres storage").Err()
+	}
+
+	return &PoolService{
+		infrastructure: deps.Infrastructure,
+		repository:     deps.Repository,
+		postgresConfig: deps.PostgresConfig,
+		defaultPool:    model.CIDRPool{Name: model.DefaultCIDRPool, CIDR: defaultCIDR},
+		ipamer:         goipam.NewWithStorage(storage),
+		managers:       map[string]*ipam.IPAManager{model.DefaultCIDRPool: defaultManager},
+	}, nil
+}
+
+// AddCIDRPool stores the new pool, the pool CIDR must not overlap the existing pools
+func (s *PoolService) AddCIDRPool(ctx context.Context, pool model.CIDRPool) (*model.CIDRPool, error) {
+	if errs := validation.IsDNS1123Label(pool.Name); len(errs) != 0 || pool.Name == model.DefaultCIDRPool {
+		return nil, appError.ErrCIDRPoolInvalidName.WithMessageF("CIDR pool name %s is invalid: %s", pool.Name, strings.Join(errs, "; ")).Err()
+	}
+
+	if !pool.CIDR.IsValid() || !pool.CIDR.Addr().Is4() || pool.CIDR != pool.CIDR.Masked() {
+		return nil, appError.ErrCIDRPoolInvalidCIDR.WithMessageF("CIDR pool CIDR %s must be an IPv4 network address", pool.CIDR).Err()
+	}
+
+	pools, err := s.GetCIDRPools(ctx)
+	if err != nil {
+		return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to get CIDR pools").Err()
+	}
+
+	for _, p := range pools {
+		if p.Name == pool.Name {
+			return nil, appError.ErrCIDRPoolAlreadyExist.WithContext("pool", pool.Name).Err()
+		}
+		if p.CIDR.Overlaps(pool.CIDR) {
+			return nil, appError.ErrCIDRPoolOverlaps.WithMessageF("CIDR pool %s overlaps with pool %s %s", pool.CIDR, p.Name, p.CIDR).Err()
+		}
+	}
+
+	// the IPAManager creates the pool prefix in the IPAM
+	manager, err := ipam.NewIPAManager(ipam.Dependencies{
+		PostgresConfig: s.postgresConfig,
+		CIDR:           pool.CIDR.String(),
+	})
+	if err != nil {
+		return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to create pool IPAManager").WithContext("pool", pool.Name).Err()
+	}
+
+	created, err := s.repository.CreateCIDRPool(ctx, postgres.CreateCIDRPoolParams{
+		Name: pool.Name,
+		Cidr: pool.CIDR,
+	})
+	if err != nil {
+		if _, err1 := s.ipamer.DeletePrefix(ctx, pool.CIDR.String()); err1 != nil {
+			return nil, appError.ErrCIDRPool.WithError(err1).WithMessage("Failed to delete pool prefix in create CIDR pool").WithContext("pool", pool.Name).Err()
+		}
+		return nil, appError.ErrCIDRPool.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to create CIDR pool in db").WithContext("pool", pool.Name).Err()
+	}
+
+	s.mutex.Lock()
+	s.managers[pool.Name] = manager
+	s.mutex.Unlock()
+
+	s.setPodCIDRs(append(pools, *toModelPool(created)))
+
+	return toModelPool(created), nil
+}
+
+// GetCIDRPools returns the default pool and the stored ones,
+// the networks of the pools added by the other agent replicas are excepted from the external peers of the lab network policies
+func (s *PoolService) GetCIDRPools(ctx context.Context) ([]model.CIDRPool, error) {
+	stored, err := s.repository.GetCIDRPools(ctx)
+	if err != nil {
+		return nil, appError.ErrCIDRPool.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to get CIDR pools from db").Err()
+	}
+
+	pools := make([]model.CIDRPool, 0, len(stored)+1)
+	pools = append(pools, s.defaultPool)
+	for _, pool := range stored {
+		pools = append(pools, *toModelPool(pool))
+	}
+
+	s.setPodCIDRs(pools)
+
+	return pools, nil
+}
+
+func (s *PoolService) setPodCIDRs(pools []model.CIDRPool) {
+	cidrs := make([]string, 0, len(pools))
+	for _, pool := range pools {
+		cidrs = append(cidrs, pool.CIDR.String())
+	}
+	s.infrastructure.SetPodCIDRs(cidrs)
+}
+
+// GetCIDRPoolManager returns the IPAM manager of the pool, empty name means the default pool
+func (s *PoolService) GetCIDRPoolManager(ctx context.Context, name string) (*ipam.IPAManager, error) {
+	if name == "" {
+		name = model.DefaultCIDRPool
+	}
+
+	s.mutex.RLock()
+	manager, ok := s.managers[name]
+	s.mutex.RUnlock()
+	if ok {
+		return manager, nil
+	}
+
+	pool, err := s.repository.GetCIDRPool(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appError.ErrCIDRPoolNotFound.WithContext("pool", name).Err()
+		}
+		return nil, appError.ErrCIDRPool.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to get CIDR pool from db").WithContext("pool", name).Err()
+	}
+
+	manager, err = ipam.NewIPAManager(ipam.Dependencies{
+		PostgresConfig: s.postgresConfig,
+		CIDR:           pool.Cidr.String(),
+	})
+	if err != nil {
+		return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to create pool IPAManager").WithContext("pool", name).Err()
+	}
+
+	s.mutex.Lock()
+	s.managers[name] = manager
+	s.mutex.Unlock()
+
+	return manager, nil
+}
+
+// GetCIDRPoolManagerByCIDR returns the name and the IPAM manager of the pool the lab network is acquired from
+func (s *PoolService) GetCIDRPoolManagerByCIDR(ctx context.Context, cidr string) (string, *ipam.IPAManager, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return "", nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to parse cidr").WithContext("cidr", cidr).Err()
+	}
+
+	pools, err := s.GetCIDRPools(ctx)
+	if err != nil {
+		return "", nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to get CIDR pools").Err()
+	}
+
+	for _, pool := range pools {
+		if pool.CIDR.Contains(prefix.Addr()) {
+			manager, err := s.GetCIDRPoolManager(ctx, pool.Name)
+			if err != nil {
+				return "", nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to get CIDR pool manager").WithContext("pool", pool.Name).Err()
+			}
+			return pool.Name, manager, nil
+		}
+	}
+
+	return "", nil, appError.ErrCIDRPoolNotFound.WithMessageF("No CIDR pool contains %s", cidr).Err()
+}
+
+// AcquireSpecificChildCIDR reserves the exact network in the pool and returns its IPAM manager
+func (s *PoolService) AcquireSpecificChildCIDR(ctx context.Context, poolName, cidr string) (*ipam.IPAManager, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil || prefix != prefix.Masked() {
+		return nil, appError.ErrCIDRPoolInvalidCIDR.WithMessageF("CIDR %s must be a network address", cidr).Err()
+	}
+
+	pools, err := s.GetCIDRPools(ctx)
+	if err != nil {
+		return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to get CIDR pools").Err()
+	}
+
+	idx := slices.IndexFunc(pools, func(p model.CIDRPool) bool { return p.Name == poolName })
+	if idx == -1 {
+		return nil, appError.ErrCIDRPoolNotFound.WithContext("pool", poolName).Err()
+	}
+
+	pool := pools[idx]
+	if !pool.CIDR.Contains(prefix.Addr()) || pool.CIDR.Bits() >= prefix.Bits() {
+		return nil, appError.ErrCIDRPoolInvalidCIDR.WithMessageF("CIDR %s is not within pool %s %s", cidr, pool.Name, pool.CIDR).Err()
+	}
+
+	manager, err := s.GetCIDRPoolManager(ctx, pool.Name)
+	if err != nil {
+		return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to get CIDR pool manager").WithContext("pool", pool.Name).Err()
+	}
+
+	if _, err = s.ipamer.AcquireSpecificChildPrefix(ctx, pool.CIDR.String(), prefix.String()); err != nil {
+		return nil, appError.ErrCIDRPoolCIDRTaken.WithError(err).WithMessageF("CIDR %s is not available in pool %s", cidr, pool.Name).Err()
+	}
+
+	return manager.GetChildCIDR(ctx, prefix.String())
+}
+
+// AcquireSpecificIP reserves the exact address in the network acquired from a pool
+func (s *PoolService) AcquireSpecificIP(ctx context.Context, cidr, ip string) error {
+	if _, err := s.ipamer.AcquireSpecificIP(ctx, cidr, ip); err != nil {
+		if errors.Is(err, goipam.ErrAlreadyAllocated) {
+			return appError.ErrCIDRPoolIPTaken.WithContext("ip", ip).Err()
+		}
+		return appError.ErrCIDRPool.WithError(err).WithMessage("Failed to acquire specific ip").WithContext("cidr", cidr).WithContext("ip", ip).Err()
+	}
+
+	return nil
+}
+
+// GetCIDRPoolsUsage returns the child networks acquired from every pool
+func (s *PoolService) GetCIDRPoolsUsage(ctx context.Context) ([]model.CIDRPoolUsage, error) {
+	pools, err := s.GetCIDRPools(ctx)
+	if err != nil {
+		return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to get CIDR pools").Err()
+	}
+
+	cidrs, err := s.ipamer.ReadAllPrefixCidrs(ctx)
+	if err != nil {
+		return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to read IPAM prefixes").Err()
+	}
+
+	prefixes := make([]*goipam.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := s.ipamer.PrefixFrom(ctx, cidr)
+		if err != nil {
+			if errors.Is(err, goipam.ErrNotFound) {
+				continue
+			}
+			return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to read IPAM prefix").WithContext("cidr", cidr).Err()
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	usages := make([]model.CIDRPoolUsage, 0, len(pools))
+	for _, pool := range pools {
+		usage := model.CIDRPoolUsage{Pool: pool}
+
+		var acquired float64
+		for _, prefix := range prefixes {
+			parent, err := netip.ParsePrefix(prefix.ParentCidr)
+			if err != nil || parent.Masked() != pool.CIDR.Masked() {
+				continue
+			}
+
+			child, err := netip.ParsePrefix(prefix.Cidr)
+			if err != nil {
+				continue
+			}
+
+			usage.ChildCIDRs = append(usage.ChildCIDRs, child)
+			acquired += addressesCount(child)
+		}
+
+		slices.SortFunc(usage.ChildCIDRs, func(a, b netip.Prefix) int {
+			return a.Addr().Compare(b.Addr())
+		})
+
+		usage.Utilization = percentage(acquired, addressesCount(pool.CIDR))
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
+// GetNetworksUsage returns the addresses acquired in the networks, the networks are the child networks of the pools or the labs IPv6 subnet.
+// IPAM does not list the acquired addresses, so they are probed from the network start until all the acquired ones are found,
+// the caller must not acquire the addresses of the networks meanwhile
+func (s *PoolService) GetNetworksUsage(ctx context.Context, cidrs ...netip.Prefix) ([]model.NetworkUsage, error) {
+	usages := make([]model.NetworkUsage, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := s.ipamer.PrefixFrom(ctx, cidr.Masked().String())
+		if err != nil {
+			if errors.Is(err, goipam.ErrNotFound) {
+				return nil, appError.ErrCIDRPoolNotFound.WithMessageF("Network %s is not acquired in IPAM", cidr).Err()
+			}
+			return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to read IPAM prefix").WithContext("cidr", cidr.String()).Err()
+		}
+
+		usage := model.NetworkUsage{CIDR: cidr}
+
+		// the network and the IPv4 broadcast addresses are reserved on the prefix creation
+		reserved := []netip.Addr{cidr.Masked().Addr()}
+		if cidr.Addr().Is4() {
+			reserved = append(reserved, broadcastAddr(cidr))
+		}
+
+		acquired := int(prefix.Usage().AcquiredIPs) - len(reserved)
+		if acquired > 0 {
+			usage.UsedIPs, err = s.probeAcquiredIPs(ctx, cidr.Masked(), reserved, acquired)
+			if err != nil {
+				return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to probe acquired addresses").WithContext("cidr", cidr.String()).Err()
+			}
+		}
+
+		usage.Utilization = percentage(float64(len(usage.UsedIPs)), addressesCount(cidr)-float64(len(reserved)))
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
+// probeAcquiredIPs finds the acquired addresses of the network, the free probed address is released right after it is acquired
+func (s *PoolService) probeAcquiredIPs(ctx context.Context, cidr netip.Prefix, reserved []netip.Addr, acquired int) ([]string, error) {
+	used := make([]string, 0, acquired)
+
+	probed := 0
+	for addr := cidr.Addr(); cidr.Contains(addr) && len(used) < acquired; addr = addr.Next() {
+		if slices.Contains(reserved, addr) {
+			continue
+		}
+
+		if probed == maxProbedIPs {
+			return nil, appError.ErrCIDRPool.WithMessageF("Network %s has acquired addresses beyond the first %d ones", cidr, maxProbedIPs).Err()
+		}
+		probed++
+
+		if _, err := s.ipamer.AcquireSpecificIP(ctx, cidr.String(), addr.String()); err != nil {
+			if errors.Is(err, goipam.ErrAlreadyAllocated) {
+				used = append(used, addr.String())
+				continue
+			}
+			return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to probe ip").WithContext("ip", addr.String()).Err()
+		}
+
+		if err := s.ipamer.ReleaseIPFromPrefix(ctx, cidr.String(), addr.String()); err != nil {
+			return nil, appError.ErrCIDRPool.WithError(err).WithMessage("Failed to release probed ip").WithContext("ip", addr.String()).Err()
+		}
+	}
+
+	return used, nil
+}
+
+func addressesCount(prefix netip.Prefix) float64 {
+	return math.Pow(2, float64(prefix.Addr().BitLen()-prefix.Bits()))
+}
+
+func broadcastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr().As4()
+	binary.BigEndian.PutUint32(addr[:], binary.BigEndian.Uint32(addr[:])|(1<<(32-prefix.Bits())-1))
+	return netip.AddrFrom4(addr)
+}
+
+func percentage(used, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return used / total * 100
+}
+
+func toModelPool(pool postgres.CidrPool) *model.CIDRPool {
+	return &model.CIDRPool{
+		Name:      pool.Name,
+		CIDR:      pool.Cidr,
+		CreatedAt: pool.CreatedAt,
+	}
+}
@@ -2,10 +2,16 @@ package service
 
 import (
 	"github.com/cybericebox/agent/internal/config"
+	"github.com/cybericebox/agent/internal/service/attackbox"
+	"github.com/cybericebox/agent/internal/service/bastion"
 	"github.com/cybericebox/agent/internal/service/challenge"
 	"github.com/cybericebox/agent/internal/service/dns"
+	"github.com/cybericebox/agent/internal/service/group"
 	"github.com/cybericebox/agent/internal/service/lab"
 	"github.com/cybericebox/agent/internal/service/platform"
+	"github.com/cybericebox/agent/internal/service/pool"
+	"github.com/cybericebox/agent/internal/service/template"
+	"github.com/cybericebox/agent/internal/service/vpn"
 	"github.com/cybericebox/lib/pkg/ipam"
 	"github.com/rs/zerolog/log"
 )
@@ -15,23 +21,37 @@ type (
 		*lab.LabService
 		*challenge.ChallengeService
 		*platform.PlatformService
+		*template.TemplateService
+		*group.GroupService
+		*pool.PoolService
 	}
 
 	IInfrastructure interface {
 		lab.IInfrastructure
 		challenge.IInfrastructure
 		dns.IInfrastructure
+		vpn.IInfrastructure
+		bastion.IInfrastructure
+		attackbox.IInfrastructure
 		platform.IInfrastructure
+		pool.IInfrastructure
 	}
 
 	labService struct {
 		*challenge.ChallengeService
 		*dns.DNSService
+		*vpn.VPNService
+		*bastion.BastionService
+		*attackbox.AttackBoxService
 	}
 
 	IRepository interface {
 		lab.IRepository
+		challenge.IRepository
 		platform.IRepository
+		template.IRepository
+		group.IRepository
+		pool.IRepository
 	}
 
 	Dependencies struct {
@@ -42,32 +62,87 @@ type (
 )
 
 func NewService(deps Dependencies) *Service {
-	IPAManager, err := ipam.NewIPAManager(ipam.Dependencies{
+	poolService, err := pool.NewPoolService(pool.Dependencies{
+		Infrastructure: deps.Infrastructure,
+		Repository:     deps.Repository,
 		PostgresConfig: ipam.PostgresConfig(deps.Config.Repository.Postgres),
-		CIDR:           deps.Config.Service.LabsCIDR,
+		DefaultCIDR:    deps.Config.Service.LabsCIDR,
 	})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to initialize IPAManager")
+		log.Fatal().Err(err).Msg("Failed to initialize CIDR pools")
 	}
 
 	challengeService := challenge.NewChallengeService(challenge.Dependencies{
 		Infrastructure: deps.Infrastructure,
+		Repository:     deps.Repository,
+		IPAM:           poolService,
+		BaseDomain:     deps.Config.Service.Ingress.BaseDomain,
+		Publish:        deps.Config.Service.Publish,
 	})
 
+	vpnService, err := vpn.NewVPNService(vpn.Dependencies{
+		Infrastructure: deps.Infrastructure,
+		ClientsCIDR:    deps.Config.Service.VPN.ClientsCIDR,
+		EndpointHost:   deps.Config.Service.VPN.EndpointHost,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize VPN service")
+	}
+
+	dnsService, err := dns.NewDNSService(deps.Infrastructure, deps.Config.Service.DNSUpstreams)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize DNS service")
+	}
+
+	labDependencies := lab.Dependencies{
+		Infrastructure: deps.Infrastructure,
+		CIDRPools:      poolService,
+		Repository:     deps.Repository,
+		AdminNamespace: deps.Config.Infrastructure.Kubernetes.AdminNamespace,
+		Service: labService{
+			ChallengeService: challengeService,
+			DNSService:       dnsService,
+			VPNService:       vpnService,
+			BastionService: bastion.NewBastionService(bastion.Dependencies{
+				Infrastructure:       deps.Infrastructure,
+				RouterNamespace:      deps.Config.Service.SSH.RouterNamespace,
+				RouterEndpoint:       deps.Config.Service.SSH.RouterEndpoint,
+				RouterServiceAccount: deps.Config.Service.SSH.RouterServiceAccount,
+			}),
+			AttackBoxService: attackbox.NewAttackBoxService(attackbox.Dependencies{
+				Infrastructure: deps.Infrastructure,
+				BaseDomain:     deps.Config.Service.Ingress.BaseDomain,
+				Defaults:       deps.Config.Service.AttackBox,
+			}),
+		},
+	}
+
+	// the IPv6 lab networks are available only in the dual-stack cluster
+	if deps.Config.Service.LabsIPv6CIDR != "" {
+		IPv6Manager, err := ipam.NewIPAManager(ipam.Dependencies{
+			PostgresConfig: ipam.PostgresConfig(deps.Config.Repository.Postgres),
+			CIDR:           deps.Config.Service.LabsIPv6CIDR,
+		})
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to initialize IPv6 IPAManager")
+		}
+		labDependencies.IPv6Manager = IPv6Manager
+	}
+
 	return &Service{
-		LabService: lab.NewLabService(lab.Dependencies{
-			Infrastructure: deps.Infrastructure,
-			IPAManager:     IPAManager,
-			Repository:     deps.Repository,
-			Service: labService{
-				ChallengeService: challengeService,
-				DNSService:       dns.NewDNSService(deps.Infrastructure),
-			},
-		}),
+		LabService:       lab.NewLabService(labDependencies),
 		ChallengeService: challengeService,
 		PlatformService: platform.NewPlatformService(platform.Dependencies{
 			Infrastructure: deps.Infrastructure,
 			Repository:     deps.Repository,
 		}),
+		TemplateService: template.NewTemplateService(template.Dependencies{
+			Repository: deps.Repository,
+		}),
+		GroupService: group.NewGroupService(group.Dependencies{
+			Repository:     deps.Repository,
+			AdminNamespace: deps.Config.Infrastructure.Kubernetes.AdminNamespace,
+		}),
+		PoolService: poolService,
 	}
 }
@@ -0,0 +1,153 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/cybericebox/agent/internal/delivery/repository/postgres"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// createTemplateAttempts limits the creations of the next template version taken by the concurrent creation
+	createTemplateAttempts = 5
+)
+
+type (
+	IRepository interface {
+		CreateLabTemplate(ctx context.Context, arg postgres.CreateLabTemplateParams) (postgres.LabTemplate, error)
+		GetLabTemplate(ctx context.Context, id uuid.UUID) (postgres.LabTemplate, error)
+		GetLabTemplates(ctx context.Context, name pgtype.Text) ([]postgres.LabTemplate, error)
+		DeleteLabTemplate(ctx context.Context, id uuid.UUID) (int64, error)
+	}
+
+	Dependencies struct {
+		Repository IRepository
+	}
+
+	TemplateService struct {
+		repository IRepository
+	}
+)
+
+func NewTemplateService(deps Dependencies) *TemplateService {
+	return &TemplateService{
+		repository: deps.Repository,
+	}
+}
+
+// CreateTemplate stores the challenges as the next version of the named template
+func (s *TemplateService) CreateTemplate(ctx context.Context, name string, challenges []model.ChallengeConfig) (*model.LabTemplate, error) {
+	if name == "" {
+		return nil, appError.ErrLabTemplateNameRequired.Err()
+	}
+
+	data, err := json.Marshal(challenges)
+	if err != nil {
+		return nil, appError.ErrLabTemplate.WithError(err).WithMessage("Failed to marshal template challenges").WithContext("name", name).Err()
+	}
+
+	template, err := s.createTemplateVersion(ctx, postgres.CreateLabTemplateParams{
+		ID:         uuid.Must(uuid.NewV7()),
+		Name:       name,
+		Challenges: data,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, appError.ErrLabTemplateVersionTaken.WithContext("name", name).Err()
+		}
+		return nil, appError.ErrLabTemplate.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to create template in db").WithContext("name", name).Err()
+	}
+
+	return toModelTemplate(template)
+}
+
+// createTemplateVersion creates the next version of the template, the creation is repeated
+// if the same version is created concurrently and the insert violates the unique template version
+func (s *TemplateService) createTemplateVersion(ctx context.Context, arg postgres.CreateLabTemplateParams) (template postgres.LabTemplate, err error) {
+	for range createTemplateAttempts {
+		template, err = s.repository.CreateLabTemplate(ctx, arg)
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) || pgErr.Code != pgerrcode.UniqueViolation {
+			return
+		}
+	}
+
+	return
+}
+
+func (s *TemplateService) GetTemplate(ctx context.Context, templateID string) (*model.LabTemplate, error) {
+	parsedTemplateID, err := uuid.FromString(templateID)
+	if err != nil {
+		return nil, appError.ErrLabTemplate.WithError(err).WithMessage("Failed to parse template id").WithContext("templateID", templateID).Err()
+	}
+
+	template, err := s.repository.GetLabTemplate(ctx, parsedTemplateID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appError.ErrLabTemplateNotFound.WithContext("templateID", templateID).Err()
+		}
+		return nil, appError.ErrLabTemplate.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to get template from db").WithContext("templateID", templateID).Err()
+	}
+
+	return toModelTemplate(template)
+}
+
+// GetTemplates returns all versions of the templates, filtered by name if it is not empty
+func (s *TemplateService) GetTemplates(ctx context.Context, name string) ([]*model.LabTemplate, error) {
+	templates, err := s.repository.GetLabTemplates(ctx, pgtype.Text{String: name, Valid: name != ""})
+	if err != nil {
+		return nil, appError.ErrLabTemplate.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to get templates from db").Err()
+	}
+
+	result := make([]*model.LabTemplate, 0, len(templates))
+	for _, template := range templates {
+		t, err := toModelTemplate(template)
+		if err != nil {
+			return nil, appError.ErrLabTemplate.WithError(err).WithMessage("Failed to convert template").Err()
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (s *TemplateService) DeleteTemplate(ctx context.Context, templateID string) error {
+	parsedTemplateID, err := uuid.FromString(templateID)
+	if err != nil {
+		return appError.ErrLabTemplate.WithError(err).WithMessage("Failed to parse template id").WithContext("templateID", templateID).Err()
+	}
+
+	affected, err := s.repository.DeleteLabTemplate(ctx, parsedTemplateID)
+	if err != nil {
+		return appError.ErrLabTemplate.WithWrappedError(appError.ErrPostgres.WithError(err)).WithMessage("Failed to delete template from db").WithContext("templateID", templateID).Err()
+	}
+
+	if affected == 0 {
+		return appError.ErrLabTemplateNotFound.WithContext("templateID", templateID).Err()
+	}
+
+	return nil
+}
+
+func toModelTemplate(template postgres.LabTemplate) (*model.LabTemplate, error) {
+	challenges := make([]model.ChallengeConfig, 0)
+	if err := json.Unmarshal(template.Challenges, &challenges); err != nil {
+		return nil, appError.ErrLabTemplate.WithError(err).WithMessage("Failed to unmarshal template challenges").WithContext("templateID", template.ID.String()).Err()
+	}
+
+	return &model.LabTemplate{
+		ID:         template.ID,
+		Name:       template.Name,
+		Version:    template.Version,
+		Challenges: challenges,
+		CreatedAt:  template.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,432 @@
+package vpn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/cybericebox/agent/internal/config"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+	"github.com/cybericebox/lib/pkg/wgKeyGen"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	gatewayName       = "vpn-gateway"
+	gatewayConfigName = "vpn-config"
+	gatewayConfigPath = "/config/wg_confs"
+
+	Mb64   = 64 * 1024 * 1024
+	Cpu50m = 50
+	image  = "linuxserver/wireguard:1.0.20210914"
+
+	port      = 51820
+	keepalive = 25
+
+	nodePortService     = "NodePort"
+	loadBalancerService = "LoadBalancer"
+
+	serverConfigFile = "wg0.conf"
+	stateFile        = "state"
+
+	// the gateway reloads the changed config without dropping the tunnels, the kubelet updates the mounted secret within its sync period
+	gatewayScript = `set -e
+cp /config/wg_confs/wg0.conf /tmp/wg0.conf
+wg-quick up /tmp/wg0.conf
+trap 'wg-quick down /tmp/wg0.conf; exit 0' TERM INT
+while true; do
+	sleep 10 & wait $!
+	if ! cmp -s /config/wg_confs/wg0.conf /tmp/wg0.conf; then
+		cp /config/wg_confs/wg0.conf /tmp/wg0.conf
+		wg syncconf wg0 <(wg-quick strip /tmp/wg0.conf)
+	fi
+done`
+
+	// the gateway masquerades the peers traffic, so the lab pods reply to the gateway address
+	serverConfigContent = `[Interface]
+Address = {{.Address}}
+ListenPort = {{.Port}}
+PrivateKey = {{.PrivateKey}}
+PostUp = iptables -t nat -A POSTROUTING -o eth0 -j MASQUERADE
+PostDown = iptables -t nat -D POSTROUTING -o eth0 -j MASQUERADE
+{{range .Peers}}
+[Peer]
+# {{.ID}}
+PublicKey = {{.PublicKey}}
+PresharedKey = {{.PresharedKey}}
+AllowedIPs = {{.Address}}/32
+{{end}}`
+	clientConfigContent = `[Interface]
+PrivateKey = {{.PrivateKey}}
+Address = {{.Address}}/32
+DNS = {{.DNS}}
+
+[Peer]
+PublicKey = {{.GatewayPublicKey}}
+PresharedKey = {{.PresharedKey}}
+AllowedIPs = {{.AllowedIPs}}
+Endpoint = {{.Endpoint}}
+PersistentKeepalive = {{.Keepalive}}
+`
+)
+
+type (
+	// gatewayState is the gateway keys and peers stored with the gateway config
+	gatewayState struct {
+		PrivateKey string
+		PublicKey  string
+		Peers      []model.VPNPeer
+	}
+
+	serverConfig struct {
+		Address    string
+		Port       int
+		PrivateKey string
+		Peers      []model.VPNPeer
+	}
+
+	clientConfig struct {
+		PrivateKey       string
+		Address          netip.Addr
+		DNS              string
+		GatewayPublicKey string
+		PresharedKey     string
+		AllowedIPs       string
+		Endpoint         string
+		Keepalive        int
+	}
+
+	IInfrastructure interface {
+		ApplyDeployment(ctx context.Context, config model.ApplyDeploymentConfig) error
+
+		ApplySecret(ctx context.Context, name, namespace string, labels, data map[string]string) error
+		GetSecretData(ctx context.Context, name, namespace string) (map[string]string, error)
+
+		ApplyService(ctx context.Context, cfg model.ApplyServiceConfig) error
+		GetService(ctx context.Context, name, namespace string) (*model.ServiceStatus, error)
+
+		ApplyVPNGatewayPolicy(ctx context.Context, labID string, port int32) error
+	}
+
+	Dependencies struct {
+		Infrastructure IInfrastructure
+		// ClientsCIDR is the tunnel subnet, the first address is the gateway one
+		ClientsCIDR string
+		// EndpointHost is the public host of the cluster nodes, the NodePort gateways require it
+		EndpointHost string
+	}
+
+	VPNService struct {
+		infrastructure IInfrastructure
+		keyGenerator   *wgKeyGen.KeyGenerator
+		clientsCIDR    netip.Prefix
+		endpointHost   string
+	}
+)
+
+func NewVPNService(deps Dependencies) (*VPNService, error) {
+	clientsCIDR, err := netip.ParsePrefix(deps.ClientsCIDR)
+	if err != nil || !clientsCIDR.Addr().Is4() || clientsCIDR.Bits() > 30 {
+		return nil, appError.ErrLabVPN.WithError(err).WithMessageF("VPN clients CIDR %s must be an IPv4 network with at least 2 peer addresses", deps.ClientsCIDR).Err()
+	}
+
+	return &VPNService{
+		infrastructure: deps.Infrastructure,
+		keyGenerator:   wgKeyGen.NewKeyGenerator(),
+		clientsCIDR:    clientsCIDR.Masked(),
+		endpointHost:   deps.EndpointHost,
+	}, nil
+}
+
+// ValidateVPNGateway checks the gateway type can be exposed in the cluster
+func (s *VPNService) ValidateVPNGateway(gateway model.VPNGateway) error {
+	switch gateway {
+	case model.VPNGatewayDisabled, model.VPNGatewayLoadBalancer:
+		return nil
+	case model.VPNGatewayNodePort:
+		if s.endpointHost == "" {
+			return appError.ErrLabVPNInvalidGateway.WithMessage("NodePort VPN gateway requires the endpoint host").Err()
+		}
+		return nil
+	default:
+		return appError.ErrLabVPNInvalidGateway.WithMessageF("Lab VPN gateway type %d is invalid", gateway).Err()
+	}
+}
+
+// CreateVPNGateway creates the lab WireGuard gateway with the reserved lab address, the new gateway has no peers
+func (s *VPNService) CreateVPNGateway(ctx context.Context, labID, ip string, gateway model.VPNGateway) error {
+	labVPNErr := appError.ErrLabVPN.WithContext("labID", labID)
+
+	keys, err := s.keyGenerator.NewKeyPair()
+	if err != nil {
+		return labVPNErr.WithError(err).WithMessage("Failed to generate gateway keys").Err()
+	}
+
+	if err = s.applyConfig(ctx, labID, gatewayState{PrivateKey: keys.PrivateKey, PublicKey: keys.PublicKey}); err != nil {
+		return labVPNErr.WithError(err).WithMessage("Failed to apply gateway config").Err()
+	}
+
+	if err = s.infrastructure.ApplyVPNGatewayPolicy(ctx, labID, port); err != nil {
+		return labVPNErr.WithError(err).WithMessage("Failed to apply gateway network policy").Err()
+	}
+
+	if err = s.infrastructure.ApplyDeployment(ctx, model.ApplyDeploymentConfig{
+		Name:  gatewayName,
+		LabID: labID,
+		Image: image,
+		IP:    ip,
+		Labels: map[string]string{
+			config.PlatformLabel:      config.LabVPN,
+			config.LabIDLabel:         labID,
+			config.EgressProfileLabel: config.VPNGatewayEgress,
+		},
+		Resources: model.ResourcesConfig{
+			Requests: model.ResourceConfig{
+				Memory: Mb64,
+				CPU:    Cpu50m,
+			},
+			Limit: model.ResourceConfig{
+				Memory: Mb64,
+				CPU:    Cpu50m,
+			},
+		},
+		ReplicaCount: 1,
+		Command:      []string{"/bin/bash", "-c", gatewayScript},
+		// the gateway sets up the WireGuard interface and forwards the peers traffic,
+		// the kubelet must allow the unsafe ip forwarding sysctl
+		CapAdds: []string{"NET_ADMIN"},
+		Sysctls: map[string]string{"net.ipv4.ip_forward": "1"},
+		Volumes: []model.Volume{{
+			Name:       gatewayConfigName,
+			SecretName: gatewayConfigName,
+			MountPath:  gatewayConfigPath,
+		}},
+	}); err != nil {
+		return labVPNErr.WithError(err).WithMessage("Failed to apply gateway deployment").Err()
+	}
+
+	serviceType := nodePortService
+	if gateway == model.VPNGatewayLoadBalancer {
+		serviceType = loadBalancerService
+	}
+
+	if err = s.infrastructure.ApplyService(ctx, model.ApplyServiceConfig{
+		Name:  gatewayName,
+		LabID: labID,
+		Labels: map[string]string{
+			config.PlatformLabel: config.LabVPN,
+			config.LabIDLabel:    labID,
+		},
+		Selector: map[string]string{
+			config.PlatformLabel: config.LabVPN,
+		},
+		Type: serviceType,
+		Ports: []model.ServicePort{{
+			Name:     "wireguard",
+			Protocol: "UDP",
+			Port:     port,
+		}},
+	}); err != nil {
+		return labVPNErr.WithError(err).WithMessage("Failed to apply gateway service").Err()
+	}
+
+	return nil
+}
+
+// AddVPNPeer adds the player peer to the lab gateway and returns the client config, the keys of the existing peer are replaced
+func (s *VPNService) AddVPNPeer(ctx context.Context, lab *model.Lab, peerID string) (string, error) {
+	labVPNErr := appError.ErrLabVPN.WithContext("labID", lab.ID.String()).WithContext("peerID", peerID)
+
+	if errs := validation.IsDNS1123Label(peerID); len(errs) != 0 {
+		return "", appError.ErrLabVPNInvalidPeer.WithMessageF("Lab VPN peer id %s is invalid: %s", peerID, strings.Join(errs, "; ")).Err()
+	}
+
+	// the peer is not added until the gateway can be reached
+	endpoint, err := s.endpoint(ctx, lab.ID.String())
+	if err != nil {
+		return "", labVPNErr.WithError(err).WithMessage("Failed to get gateway endpoint").Err()
+	}
+
+	dns, err := lab.CIDRManager.GetFirstIP()
+	if err != nil {
+		return "", labVPNErr.WithError(err).WithMessage("Failed to get dns server ip").Err()
+	}
+
+	state, err := s.getState(ctx, lab.ID.String())
+	if err != nil {
+		return "", labVPNErr.WithError(err).WithMessage("Failed to get gateway state").Err()
+	}
+
+	keys, err := s.keyGenerator.NewKeyPair()
+	if err != nil {
+		return "", labVPNErr.WithError(err).WithMessage("Failed to generate peer keys").Err()
+	}
+
+	presharedKey, err := s.keyGenerator.NewPreSharedKey()
+	if err != nil {
+		return "", labVPNErr.WithError(err).WithMessage("Failed to generate peer preshared key").Err()
+	}
+
+	peer := model.VPNPeer{
+		ID:           peerID,
+		PublicKey:    keys.PublicKey,
+		PresharedKey: presharedKey,
+	}
+
+	if idx := slices.IndexFunc(state.Peers, func(p model.VPNPeer) bool { return p.ID == peerID }); idx != -1 {
+		peer.Address = state.Peers[idx].Address
+		state.Peers[idx] = peer
+	} else {
+		peer.Address, err = s.freeAddress(state.Peers)
+		if err != nil {
+			return "", labVPNErr.WithError(err).WithMessage("Failed to get peer address").Err()
+		}
+		state.Peers = append(state.Peers, peer)
+	}
+
+	// the gateway reloads the changed config
+	if err = s.applyConfig(ctx, lab.ID.String(), state); err != nil {
+		return "", labVPNErr.WithError(err).WithMessage("Failed to apply gateway config").Err()
+	}
+
+	// the peer reaches the lab network and the lab segments
+	allowedIPs := []string{lab.CIDR.String()}
+	for _, segment := range lab.Segments {
+		allowedIPs = append(allowedIPs, segment.CIDR.String())
+	}
+
+	var tpl bytes.Buffer
+
+	t, err := template.New("client").Parse(clientConfigContent)
+	if err != nil {
+		return "", labVPNErr.WithError(err).WithMessage("Failed to parse template").Err()
+	}
+	if err = t.Execute(&tpl, clientConfig{
+		PrivateKey:       keys.PrivateKey,
+		Address:          peer.Address,
+		DNS:              dns,
+		GatewayPublicKey: state.PublicKey,
+		PresharedKey:     peer.PresharedKey,
+		AllowedIPs:       strings.Join(allowedIPs, ", "),
+		Endpoint:         endpoint,
+		Keepalive:        keepalive,
+	}); err != nil {
+		return "", labVPNErr.WithError(err).WithMessage("Failed to execute template").Err()
+	}
+
+	return tpl.String(), nil
+}
+
+// RevokeVPNPeer removes the player peer from the lab gateway
+func (s *VPNService) RevokeVPNPeer(ctx context.Context, labID, peerID string) error {
+	labVPNErr := appError.ErrLabVPN.WithContext("labID", labID).WithContext("peerID", peerID)
+
+	state, err := s.getState(ctx, labID)
+	if err != nil {
+		return labVPNErr.WithError(err).WithMessage("Failed to get gateway state").Err()
+	}
+
+	idx := slices.IndexFunc(state.Peers, func(p model.VPNPeer) bool { return p.ID == peerID })
+	if idx == -1 {
+		return appError.ErrLabVPNPeerNotFound.WithContext("labID", labID).WithContext("peerID", peerID).Err()
+	}
+	state.Peers = slices.Delete(state.Peers, idx, idx+1)
+
+	// the gateway reloads the changed config
+	if err = s.applyConfig(ctx, labID, state); err != nil {
+		return labVPNErr.WithError(err).WithMessage("Failed to apply gateway config").Err()
+	}
+
+	return nil
+}
+
+func (s *VPNService) getState(ctx context.Context, labID string) (gatewayState, error) {
+	data, err := s.infrastructure.GetSecretData(ctx, gatewayConfigName, labID)
+	if err != nil {
+		return gatewayState{}, appError.ErrLabVPN.WithError(err).WithMessage("Failed to get gateway config").WithContext("labID", labID).Err()
+	}
+
+	var state gatewayState
+	if err = json.Unmarshal([]byte(data[stateFile]), &state); err != nil {
+		return gatewayState{}, appError.ErrLabVPN.WithError(err).WithMessage("Failed to unmarshal gateway state").WithContext("labID", labID).Err()
+	}
+
+	return state, nil
+}
+
+// applyConfig stores the gateway state and the WireGuard config rendered from it
+func (s *VPNService) applyConfig(ctx context.Context, labID string, state gatewayState) error {
+	var tpl bytes.Buffer
+
+	t, err := template.New("server").Parse(serverConfigContent)
+	if err != nil {
+		return appError.ErrLabVPN.WithError(err).WithMessage("Failed to parse template").WithContext("labID", labID).Err()
+	}
+	if err = t.Execute(&tpl, serverConfig{
+		Address:    netip.PrefixFrom(s.clientsCIDR.Addr().Next(), s.clientsCIDR.Bits()).String(),
+		Port:       port,
+		PrivateKey: state.PrivateKey,
+		Peers:      state.Peers,
+	}); err != nil {
+		return appError.ErrLabVPN.WithError(err).WithMessage("Failed to execute template").WithContext("labID", labID).Err()
+	}
+
+	stateData, err := json.Marshal(state)
+	if err != nil {
+		return appError.ErrLabVPN.WithError(err).WithMessage("Failed to marshal gateway state").WithContext("labID", labID).Err()
+	}
+
+	if err = s.infrastructure.ApplySecret(ctx, gatewayConfigName, labID, map[string]string{
+		config.PlatformLabel: config.LabVPNConfig,
+		config.LabIDLabel:    labID,
+	}, map[string]string{
+		serverConfigFile: tpl.String(),
+		stateFile:        string(stateData),
+	}); err != nil {
+		return appError.ErrLabVPN.WithError(err).WithMessage("Failed to apply gateway secret").WithContext("labID", labID).Err()
+	}
+
+	return nil
+}
+
+// freeAddress returns the first tunnel address not taken by the peers, the first address is the gateway one
+func (s *VPNService) freeAddress(peers []model.VPNPeer) (netip.Addr, error) {
+	for addr := s.clientsCIDR.Addr().Next().Next(); s.clientsCIDR.Contains(addr.Next()); addr = addr.Next() {
+		if !slices.ContainsFunc(peers, func(p model.VPNPeer) bool { return p.Address == addr }) {
+			return addr, nil
+		}
+	}
+
+	return netip.Addr{}, appError.ErrLabVPNPeersExhausted.WithContext("cidr", s.clientsCIDR.String()).Err()
+}
+
+// endpoint returns the public address of the gateway, the NodePort gateway is reached by the configured host
+func (s *VPNService) endpoint(ctx context.Context, labID string) (string, error) {
+	svc, err := s.infrastructure.GetService(ctx, gatewayName, labID)
+	if err != nil {
+		return "", appError.ErrLabVPN.WithError(err).WithMessage("Failed to get gateway service").WithContext("labID", labID).Err()
+	}
+
+	if len(svc.Ports) == 0 {
+		return "", appError.ErrLabVPNEndpointNotReady.WithContext("labID", labID).Err()
+	}
+
+	if svc.Type == loadBalancerService {
+		if len(svc.ExternalAddresses) == 0 {
+			return "", appError.ErrLabVPNEndpointNotReady.WithMessage("Lab VPN gateway load balancer has no address yet").WithContext("labID", labID).Err()
+		}
+		return net.JoinHostPort(svc.ExternalAddresses[0], strconv.Itoa(int(svc.Ports[0].Port))), nil
+	}
+
+	if svc.Ports[0].NodePort == 0 || s.endpointHost == "" {
+		return "", appError.ErrLabVPNEndpointNotReady.WithContext("labID", labID).Err()
+	}
+
+	return net.JoinHostPort(s.endpointHost, strconv.Itoa(int(svc.Ports[0].NodePort))), nil
+}
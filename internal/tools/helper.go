@@ -4,10 +4,34 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"github.com/cybericebox/agent/internal/config"
 	"github.com/cybericebox/agent/internal/model"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"net/netip"
+	"slices"
 	"strings"
 )
 
+// unprivilegedCapabilities can be added to the instance container without the privileged permission of the lab group,
+// they are the default capabilities of the container runtime and the network ones the network challenges need
+var unprivilegedCapabilities = []string{
+	"AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD", "NET_ADMIN", "NET_BIND_SERVICE",
+	"NET_RAW", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT",
+}
+
+// labels used by the platform itself can not be set by the users
+var reservedLabels = []string{
+	config.PlatformLabel,
+	config.LabGroupIDLabel,
+	config.LabIDLabel,
+	config.ChallengeIDLabel,
+	config.InstanceIDLabel,
+	config.EgressProfileLabel,
+	config.SegmentedLabel,
+	config.IngressRulesLabel,
+	config.EgressRulesLabel,
+}
+
 func RecordsToStr(records []model.DNSRecordConfig) string {
 	var strRecords []string
 
@@ -42,3 +66,79 @@ func GetLabel(values ...string) string {
 	cut, _ := strings.CutSuffix(base64.URLEncoding.EncodeToString(labelSHA[:]), "=")
 	return fmt.Sprintf("A%sA", cut)
 }
+
+// ValidateLabels checks that the labels are valid kubernetes labels and do not override the platform ones
+func ValidateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if slices.Contains(reservedLabels, key) {
+			return fmt.Errorf("label %s is reserved by the platform", key)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return fmt.Errorf("label key %s is invalid: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
+			return fmt.Errorf("label %s value is invalid: %s", key, strings.Join(errs, "; "))
+		}
+	}
+
+	return nil
+}
+
+// ValidateEgressProfile checks that the profile mode is known and the allowed networks and domains are valid
+func ValidateEgressProfile(profile model.EgressProfile) error {
+	switch profile.Mode {
+	case model.EgressModeDefault, model.EgressModeInternet, model.EgressModeNone:
+		return nil
+	case model.EgressModeCIDRs:
+		if len(profile.CIDRs) == 0 {
+			return fmt.Errorf("CIDRs egress mode requires at least one CIDR")
+		}
+		for _, cidr := range profile.CIDRs {
+			if _, err := netip.ParsePrefix(cidr); err != nil {
+				return fmt.Errorf("CIDR %s is invalid: %w", cidr, err)
+			}
+		}
+		return nil
+	case model.EgressModeDomains:
+		if len(profile.Domains) == 0 {
+			return fmt.Errorf("domains egress mode requires at least one domain")
+		}
+		for _, domain := range profile.Domains {
+			if errs := validation.IsDNS1123Subdomain(domain); len(errs) != 0 {
+				return fmt.Errorf("domain %s is invalid: %s", domain, strings.Join(errs, "; "))
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown egress mode %d", profile.Mode)
+	}
+}
+
+// SegmentMemberLabel returns the label of the pods joined to the lab segment
+func SegmentMemberLabel(segment string) string {
+	return fmt.Sprintf("%s-%s", config.SegmentLabel, segment)
+}
+
+// ValidateNetworkRules checks that the rules ports and protocols are valid
+func ValidateNetworkRules(rules []model.NetworkRule) error {
+	for _, rule := range rules {
+		for _, port := range rule.Ports {
+			if port.Port < 1 || port.Port > 65535 {
+				return fmt.Errorf("port %d is out of range", port.Port)
+			}
+			if !slices.Contains([]string{"", "TCP", "UDP", "SCTP"}, port.Protocol) {
+				return fmt.Errorf("protocol %s is not supported", port.Protocol)
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsPrivilegedInstance reports whether the instance requires the privileged permission of the lab group,
+// the privileged instance and the instance adding any capability out of the unprivileged ones require it
+func IsPrivilegedInstance(inst model.InstanceConfig) bool {
+	return inst.Privileged || slices.ContainsFunc(inst.CapAdds, func(capAdd string) bool {
+		return !slices.Contains(unprivilegedCapabilities, capAdd)
+	})
+}
@@ -3,10 +3,9 @@ package useCase
 import (
 	"context"
 	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/internal/tools"
 	"github.com/cybericebox/agent/pkg/appError"
-	"github.com/cybericebox/lib/pkg/worker"
-	"github.com/hashicorp/go-multierror"
-	"sync"
+	"slices"
 )
 
 type (
@@ -19,26 +18,33 @@ type (
 	}
 )
 
-func (u *UseCase) AddLabsChallenges(ctx context.Context, labsGroupID string, labIDs []string, challengesConfigs []model.ChallengeConfig, flagEnvVariables map[string]map[string]map[string]model.EnvConfig) error {
-	var errs error
-
-	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs)
+func (u *UseCase) AddLabsChallenges(ctx context.Context, labsGroupID string, labIDs []string, selector string, challengesConfigs []model.ChallengeConfig, flagEnvVariables map[string]map[string]map[string]model.EnvConfig) error {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
 	if err != nil {
 		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
 	}
 
-	wg := new(sync.WaitGroup)
+	if err = u.checkLabsGroupsWindow(ctx, labIDs, false); err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to check labs groups event window").Err()
+	}
+
+	// the privileged instances are deployed only to the labs of the groups allowing them
+	if len(labIDs) > 0 && hasPrivilegedInstances(challengesConfigs) {
+		if err = u.service.CheckLabsPrivileged(ctx, labIDs); err != nil {
+			return appError.ErrPlatform.WithError(err).WithMessage("Failed to check labs groups privileged permission").Err()
+		}
+	}
 
-	for _, labID := range labIDs {
+	if errs := u.runLabsTasks(labIDs, "add_lab_challenges", func(labID string) error {
 		labChallengesConfigs := make([]model.ChallengeConfig, 0, len(challengesConfigs))
 
 		for _, chConfig := range challengesConfigs {
 			instances := make([]model.InstanceConfig, 0, len(chConfig.Instances))
 
 			for _, inst := range chConfig.Instances {
-				flagEnv, ok := flagEnvVariables[labID][chConfig.ID][inst.ID]
-				if ok {
-					inst.Envs = append(inst.Envs, flagEnv)
+				// the flag is delivered through the instance secret, not the deployment env
+				if flagEnv, ok := flagEnvVariables[labID][chConfig.ID][inst.ID]; ok {
+					inst.Flag = &flagEnv
 				}
 
 				instances = append(instances, inst)
@@ -46,161 +52,91 @@ func (u *UseCase) AddLabsChallenges(ctx context.Context, labsGroupID string, lab
 
 			labChallengesConfigs = append(labChallengesConfigs, model.ChallengeConfig{ID: chConfig.ID, Instances: instances})
 		}
-		wg.Add(1)
-		u.worker.AddTask(worker.NewTask().
-			WithKey(labID, "add_lab_challenges").
-			WithDo(func() error {
-				if err := u.service.AddLabChallenges(ctx, labID, labChallengesConfigs); err != nil {
-					errs = multierror.Append(errs, err)
-					return err
-				}
-				return nil
-			}).WithOnDone(func(_, _ error) {
-			wg.Done()
-		}).Create())
-	}
-
-	wg.Wait()
 
-	if errs != nil {
+		defer u.lockLab(labID)()
+		return u.service.AddLabChallenges(ctx, labID, labChallengesConfigs)
+	}); errs != nil {
 		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to add challenges").Err()
 	}
 
 	return nil
 }
 
-func (u *UseCase) StartLabsChallenges(ctx context.Context, labsGroupID string, labIDs, challengeIDs []string) error {
-	var errs error
-
-	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs)
+func (u *UseCase) StartLabsChallenges(ctx context.Context, labsGroupID string, labIDs []string, selector string, challengeIDs []string) error {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
 	if err != nil {
 		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
 	}
 
-	wg := new(sync.WaitGroup)
-
-	for _, labID := range labIDs {
-		wg.Add(1)
-		u.worker.AddTask(worker.NewTask().
-			WithKey(labID, "start_lab_challenges").
-			WithDo(func() error {
-				if err := u.service.StartLabChallenges(ctx, labID, challengeIDs); err != nil {
-					errs = multierror.Append(errs, err)
-					return err
-				}
-				return nil
-			}).WithOnDone(func(_, _ error) {
-			wg.Done()
-		}).Create())
+	if err = u.checkLabsGroupsWindow(ctx, labIDs, true); err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to check labs groups event window").Err()
 	}
 
-	wg.Wait()
-
-	if errs != nil {
+	if errs := u.runLabsTasks(labIDs, "start_lab_challenges", func(labID string) error {
+		return u.service.StartLabChallenges(ctx, labID, challengeIDs)
+	}); errs != nil {
 		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to start challenges").Err()
 	}
 
 	return nil
 }
 
-func (u *UseCase) StopLabsChallenges(ctx context.Context, labsGroupID string, labIDs, challengeIDs []string) error {
-	var errs error
-
-	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs)
+func (u *UseCase) StopLabsChallenges(ctx context.Context, labsGroupID string, labIDs []string, selector string, challengeIDs []string) error {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
 	if err != nil {
 		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
 	}
 
-	wg := new(sync.WaitGroup)
-
-	for _, labID := range labIDs {
-		wg.Add(1)
-		u.worker.AddTask(worker.NewTask().
-			WithKey(labID, "stop_lab_challenges").
-			WithDo(func() error {
-				if err := u.service.StopLabChallenges(ctx, labID, challengeIDs); err != nil {
-					errs = multierror.Append(errs, err)
-					return err
-				}
-				return nil
-			}).WithOnDone(func(_, _ error) {
-			wg.Done()
-		}).Create())
-	}
-
-	wg.Wait()
-
-	if errs != nil {
+	if errs := u.runLabsTasks(labIDs, "stop_lab_challenges", func(labID string) error {
+		return u.service.StopLabChallenges(ctx, labID, challengeIDs)
+	}); errs != nil {
 		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to stop challenges").Err()
 	}
 
 	return nil
 }
 
-func (u *UseCase) ResetLabsChallenges(ctx context.Context, labsGroupID string, labIDs, challengeIDs []string) error {
-	var errs error
-
-	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs)
+func (u *UseCase) ResetLabsChallenges(ctx context.Context, labsGroupID string, labIDs []string, selector string, challengeIDs []string) error {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
 	if err != nil {
 		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
 	}
 
-	wg := new(sync.WaitGroup)
-
-	for _, labID := range labIDs {
-		wg.Add(1)
-		u.worker.AddTask(worker.NewTask().
-			WithKey(labID, "reset_lab_challenges").
-			WithDo(func() error {
-				if err := u.service.ResetLabChallenges(ctx, labID, challengeIDs); err != nil {
-					errs = multierror.Append(errs, err)
-					return err
-				}
-				return nil
-			}).WithOnDone(func(_, _ error) {
-			wg.Done()
-		}).Create())
+	if err = u.checkLabsGroupsWindow(ctx, labIDs, true); err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to check labs groups event window").Err()
 	}
 
-	wg.Wait()
-
-	if errs != nil {
+	if errs := u.runLabsTasks(labIDs, "reset_lab_challenges", func(labID string) error {
+		return u.service.ResetLabChallenges(ctx, labID, challengeIDs)
+	}); errs != nil {
 		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to reset challenges").Err()
 	}
 
 	return nil
 }
 
-func (u *UseCase) DeleteLabsChallenges(ctx context.Context, labsGroupID string, labIDs, challengeIDs []string) error {
-	var errs error
-
-	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs)
+func (u *UseCase) DeleteLabsChallenges(ctx context.Context, labsGroupID string, labIDs []string, selector string, challengeIDs []string) error {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
 	if err != nil {
 		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
 	}
 
-	wg := new(sync.WaitGroup)
-
-	for _, labID := range labIDs {
-		wg.Add(1)
-		u.worker.AddTask(worker.NewTask().
-			WithKey(labID, "delete_lab_challenges").
-			WithDo(func() error {
-				if err := u.service.DeleteLabChallenges(ctx, labID, challengeIDs); err != nil {
-					errs = multierror.Append(errs, err)
-					return err
-				}
-				return nil
-			}).WithOnDone(func(_, _ error) {
-			wg.Done()
-		}).Create())
-	}
-
-	wg.Wait()
-
-	if errs != nil {
+	if errs := u.runLabsTasks(labIDs, "delete_lab_challenges", func(labID string) error {
+		defer u.lockLab(labID)()
+		return u.service.DeleteLabChallenges(ctx, labID, challengeIDs)
+	}); errs != nil {
 		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to delete challenges").Err()
 	}
 
 	return nil
 }
+
+// hasPrivilegedInstances reports whether any instance requires the privileged permission of the lab group
+func hasPrivilegedInstances(challengesConfigs []model.ChallengeConfig) bool {
+	for _, chConfig := range challengesConfigs {
+		if slices.ContainsFunc(chConfig.Instances, tools.IsPrivilegedInstance) {
+			return true
+		}
+	}
+	return false
+}
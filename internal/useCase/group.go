@@ -0,0 +1,189 @@
+package useCase
+
+import (
+	"context"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+type (
+	IGroupService interface {
+		CreateLabsGroup(ctx context.Context, group model.LabsGroup) (*model.LabsGroup, error)
+		UpdateLabsGroup(ctx context.Context, group model.LabsGroup) (*model.LabsGroup, error)
+		GetLabsGroup(ctx context.Context, groupID string) (*model.LabsGroup, error)
+		GetLabsGroups(ctx context.Context, groupIDs []string) ([]*model.LabsGroup, error)
+		GetLabsGroupsByLabs(ctx context.Context, labIDs []string) ([]*model.LabsGroup, error)
+		CheckLabsPrivileged(ctx context.Context, labIDs []string) error
+		CountLabsGroupLabs(ctx context.Context, groupID uuid.UUID) (int64, error)
+		DeleteLabsGroup(ctx context.Context, groupID string) error
+	}
+)
+
+func (u *UseCase) CreateLabsGroup(ctx context.Context, group model.LabsGroup) (*model.LabsGroup, error) {
+	created, err := u.service.CreateLabsGroup(ctx, group)
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to create labs group").Err()
+	}
+
+	return created, nil
+}
+
+// UpdateLabsGroup updates the group and reapplies its labels to the namespaces of the group labs,
+// the privileged permission is not taken from the group whose labs run the privileged instances
+func (u *UseCase) UpdateLabsGroup(ctx context.Context, group model.LabsGroup) (*model.LabsGroup, error) {
+	if !group.AllowPrivileged {
+		groupLabs, err := u.service.GetStoredLabs(ctx, group.ID.String())
+		if err != nil {
+			return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get labs group labs").Err()
+		}
+
+		labIDs := make([]string, 0, len(groupLabs))
+		for _, lab := range groupLabs {
+			labIDs = append(labIDs, lab.ID.String())
+		}
+
+		if err = u.checkLabsUnprivileged(ctx, labIDs); err != nil {
+			return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to check labs privileged instances").WithContext("groupID", group.ID.String()).Err()
+		}
+	}
+
+	updated, err := u.service.UpdateLabsGroup(ctx, group)
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to update labs group").Err()
+	}
+
+	labs, err := u.service.GetStoredLabs(ctx, updated.ID.String())
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get labs group labs").Err()
+	}
+
+	labIDs := make([]string, 0, len(labs))
+	labsMetadata := make(map[string]map[string]string, len(labs))
+	for _, lab := range labs {
+		labIDs = append(labIDs, lab.ID.String())
+		labsMetadata[lab.ID.String()] = lab.Metadata
+	}
+
+	if errs := u.runLabsTasks(labIDs, "update_lab_metadata", func(labID string) error {
+		return u.service.UpdateLabMetadata(ctx, labID, labsMetadata[labID], updated.Labels)
+	}); errs != nil {
+		return nil, appError.ErrPlatform.WithError(errs).WithMessage("Failed to apply labs group labels to labs").Err()
+	}
+
+	return updated, nil
+}
+
+func (u *UseCase) GetLabsGroups(ctx context.Context, groupIDs []string) ([]*model.LabsGroup, error) {
+	groups, err := u.service.GetLabsGroups(ctx, groupIDs)
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get labs groups").Err()
+	}
+
+	return groups, nil
+}
+
+func (u *UseCase) DeleteLabsGroup(ctx context.Context, groupID string) error {
+	if err := u.service.DeleteLabsGroup(ctx, groupID); err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to delete labs group").Err()
+	}
+
+	return nil
+}
+
+// applyLabsGroup checks the group limits for the new labs and fills the lab config defaults from the group,
+// labs of a group without a stored entity are created as is
+func (u *UseCase) applyLabsGroup(ctx context.Context, labsGroupID string, count int, labConfig model.LabConfig) (model.LabConfig, error) {
+	parsedGroupID := uuid.FromStringOrNil(labsGroupID)
+	if parsedGroupID.IsNil() {
+		return labConfig, nil
+	}
+
+	group, err := u.service.GetLabsGroup(ctx, labsGroupID)
+	if err != nil {
+		return labConfig, appError.ErrPlatform.WithError(err).WithMessage("Failed to get labs group").Err()
+	}
+
+	if group == nil {
+		return labConfig, nil
+	}
+
+	if group.IsEnded(time.Now()) {
+		return labConfig, appError.ErrLabGroupEventEnded.WithContext("groupID", labsGroupID).Err()
+	}
+
+	if group.MaxLabs != 0 {
+		labsCount, err := u.service.CountLabsGroupLabs(ctx, parsedGroupID)
+		if err != nil {
+			return labConfig, appError.ErrPlatform.WithError(err).WithMessage("Failed to count labs group labs").Err()
+		}
+
+		if labsCount+int64(count) > int64(group.MaxLabs) {
+			return labConfig, appError.ErrLabGroupMaxLabsExceeded.
+				WithMessageF("Labs group allows %d labs, it has %d and %d more were requested", group.MaxLabs, labsCount, count).
+				WithContext("groupID", labsGroupID).Err()
+		}
+	}
+
+	if labConfig.CIDRMask == 0 {
+		labConfig.CIDRMask = group.CIDRMask
+	}
+
+	if labConfig.CIDRPool == "" {
+		labConfig.CIDRPool = group.CIDRPool
+	}
+
+	if labConfig.Quota == (model.ResourceQuotaConfig{}) {
+		labConfig.Quota = group.Quota
+	}
+
+	if labConfig.IsolationMode == model.IsolationModeDefault {
+		labConfig.IsolationMode = group.IsolationMode
+	}
+
+	labConfig.Labels = group.Labels
+
+	return labConfig, nil
+}
+
+// checkLabsGroupsWindow rejects the operation if the event of any lab group has ended,
+// if requireStarted is set the event must also have started
+func (u *UseCase) checkLabsGroupsWindow(ctx context.Context, labIDs []string, requireStarted bool) error {
+	if len(labIDs) == 0 {
+		return nil
+	}
+
+	groups, err := u.service.GetLabsGroupsByLabs(ctx, labIDs)
+	if err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get labs groups").Err()
+	}
+
+	now := time.Now()
+	for _, group := range groups {
+		if group.IsEnded(now) {
+			return appError.ErrLabGroupEventEnded.WithContext("groupID", group.ID.String()).Err()
+		}
+		if requireStarted && !group.IsStarted(now) {
+			return appError.ErrLabGroupEventNotStarted.WithContext("groupID", group.ID.String()).Err()
+		}
+	}
+
+	return nil
+}
+
+// checkLabsUnprivileged rejects the labs running the privileged instances
+func (u *UseCase) checkLabsUnprivileged(ctx context.Context, labIDs []string) error {
+	for _, labID := range labIDs {
+		privileged, err := u.service.HasLabPrivilegedInstances(ctx, labID)
+		if err != nil {
+			return appError.ErrPlatform.WithError(err).WithMessage("Failed to check lab privileged instances").WithContext("labID", labID).Err()
+		}
+
+		if privileged {
+			return appError.ErrLabGroupPrivilegedNotAllowed.WithMessage("Lab runs the privileged instances").WithContext("labID", labID).Err()
+		}
+	}
+
+	return nil
+}
@@ -5,60 +5,158 @@ import (
 	"github.com/cybericebox/agent/internal/model"
 	"github.com/cybericebox/agent/pkg/appError"
 	"github.com/cybericebox/lib/pkg/worker"
+	"github.com/gofrs/uuid"
 	"github.com/hashicorp/go-multierror"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 )
 
 type (
 	// ILabService interface
 	ILabService interface {
-		CreateLab(ctx context.Context, subnetMask uint32, labsGroupID string) (*model.Lab, error)
+		CreateLab(ctx context.Context, labsGroupID string, cfg model.LabConfig) (*model.Lab, error)
 		GetLab(ctx context.Context, labID string) (*model.Lab, error)
 		StartLab(ctx context.Context, labID string) error
 		StopLab(ctx context.Context, labID string) error
 		DeleteLab(ctx context.Context, labID string) error
+		UpdateLabMetadata(ctx context.Context, labID string, metadata, extraLabels map[string]string) error
+		MoveLab(ctx context.Context, labID, groupID string, quota *model.ResourceQuotaConfig, groupLabels map[string]string) error
+		HasLabPrivilegedInstances(ctx context.Context, labID string) (bool, error)
+		SetLabIsolationMode(ctx context.Context, labID string, mode model.IsolationMode) error
+		SetLabEgressProfile(ctx context.Context, labID string, profile model.EgressProfile) error
+		RefreshLabExternalPolicies(ctx context.Context, labID string) error
+
+		CheckLabIPAM(ctx context.Context, labID string, release bool) (*model.LabIPAMReport, error)
+		AddLabVPNPeer(ctx context.Context, labID, peerID string) (string, error)
+		RevokeLabVPNPeer(ctx context.Context, labID, peerID string) error
+		AddLabSSHKey(ctx context.Context, labID, playerID, publicKey string) (*model.SSHAccess, error)
+		RemoveLabSSHKey(ctx context.Context, labID, playerID, publicKey string) error
+		GetLabAttackBoxAccess(ctx context.Context, labID string) (*model.AttackBoxAccess, error)
+		StartLabAttackBox(ctx context.Context, labID string) error
+		StopLabAttackBox(ctx context.Context, labID string) error
 
 		GetLabsStatus(ctx context.Context) ([]*model.LabStatus, error)
 	}
 )
 
-func (u *UseCase) GetLabs(ctx context.Context, labsGroupID string, labIDs []string) ([]*model.Lab, error) {
-	var errs error
-
-	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs)
+func (u *UseCase) GetLabs(ctx context.Context, labsGroupID string, labIDs []string, selector string) ([]*model.Lab, error) {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
 	if err != nil {
 		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
 	}
 
 	labs := make([]*model.Lab, 0, len(labIDs))
+	labsMutex := new(sync.Mutex)
+
+	if errs := u.runLabsTasks(labIDs, "get_lab", func(labID string) error {
+		lab, err := u.service.GetLab(ctx, labID)
+		if err != nil {
+			return err
+		}
+		labsMutex.Lock()
+		labs = append(labs, lab)
+		labsMutex.Unlock()
+		return nil
+	}); errs != nil {
+		return nil, appError.ErrPlatform.WithError(errs).WithMessage("Failed to get labs").Err()
+	}
 
-	wg := new(sync.WaitGroup)
+	// group and metadata are kept only in the state
+	storedLabs, err := u.service.GetStoredLabs(ctx, labsGroupID)
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get stored labs").Err()
+	}
 
-	for _, id := range labIDs {
-		wg.Add(1)
-		u.worker.AddTask(worker.NewTask().
-			WithKey(id, "get_lab").
-			WithDo(func() error {
-				lab, err := u.service.GetLab(ctx, id)
-				if err != nil {
-					errs = multierror.Append(errs, err)
-					return err
-				}
-				labs = append(labs, lab)
-				return nil
-			}).WithOnDone(func(_, _ error) {
-			wg.Done()
-		}).Create())
+	for _, lab := range labs {
+		for _, storedLab := range storedLabs {
+			if storedLab.ID == lab.ID {
+				lab.GroupID = storedLab.GroupID
+				lab.Metadata = storedLab.Metadata
+				lab.IsolationMode = storedLab.IsolationMode
+				lab.Egress = storedLab.Egress
+				lab.Segments = storedLab.Segments
+				lab.VPNGateway = storedLab.VPNGateway
+				lab.SSHBastion = storedLab.SSHBastion
+				lab.AttackBox = storedLab.AttackBox
+				break
+			}
+		}
 	}
 
-	wg.Wait()
+	return labs, nil
 
-	if errs != nil {
-		return nil, appError.ErrPlatform.WithError(errs).WithMessage("Failed to get labs").Err()
+}
+
+// UpdateLabMetadata replaces the lab metadata, the lab group labels are kept in the lab namespace
+func (u *UseCase) UpdateLabMetadata(ctx context.Context, labID string, metadata map[string]string) error {
+	groups, err := u.service.GetLabsGroupsByLabs(ctx, []string{labID})
+	if err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab group").Err()
 	}
 
-	return labs, nil
+	var groupLabels map[string]string
+	if len(groups) != 0 {
+		groupLabels = groups[0].Labels
+	}
+
+	if err = u.service.UpdateLabMetadata(ctx, labID, metadata, groupLabels); err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to update lab metadata").Err()
+	}
+
+	return nil
+}
+
+// AddLabVPNPeer adds the player peer to the lab VPN gateway and returns the ready to import client config
+func (u *UseCase) AddLabVPNPeer(ctx context.Context, labID, peerID string) (string, error) {
+	// the gateway peers are read and written back, so the peers changes of the lab are serialized
+	defer u.lockLab(labID)()
+
+	clientConfig, err := u.service.AddLabVPNPeer(ctx, labID, peerID)
+	if err != nil {
+		return "", appError.ErrPlatform.WithError(err).WithMessage("Failed to add lab VPN peer").Err()
+	}
+
+	return clientConfig, nil
+}
+
+func (u *UseCase) RevokeLabVPNPeer(ctx context.Context, labID, peerID string) error {
+	defer u.lockLab(labID)()
+
+	if err := u.service.RevokeLabVPNPeer(ctx, labID, peerID); err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to revoke lab VPN peer").Err()
+	}
+
+	return nil
+}
+
+// AddLabSSHKey authorizes the player public key on the lab SSH bastion and returns the login of the shared SSH router
+func (u *UseCase) AddLabSSHKey(ctx context.Context, labID, playerID, publicKey string) (*model.SSHAccess, error) {
+	access, err := u.service.AddLabSSHKey(ctx, labID, playerID, publicKey)
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to add lab SSH key").Err()
+	}
 
+	return access, nil
+}
+
+func (u *UseCase) RemoveLabSSHKey(ctx context.Context, labID, playerID, publicKey string) error {
+	if err := u.service.RemoveLabSSHKey(ctx, labID, playerID, publicKey); err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to remove lab SSH key").Err()
+	}
+
+	return nil
+}
+
+// GetLabAttackBoxAccess returns the authenticated lab URL of the attack box with the credentials
+func (u *UseCase) GetLabAttackBoxAccess(ctx context.Context, labID string) (*model.AttackBoxAccess, error) {
+	access, err := u.service.GetLabAttackBoxAccess(ctx, labID)
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab attack box access").Err()
+	}
+
+	return access, nil
 }
 
 func (u *UseCase) GetLabsStatus(ctx context.Context) ([]*model.LabStatus, error) {
@@ -70,23 +168,57 @@ func (u *UseCase) GetLabsStatus(ctx context.Context) ([]*model.LabStatus, error)
 	return labs, nil
 }
 
-func (u *UseCase) CreateLabs(ctx context.Context, labsGroupID string, subnetMask uint32, count int) ([]*model.Lab, error) {
+// CreateLabs creates the labs, if cidrs are given every lab gets the exact network with the same index
+func (u *UseCase) CreateLabs(ctx context.Context, labsGroupID string, count int, labConfig model.LabConfig, cidrs []string, templateID string, flagEnvVariables map[int]map[string]map[string]model.EnvConfig) ([]*model.Lab, error) {
 	var errs error
 
-	labs := make([]*model.Lab, 0, count)
+	if len(cidrs) != 0 {
+		if count != 0 && count != len(cidrs) {
+			return nil, appError.ErrLabInvalidCIDRs.WithMessageF("%d CIDRs were given for %d labs", len(cidrs), count).Err()
+		}
+		count = len(cidrs)
+	}
+
+	var template *model.LabTemplate
+	if templateID != "" {
+		var err error
+		template, err = u.service.GetTemplate(ctx, templateID)
+		if err != nil {
+			return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get template").Err()
+		}
+	}
+
+	// the group max labs check and the labs creation are done under the group lock, so the concurrent creates can not exceed it
+	unlock := u.lockLabsGroup(labsGroupID)
+
+	labConfig, err := u.applyLabsGroup(ctx, labsGroupID, count, labConfig)
+	if err != nil {
+		unlock()
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to apply labs group").Err()
+	}
+
+	// labs are kept in the request order, so the template flags can be matched by the lab index
+	labs := make([]*model.Lab, count)
 
+	errsMutex := new(sync.Mutex)
 	wg := new(sync.WaitGroup)
 
 	for i := 0; i < count; i++ {
 		wg.Add(1)
 		u.worker.AddTask(worker.NewTask().
 			WithDo(func() error {
-				lab, err := u.service.CreateLab(ctx, subnetMask, labsGroupID)
+				cfg := labConfig
+				if len(cidrs) != 0 {
+					cfg.CIDR = cidrs[i]
+				}
+				lab, err := u.service.CreateLab(ctx, labsGroupID, cfg)
 				if err != nil {
+					errsMutex.Lock()
 					errs = multierror.Append(errs, err)
+					errsMutex.Unlock()
 					return err
 				}
-				labs = append(labs, lab)
+				labs[i] = lab
 				return nil
 			}).WithOnDone(func(_, _ error) {
 			wg.Done()
@@ -95,114 +227,274 @@ func (u *UseCase) CreateLabs(ctx context.Context, labsGroupID string, subnetMask
 
 	wg.Wait()
 
+	unlock()
+
+	// the labs are created all or nothing, the caller gets no labs it does not know about
 	if errs != nil {
+		if err = u.deleteCreatedLabs(ctx, labs); err != nil {
+			errs = multierror.Append(errs, err)
+		}
 		return nil, appError.ErrPlatform.WithError(errs).WithMessage("Failed to create labs").Err()
 	}
 
+	if template != nil {
+		if err = u.deployTemplate(ctx, template, labs, flagEnvVariables); err != nil {
+			if delErr := u.deleteCreatedLabs(ctx, labs); delErr != nil {
+				err = multierror.Append(err, delErr)
+			}
+			return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to deploy template to labs").Err()
+		}
+	}
+
 	return labs, nil
 }
 
-func (u *UseCase) StartLabs(ctx context.Context, labsGroupID string, labIDs []string) error {
-	var errs error
+// deleteCreatedLabs rolls back the labs created by the failed CreateLabs, the labs which failed to create are nil
+func (u *UseCase) deleteCreatedLabs(ctx context.Context, labs []*model.Lab) error {
+	labIDs := make([]string, 0, len(labs))
+	for _, lab := range labs {
+		if lab != nil {
+			labIDs = append(labIDs, lab.ID.String())
+		}
+	}
+	if len(labIDs) == 0 {
+		return nil
+	}
 
-	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs)
-	if err != nil {
-		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
+	if err := u.DeleteLabs(ctx, "", labIDs, ""); err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to roll back created labs").Err()
 	}
 
-	wg := new(sync.WaitGroup)
+	return nil
+}
 
-	for _, id := range labIDs {
-		wg.Add(1)
-		u.worker.AddTask(worker.NewTask().
-			WithKey(id, "start_lab").
-			WithDo(func() error {
-				err := u.service.StartLab(ctx, id)
-				if err != nil {
-					errs = multierror.Append(errs, err)
-					return err
-				}
-				return nil
-			}).WithOnDone(func(_, _ error) {
-			wg.Done()
-		}).Create())
+func (u *UseCase) StartLabs(ctx context.Context, labsGroupID string, labIDs []string, selector string) error {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
+	if err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
 	}
 
-	wg.Wait()
+	if err = u.checkLabsGroupsWindow(ctx, labIDs, true); err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to check labs groups event window").Err()
+	}
 
-	if errs != nil {
+	if errs := u.runLabsTasks(labIDs, "start_lab", func(labID string) error {
+		return u.service.StartLab(ctx, labID)
+	}); errs != nil {
 		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to start labs").Err()
 	}
 
 	return nil
 }
 
-func (u *UseCase) StopLabs(ctx context.Context, labsGroupID string, labIDs []string) error {
-	var errs error
+func (u *UseCase) StopLabs(ctx context.Context, labsGroupID string, labIDs []string, selector string) error {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
+	if err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
+	}
+
+	if errs := u.runLabsTasks(labIDs, "stop_lab", func(labID string) error {
+		return u.service.StopLab(ctx, labID)
+	}); errs != nil {
+		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to stop labs").Err()
+	}
 
-	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs)
+	return nil
+}
+
+// StartLabsAttackBox starts the attack boxes of the labs, the lab challenges keep their state
+func (u *UseCase) StartLabsAttackBox(ctx context.Context, labsGroupID string, labIDs []string, selector string) error {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
 	if err != nil {
 		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
 	}
 
-	wg := new(sync.WaitGroup)
+	if err = u.checkLabsGroupsWindow(ctx, labIDs, true); err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to check labs groups event window").Err()
+	}
 
-	for _, id := range labIDs {
-		wg.Add(1)
-		u.worker.AddTask(worker.NewTask().
-			WithKey(id, "stop_lab").
-			WithDo(func() error {
-				err := u.service.StopLab(ctx, id)
-				if err != nil {
-					errs = multierror.Append(errs, err)
-					return err
-				}
-				return nil
-			}).WithOnDone(func(_, _ error) {
-			wg.Done()
-		}).Create())
+	if errs := u.runLabsTasks(labIDs, "start_lab_attack_box", func(labID string) error {
+		return u.service.StartLabAttackBox(ctx, labID)
+	}); errs != nil {
+		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to start labs attack boxes").Err()
 	}
 
-	wg.Wait()
+	return nil
+}
 
-	if errs != nil {
-		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to stop labs").Err()
+// StopLabsAttackBox stops the attack boxes of the labs, the lab challenges keep their state
+func (u *UseCase) StopLabsAttackBox(ctx context.Context, labsGroupID string, labIDs []string, selector string) error {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
+	if err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
+	}
+
+	if errs := u.runLabsTasks(labIDs, "stop_lab_attack_box", func(labID string) error {
+		return u.service.StopLabAttackBox(ctx, labID)
+	}); errs != nil {
+		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to stop labs attack boxes").Err()
 	}
 
 	return nil
 }
 
-func (u *UseCase) DeleteLabs(ctx context.Context, labsGroupID string, labIDs []string) error {
-	var errs error
+func (u *UseCase) DeleteLabs(ctx context.Context, labsGroupID string, labIDs []string, selector string) error {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
+	if err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
+	}
 
-	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs)
+	if errs := u.runLabsTasks(labIDs, "delete_lab", func(labID string) error {
+		return u.service.DeleteLab(ctx, labID)
+	}); errs != nil {
+		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to delete labs").Err()
+	}
+
+	return nil
+}
+
+// MoveLabs moves the labs to the target group, the target group limits are checked and its quota is applied to the labs
+func (u *UseCase) MoveLabs(ctx context.Context, labsGroupID string, labIDs []string, selector, targetGroupID string) error {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
 	if err != nil {
 		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
 	}
 
-	wg := new(sync.WaitGroup)
+	parsedTargetGroupID, err := uuid.FromString(targetGroupID)
+	if err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to parse target labs group id").WithContext("groupID", targetGroupID).Err()
+	}
 
-	for _, id := range labIDs {
-		wg.Add(1)
-		u.worker.AddTask(worker.NewTask().
-			WithKey(id, "delete_lab").
-			WithDo(func() error {
-				err := u.service.DeleteLab(ctx, id)
-				if err != nil {
-					errs = multierror.Append(errs, err)
-					return err
+	// the target group max labs check and the moves are done under the group lock
+	defer u.lockLabsGroup(targetGroupID)()
+
+	targetGroup, err := u.service.GetLabsGroup(ctx, targetGroupID)
+	if err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get target labs group").Err()
+	}
+
+	var quota *model.ResourceQuotaConfig
+	if targetGroup != nil {
+		if targetGroup.IsEnded(time.Now()) {
+			return appError.ErrLabGroupEventEnded.WithContext("groupID", targetGroupID).Err()
+		}
+
+		if targetGroup.MaxLabs != 0 {
+			targetLabs, err := u.service.GetStoredLabs(ctx, targetGroupID)
+			if err != nil {
+				return appError.ErrPlatform.WithError(err).WithMessage("Failed to get target labs group labs").Err()
+			}
+
+			// labs already in the target group are not counted twice
+			count := len(targetLabs)
+			for _, id := range labIDs {
+				if !slices.ContainsFunc(targetLabs, func(lab model.Lab) bool { return lab.ID.String() == id }) {
+					count++
 				}
-				return nil
-			}).WithOnDone(func(_, _ error) {
-			wg.Done()
-		}).Create())
+			}
+
+			if count > int(targetGroup.MaxLabs) {
+				return appError.ErrLabGroupMaxLabsExceeded.
+					WithMessageF("Labs group allows %d labs, it would have %d after the move", targetGroup.MaxLabs, count).
+					WithContext("groupID", targetGroupID).Err()
+			}
+		}
+
+		if targetGroup.Quota != (model.ResourceQuotaConfig{}) {
+			quota = &targetGroup.Quota
+		}
 	}
 
-	wg.Wait()
+	// the labs with the privileged instances are moved only to the group allowing them
+	if targetGroup == nil || !targetGroup.AllowPrivileged {
+		if err = u.checkLabsUnprivileged(ctx, labIDs); err != nil {
+			return appError.ErrPlatform.WithError(err).WithMessage("Failed to check labs privileged instances").WithContext("groupID", targetGroupID).Err()
+		}
+	}
 
-	if errs != nil {
-		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to delete labs").Err()
+	var targetLabels map[string]string
+	if targetGroup != nil {
+		targetLabels = targetGroup.Labels
+	}
+
+	if errs := u.runLabsTasks(labIDs, "move_lab", func(labID string) error {
+		// the namespace labels of the previous group are replaced by the target group ones
+		return u.service.MoveLab(ctx, labID, parsedTargetGroupID.String(), quota, targetLabels)
+	}); errs != nil {
+		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to move labs").Err()
+	}
+
+	return nil
+}
+
+func (u *UseCase) SetLabsIsolationMode(ctx context.Context, labsGroupID string, labIDs []string, selector string, mode model.IsolationMode) error {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
+	if err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
+	}
+
+	if errs := u.runLabsTasks(labIDs, "set_lab_isolation_mode", func(labID string) error {
+		return u.service.SetLabIsolationMode(ctx, labID, mode)
+	}); errs != nil {
+		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to set labs isolation mode").Err()
+	}
+
+	return nil
+}
+
+func (u *UseCase) SetLabsEgressProfile(ctx context.Context, labsGroupID string, labIDs []string, selector string, profile model.EgressProfile) error {
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
+	if err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
+	}
+
+	if errs := u.runLabsTasks(labIDs, "set_lab_egress_profile", func(labID string) error {
+		return u.service.SetLabEgressProfile(ctx, labID, profile)
+	}); errs != nil {
+		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to set labs egress profile").Err()
 	}
 
 	return nil
 }
+
+// CheckLabsIPAM cross-checks the labs IPAM allocations, no lab ids, group and selector mean all the labs
+func (u *UseCase) CheckLabsIPAM(ctx context.Context, labsGroupID string, labIDs []string, selector string, release bool) ([]*model.LabIPAMReport, error) {
+	if len(labIDs) == 0 && labsGroupID == "" && selector == "" {
+		labs, err := u.service.GetStoredLabs(ctx, "")
+		if err != nil {
+			return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get stored labs").Err()
+		}
+		for _, lab := range labs {
+			labIDs = append(labIDs, lab.ID.String())
+		}
+	}
+
+	labIDs, err := u.getLabIDs(ctx, labsGroupID, labIDs, selector)
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get lab IDs").Err()
+	}
+
+	reports := make([]*model.LabIPAMReport, 0, len(labIDs))
+	reportsMutex := new(sync.Mutex)
+
+	if errs := u.runLabsTasks(labIDs, "check_lab_ipam", func(labID string) error {
+		// the addresses are probed and released while the lab challenges are not added or deleted
+		defer u.lockLab(labID)()
+		report, err := u.service.CheckLabIPAM(ctx, labID, release)
+		if err != nil {
+			return err
+		}
+		reportsMutex.Lock()
+		reports = append(reports, report)
+		reportsMutex.Unlock()
+		return nil
+	}); errs != nil {
+		return nil, appError.ErrPlatform.WithError(errs).WithMessage("Failed to check labs IPAM").Err()
+	}
+
+	slices.SortFunc(reports, func(a, b *model.LabIPAMReport) int {
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	return reports, nil
+}
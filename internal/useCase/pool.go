@@ -0,0 +1,59 @@
+package useCase
+
+import (
+	"context"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+)
+
+type (
+	IPoolService interface {
+		AddCIDRPool(ctx context.Context, pool model.CIDRPool) (*model.CIDRPool, error)
+		GetCIDRPools(ctx context.Context) ([]model.CIDRPool, error)
+		GetCIDRPoolsUsage(ctx context.Context) ([]model.CIDRPoolUsage, error)
+	}
+)
+
+func (u *UseCase) AddCIDRPool(ctx context.Context, pool model.CIDRPool) (*model.CIDRPool, error) {
+	created, err := u.service.AddCIDRPool(ctx, pool)
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to add CIDR pool").Err()
+	}
+
+	// the labs must not reach the labs of the new pool as the external addresses
+	labs, err := u.service.GetStoredLabs(ctx, "")
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get stored labs").Err()
+	}
+
+	labIDs := make([]string, 0, len(labs))
+	for _, lab := range labs {
+		labIDs = append(labIDs, lab.ID.String())
+	}
+
+	if errs := u.runLabsTasks(labIDs, "refresh_external_policies", func(labID string) error {
+		return u.service.RefreshLabExternalPolicies(ctx, labID)
+	}); errs != nil {
+		return nil, appError.ErrPlatform.WithError(errs).WithMessage("CIDR pool is added, failed to refresh labs network policies").Err()
+	}
+
+	return created, nil
+}
+
+func (u *UseCase) GetCIDRPools(ctx context.Context) ([]model.CIDRPool, error) {
+	pools, err := u.service.GetCIDRPools(ctx)
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get CIDR pools").Err()
+	}
+
+	return pools, nil
+}
+
+func (u *UseCase) GetCIDRPoolsUsage(ctx context.Context) ([]model.CIDRPoolUsage, error) {
+	usages, err := u.service.GetCIDRPoolsUsage(ctx)
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get CIDR pools usage").Err()
+	}
+
+	return usages, nil
+}
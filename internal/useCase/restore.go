@@ -4,15 +4,12 @@ import (
 	"context"
 	"github.com/cybericebox/agent/internal/model"
 	"github.com/cybericebox/agent/pkg/appError"
-	"github.com/cybericebox/lib/pkg/worker"
-	"github.com/hashicorp/go-multierror"
-	"sync"
 )
 
 type (
 	IRestoreService interface {
 		GetStoredLabs(ctx context.Context, labsGroupID string) ([]model.Lab, error)
-		RestoreLabIfNeeded(ctx context.Context, lab model.Lab) error
+		RestoreLabIfNeeded(ctx context.Context, lab model.Lab, extraLabels map[string]string) error
 	}
 )
 
@@ -24,35 +21,43 @@ func (u *UseCase) Restore() error {
 }
 
 func (u *UseCase) RestoreLabsFromState(ctx context.Context) error {
+	// the CIDR pools are loaded to except all their networks from the restored lab network policies
+	if _, err := u.service.GetCIDRPools(ctx); err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get CIDR pools").Err()
+	}
+
 	// get all the labs in the state
 	labs, err := u.service.GetStoredLabs(ctx, "")
 	if err != nil {
 		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get stored labs").Err()
 	}
-	var errs error
-	wg := new(sync.WaitGroup)
 
-	// check if the labs exist in the infrastructure
-	for _, lab := range labs {
-		wg.Add(1)
-		u.worker.AddTask(worker.NewTask().
-			WithKey(lab.ID.String(), "restore_lab").
-			WithDo(func() error {
-				if err = u.service.RestoreLabIfNeeded(ctx, lab); err != nil {
-					errs = multierror.Append(errs, appError.ErrPlatform.WithError(err).WithMessage("Failed to restore lab").WithContext("labID", lab.ID.String()).Err())
-					return err
-				}
-				return nil
-			}).
-			WithOnDone(func(_, _ error) {
-				wg.Done()
-			}).Create())
+	// the restored lab namespaces get the labels of their groups back
+	groups, err := u.service.GetLabsGroups(ctx, nil)
+	if err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to get labs groups").Err()
+	}
 
+	groupsLabels := make(map[string]map[string]string, len(groups))
+	for _, group := range groups {
+		groupsLabels[group.ID.String()] = group.Labels
 	}
 
-	wg.Wait()
+	labIDs := make([]string, 0, len(labs))
+	labsByID := make(map[string]model.Lab, len(labs))
+	for _, lab := range labs {
+		labIDs = append(labIDs, lab.ID.String())
+		labsByID[lab.ID.String()] = lab
+	}
 
-	if errs != nil {
+	// check if the labs exist in the infrastructure
+	if errs := u.runLabsTasks(labIDs, "restore_lab", func(labID string) error {
+		lab := labsByID[labID]
+		if err := u.service.RestoreLabIfNeeded(ctx, lab, groupsLabels[lab.GroupID.String()]); err != nil {
+			return appError.ErrPlatform.WithError(err).WithMessage("Failed to restore lab").WithContext("labID", labID).Err()
+		}
+		return nil
+	}); errs != nil {
 		return appError.ErrPlatform.WithError(errs).WithMessage("Failed to restore labs from state").Err()
 	}
 
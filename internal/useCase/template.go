@@ -0,0 +1,62 @@
+package useCase
+
+import (
+	"context"
+	"github.com/cybericebox/agent/internal/model"
+	"github.com/cybericebox/agent/pkg/appError"
+)
+
+type (
+	ITemplateService interface {
+		CreateTemplate(ctx context.Context, name string, challenges []model.ChallengeConfig) (*model.LabTemplate, error)
+		GetTemplate(ctx context.Context, templateID string) (*model.LabTemplate, error)
+		GetTemplates(ctx context.Context, name string) ([]*model.LabTemplate, error)
+		DeleteTemplate(ctx context.Context, templateID string) error
+	}
+)
+
+func (u *UseCase) CreateTemplate(ctx context.Context, name string, challenges []model.ChallengeConfig) (*model.LabTemplate, error) {
+	template, err := u.service.CreateTemplate(ctx, name, challenges)
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to create template").Err()
+	}
+
+	return template, nil
+}
+
+func (u *UseCase) ListTemplates(ctx context.Context, name string) ([]*model.LabTemplate, error) {
+	templates, err := u.service.GetTemplates(ctx, name)
+	if err != nil {
+		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get templates").Err()
+	}
+
+	return templates, nil
+}
+
+func (u *UseCase) DeleteTemplate(ctx context.Context, templateID string) error {
+	if err := u.service.DeleteTemplate(ctx, templateID); err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to delete template").Err()
+	}
+
+	return nil
+}
+
+// deployTemplate adds the template challenges to the labs, flagEnvVariables are keyed by the lab index in labs
+func (u *UseCase) deployTemplate(ctx context.Context, template *model.LabTemplate, labs []*model.Lab, flagEnvVariables map[int]map[string]map[string]model.EnvConfig) error {
+	labIDs := make([]string, 0, len(labs))
+	// map[labID]map[challengeID]map[instanceID]model.EnvConfig
+	labsFlagEnvVariables := make(map[string]map[string]map[string]model.EnvConfig)
+
+	for i, lab := range labs {
+		labIDs = append(labIDs, lab.ID.String())
+		if flags, ok := flagEnvVariables[i]; ok {
+			labsFlagEnvVariables[lab.ID.String()] = flags
+		}
+	}
+
+	if err := u.AddLabsChallenges(ctx, "", labIDs, "", template.Challenges, labsFlagEnvVariables); err != nil {
+		return appError.ErrPlatform.WithError(err).WithMessage("Failed to add template challenges").WithContext("templateID", template.ID.String()).Err()
+	}
+
+	return nil
+}
@@ -6,7 +6,10 @@ import (
 	"github.com/cybericebox/agent/pkg/appError"
 	"github.com/cybericebox/lib/pkg/worker"
 	"github.com/gofrs/uuid"
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/labels"
 	"slices"
+	"sync"
 )
 
 type (
@@ -14,6 +17,9 @@ type (
 		IRestoreService
 		IChallengeService
 		ILabService
+		ITemplateService
+		IGroupService
+		IPoolService
 
 		GetStoredLabs(ctx context.Context, labsGroupID string) ([]model.Lab, error)
 	}
@@ -26,6 +32,22 @@ type (
 	UseCase struct {
 		service IService
 		worker  worker.Worker
+		// labsGroupLocks serialize the operations checking the group labs count with the ones changing it
+		labsGroupLocks keyedLocks
+		// labLocks serialize the lab operations acquiring and releasing the lab addresses or changing the VPN peers,
+		// the worker task key does not serialize the tasks, the queued task is replaced by the new one with the same key
+		labLocks keyedLocks
+	}
+
+	// keyedLocks keep the mutex of the key only while it is held or awaited, so the locks of the deleted keys are dropped
+	keyedLocks struct {
+		mutex sync.Mutex
+		locks map[string]*keyedLock
+	}
+
+	keyedLock struct {
+		mutex sync.Mutex
+		refs  int
 	}
 )
 
@@ -36,12 +58,59 @@ func NewUseCase(deps Dependencies) *UseCase {
 	}
 }
 
-func (u *UseCase) getLabIDs(ctx context.Context, labsGroupID string, labIDs []string) ([]string, error) {
+// lockLabsGroup locks the group labs count and returns the unlock function, the labs without a group are not locked
+func (u *UseCase) lockLabsGroup(labsGroupID string) func() {
+	if uuid.FromStringOrNil(labsGroupID).IsNil() {
+		return func() {}
+	}
+
+	return u.labsGroupLocks.lock(labsGroupID)
+}
+
+// lockLab locks the lab and returns the unlock function
+func (u *UseCase) lockLab(labID string) func() {
+	return u.labLocks.lock(labID)
+}
+
+// lock locks the key and returns the unlock function, the key lock is dropped by the last unlock
+func (l *keyedLocks) lock(key string) func() {
+	l.mutex.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*keyedLock)
+	}
+	kl, ok := l.locks[key]
+	if !ok {
+		kl = new(keyedLock)
+		l.locks[key] = kl
+	}
+	kl.refs++
+	l.mutex.Unlock()
+
+	kl.mutex.Lock()
+	return func() {
+		kl.mutex.Unlock()
+
+		l.mutex.Lock()
+		kl.refs--
+		if kl.refs == 0 {
+			delete(l.locks, key)
+		}
+		l.mutex.Unlock()
+	}
+}
+
+// getLabIDs resolves the labs targeted by the group, the explicit ids and the metadata label selector
+func (u *UseCase) getLabIDs(ctx context.Context, labsGroupID string, labIDs []string, selector string) ([]string, error) {
 	parsedGroupID := uuid.FromStringOrNil(labsGroupID)
-	if parsedGroupID.IsNil() {
+	if parsedGroupID.IsNil() && selector == "" {
 		return labIDs, nil
 	}
 
+	parsedSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, appError.ErrLabInvalidSelector.WithError(err).WithMessageF("Lab selector is invalid: %s", err.Error()).WithContext("selector", selector).Err()
+	}
+
 	labs, err := u.service.GetStoredLabs(ctx, labsGroupID)
 	if err != nil {
 		return nil, appError.ErrPlatform.WithError(err).WithMessage("Failed to get stored labs").Err()
@@ -49,9 +118,41 @@ func (u *UseCase) getLabIDs(ctx context.Context, labsGroupID string, labIDs []st
 
 	ids := make([]string, 0, len(labs))
 	for _, lab := range labs {
-		if slices.Contains(labIDs, lab.ID.String()) || len(labIDs) == 0 {
+		if (slices.Contains(labIDs, lab.ID.String()) || len(labIDs) == 0) && parsedSelector.Matches(labels.Set(lab.Metadata)) {
 			ids = append(ids, lab.ID.String())
 		}
 	}
 	return ids, nil
 }
+
+// runLabsTasks runs the task for each lab by the worker and waits for all of them, the errors of the labs are combined
+func (u *UseCase) runLabsTasks(labIDs []string, taskName string, do func(labID string) error) error {
+	var (
+		errs      error
+		errsMutex sync.Mutex
+	)
+
+	wg := new(sync.WaitGroup)
+
+	for _, id := range labIDs {
+		wg.Add(1)
+		u.worker.AddTask(worker.NewTask().
+			WithKey(id, taskName).
+			WithDo(func() error {
+				err := do(id)
+				if err != nil {
+					errsMutex.Lock()
+					errs = multierror.Append(errs, err)
+					errsMutex.Unlock()
+					return err
+				}
+				return nil
+			}).WithOnDone(func(_, _ error) {
+			wg.Done()
+		}).Create())
+	}
+
+	wg.Wait()
+
+	return errs
+}
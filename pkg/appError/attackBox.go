@@ -0,0 +1,11 @@
+package appError
+
+import "github.com/cybericebox/lib/pkg/err"
+
+var (
+	ErrLabAttackBox = err.ErrInternal.WithObjectCode(labAttackBoxObjectCode)
+
+	ErrLabAttackBoxNotEnabled    = err.ErrForbidden.WithObjectCode(labAttackBoxObjectCode).WithDetailCode(1).WithMessage("Lab has no attack box")
+	ErrLabAttackBoxDisabled      = err.ErrInvalidData.WithObjectCode(labAttackBoxObjectCode).WithDetailCode(2).WithMessage("Lab attack boxes require the ingress base domain")
+	ErrLabAttackBoxInvalidConfig = err.ErrInvalidData.WithObjectCode(labAttackBoxObjectCode).WithDetailCode(3).WithMessage("Lab attack box config is invalid")
+)
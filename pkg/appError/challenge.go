@@ -0,0 +1,15 @@
+package appError
+
+import "github.com/cybericebox/lib/pkg/err"
+
+var (
+	ErrLabChallengeQuotaExceeded     = err.ErrForbidden.WithObjectCode(labChallengeObjectCode).WithDetailCode(1).WithMessage("Lab resource quota exceeded")
+	ErrLabChallengeInvalidIP         = err.ErrInvalidData.WithObjectCode(labChallengeObjectCode).WithDetailCode(2).WithMessage("Instance ip is invalid")
+	ErrLabChallengeIPTaken           = err.ErrConflict.WithObjectCode(labChallengeObjectCode).WithDetailCode(3).WithMessage("Instance ip is already taken")
+	ErrLabChallengeInvalidPorts      = err.ErrInvalidData.WithObjectCode(labChallengeObjectCode).WithDetailCode(4).WithMessage("Instance ports are invalid")
+	ErrLabChallengePortsExhausted    = err.ErrConflict.WithObjectCode(labChallengeObjectCode).WithDetailCode(5).WithMessage("No free ports left in the published ports range")
+	ErrLabChallengeInvalidCapability = err.ErrInvalidData.WithObjectCode(labChallengeObjectCode).WithDetailCode(6).WithMessage("Instance capability is invalid")
+	ErrLabChallengeInvalidProbe      = err.ErrInvalidData.WithObjectCode(labChallengeObjectCode).WithDetailCode(7).WithMessage("Instance probe is invalid")
+	ErrLabChallengeInvalidContainer  = err.ErrInvalidData.WithObjectCode(labChallengeObjectCode).WithDetailCode(8).WithMessage("Instance container is invalid")
+	ErrLabChallengeInvalidResources  = err.ErrInvalidData.WithObjectCode(labChallengeObjectCode).WithDetailCode(9).WithMessage("Instance resources are invalid")
+)
@@ -11,6 +11,12 @@ const (
 	labObjectCode
 	labChallengeObjectCode
 	labDNSObjectCode
+	labTemplateObjectCode
+	labGroupObjectCode
+	cidrPoolObjectCode
+	labVPNObjectCode
+	labSSHObjectCode
+	labAttackBoxObjectCode
 )
 
 // base object errors
@@ -0,0 +1,20 @@
+package appError
+
+import "github.com/cybericebox/lib/pkg/err"
+
+var (
+	ErrLabGroup = err.ErrInternal.WithObjectCode(labGroupObjectCode)
+
+	ErrLabGroupNotFound           = err.ErrObjectNotFound.WithObjectCode(labGroupObjectCode).WithDetailCode(1).WithMessage("Labs group not found")
+	ErrLabGroupNameRequired       = err.ErrInvalidData.WithObjectCode(labGroupObjectCode).WithDetailCode(2).WithMessage("Labs group name is required")
+	ErrLabGroupInvalidLabel       = err.ErrInvalidData.WithObjectCode(labGroupObjectCode).WithDetailCode(3).WithMessage("Labs group label is invalid")
+	ErrLabGroupInvalidEventWindow = err.ErrInvalidData.WithObjectCode(labGroupObjectCode).WithDetailCode(4).WithMessage("Labs group event must end after it starts")
+	ErrLabGroupMaxLabsExceeded    = err.ErrForbidden.WithObjectCode(labGroupObjectCode).WithDetailCode(5).WithMessage("Labs group max labs count exceeded")
+	ErrLabGroupEventNotStarted    = err.ErrForbidden.WithObjectCode(labGroupObjectCode).WithDetailCode(6).WithMessage("Labs group event has not started yet")
+	ErrLabGroupEventEnded         = err.ErrForbidden.WithObjectCode(labGroupObjectCode).WithDetailCode(7).WithMessage("Labs group event has already ended")
+	ErrLabGroupHasLabs            = err.ErrConflict.WithObjectCode(labGroupObjectCode).WithDetailCode(8).WithMessage("Labs group still has labs")
+
+	ErrLabGroupInvalidIsolationMode = err.ErrInvalidData.WithObjectCode(labGroupObjectCode).WithDetailCode(9).WithMessage("Labs group isolation mode is invalid")
+	ErrLabGroupPrivilegedNotAllowed = err.ErrForbidden.WithObjectCode(labGroupObjectCode).WithDetailCode(10).WithMessage("Labs group does not allow the privileged instances")
+	ErrLabGroupInvalidOvercommit    = err.ErrInvalidData.WithObjectCode(labGroupObjectCode).WithDetailCode(11).WithMessage("Labs group overcommit ratio is invalid")
+)
@@ -0,0 +1,18 @@
+package appError
+
+import "github.com/cybericebox/lib/pkg/err"
+
+var (
+	ErrLabNotFound        = err.ErrObjectNotFound.WithObjectCode(labObjectCode).WithDetailCode(1).WithMessage("Lab not found")
+	ErrLabInvalidSelector = err.ErrInvalidData.WithObjectCode(labObjectCode).WithDetailCode(2).WithMessage("Lab selector is invalid")
+	ErrLabInvalidMetadata = err.ErrInvalidData.WithObjectCode(labObjectCode).WithDetailCode(3).WithMessage("Lab metadata is invalid")
+	ErrLabQuotaExceeded   = err.ErrForbidden.WithObjectCode(labObjectCode).WithDetailCode(4).WithMessage("Lab usage exceeds the resource quota")
+
+	ErrLabInvalidIsolationMode = err.ErrInvalidData.WithObjectCode(labObjectCode).WithDetailCode(5).WithMessage("Lab isolation mode is invalid")
+	ErrLabInvalidEgressProfile = err.ErrInvalidData.WithObjectCode(labObjectCode).WithDetailCode(6).WithMessage("Lab egress profile is invalid")
+	ErrLabInvalidSegment       = err.ErrInvalidData.WithObjectCode(labObjectCode).WithDetailCode(7).WithMessage("Lab segment is invalid")
+	ErrLabInvalidIPv6Network   = err.ErrInvalidData.WithObjectCode(labObjectCode).WithDetailCode(8).WithMessage("Lab IPv6 network is invalid")
+	ErrLabInvalidCIDRs         = err.ErrInvalidData.WithObjectCode(labObjectCode).WithDetailCode(9).WithMessage("Lab CIDRs do not match the labs count")
+	ErrLabInvalidCIDRMask      = err.ErrInvalidData.WithObjectCode(labObjectCode).WithDetailCode(10).WithMessage("Lab network mask is invalid")
+	ErrLabReservedIPTaken      = err.ErrConflict.WithObjectCode(labObjectCode).WithDetailCode(11).WithMessage("Lab reserved ip is already taken")
+)
@@ -0,0 +1,15 @@
+package appError
+
+import "github.com/cybericebox/lib/pkg/err"
+
+var (
+	ErrCIDRPool = err.ErrInternal.WithObjectCode(cidrPoolObjectCode)
+
+	ErrCIDRPoolNotFound     = err.ErrObjectNotFound.WithObjectCode(cidrPoolObjectCode).WithDetailCode(1).WithMessage("CIDR pool not found")
+	ErrCIDRPoolInvalidName  = err.ErrInvalidData.WithObjectCode(cidrPoolObjectCode).WithDetailCode(2).WithMessage("CIDR pool name is invalid")
+	ErrCIDRPoolInvalidCIDR  = err.ErrInvalidData.WithObjectCode(cidrPoolObjectCode).WithDetailCode(3).WithMessage("CIDR pool CIDR is invalid")
+	ErrCIDRPoolAlreadyExist = err.ErrConflict.WithObjectCode(cidrPoolObjectCode).WithDetailCode(4).WithMessage("CIDR pool already exists")
+	ErrCIDRPoolOverlaps     = err.ErrConflict.WithObjectCode(cidrPoolObjectCode).WithDetailCode(5).WithMessage("CIDR pool overlaps with another pool")
+	ErrCIDRPoolCIDRTaken    = err.ErrConflict.WithObjectCode(cidrPoolObjectCode).WithDetailCode(6).WithMessage("Requested CIDR is already taken")
+	ErrCIDRPoolIPTaken      = err.ErrConflict.WithObjectCode(cidrPoolObjectCode).WithDetailCode(7).WithMessage("Requested IP is already taken")
+)
@@ -0,0 +1,13 @@
+package appError
+
+import "github.com/cybericebox/lib/pkg/err"
+
+var (
+	ErrLabSSH = err.ErrInternal.WithObjectCode(labSSHObjectCode)
+
+	ErrLabSSHNotEnabled       = err.ErrForbidden.WithObjectCode(labSSHObjectCode).WithDetailCode(1).WithMessage("Lab has no SSH bastion")
+	ErrLabSSHDisabled         = err.ErrInvalidData.WithObjectCode(labSSHObjectCode).WithDetailCode(2).WithMessage("Lab SSH bastions are disabled")
+	ErrLabSSHInvalidPlayer    = err.ErrInvalidData.WithObjectCode(labSSHObjectCode).WithDetailCode(3).WithMessage("Lab SSH player id is invalid")
+	ErrLabSSHInvalidPublicKey = err.ErrInvalidData.WithObjectCode(labSSHObjectCode).WithDetailCode(4).WithMessage("Lab SSH public key is invalid")
+	ErrLabSSHKeyNotFound      = err.ErrObjectNotFound.WithObjectCode(labSSHObjectCode).WithDetailCode(5).WithMessage("Lab SSH player key not found")
+)
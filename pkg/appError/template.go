@@ -0,0 +1,11 @@
+package appError
+
+import "github.com/cybericebox/lib/pkg/err"
+
+var (
+	ErrLabTemplate = err.ErrInternal.WithObjectCode(labTemplateObjectCode)
+
+	ErrLabTemplateNotFound     = err.ErrObjectNotFound.WithObjectCode(labTemplateObjectCode).WithDetailCode(1).WithMessage("Lab template not found")
+	ErrLabTemplateNameRequired = err.ErrInvalidData.WithObjectCode(labTemplateObjectCode).WithDetailCode(2).WithMessage("Lab template name is required")
+	ErrLabTemplateVersionTaken = err.ErrConflict.WithObjectCode(labTemplateObjectCode).WithDetailCode(3).WithMessage("Lab template version is taken by the concurrent creation")
+)
@@ -0,0 +1,14 @@
+package appError
+
+import "github.com/cybericebox/lib/pkg/err"
+
+var (
+	ErrLabVPN = err.ErrInternal.WithObjectCode(labVPNObjectCode)
+
+	ErrLabVPNNotEnabled       = err.ErrForbidden.WithObjectCode(labVPNObjectCode).WithDetailCode(1).WithMessage("Lab has no VPN gateway")
+	ErrLabVPNInvalidGateway   = err.ErrInvalidData.WithObjectCode(labVPNObjectCode).WithDetailCode(2).WithMessage("Lab VPN gateway type is invalid")
+	ErrLabVPNInvalidPeer      = err.ErrInvalidData.WithObjectCode(labVPNObjectCode).WithDetailCode(3).WithMessage("Lab VPN peer id is invalid")
+	ErrLabVPNPeerNotFound     = err.ErrObjectNotFound.WithObjectCode(labVPNObjectCode).WithDetailCode(4).WithMessage("Lab VPN peer not found")
+	ErrLabVPNPeersExhausted   = err.ErrForbidden.WithObjectCode(labVPNObjectCode).WithDetailCode(5).WithMessage("Lab VPN has no free peer addresses")
+	ErrLabVPNEndpointNotReady = err.ErrInternal.WithObjectCode(labVPNObjectCode).WithDetailCode(6).WithMessage("Lab VPN gateway endpoint is not ready")
+)
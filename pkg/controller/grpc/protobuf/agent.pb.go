@@ -101,9 +101,29 @@ type CreateLabsRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CIDRMask    uint32 `protobuf:"varint,1,opt,name=CIDRMask,proto3" json:"CIDRMask,omitempty"`
-	Count       uint32 `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
-	LabsGroupID string `protobuf:"bytes,3,opt,name=LabsGroupID,proto3" json:"LabsGroupID,omitempty"`
+	CIDRMask         uint32                     `protobuf:"varint,1,opt,name=CIDRMask,proto3" json:"CIDRMask,omitempty"`
+	Count            uint32                     `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+	LabsGroupID      string                     `protobuf:"bytes,3,opt,name=LabsGroupID,proto3" json:"LabsGroupID,omitempty"`
+	TemplateID       string                     `protobuf:"bytes,4,opt,name=TemplateID,proto3" json:"TemplateID,omitempty"`
+	FlagEnvVariables []*TemplateFlagEnvVariable `protobuf:"bytes,5,rep,name=FlagEnvVariables,proto3" json:"FlagEnvVariables,omitempty"`
+	Quota            *ResourceQuota             `protobuf:"bytes,6,opt,name=Quota,proto3" json:"Quota,omitempty"`
+	Metadata         map[string]string          `protobuf:"bytes,7,rep,name=Metadata,proto3" json:"Metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// 0 - labs group default, 1 - isolated, 2 - shared with the labs of the same group, 3 - reachable from the admin namespace
+	IsolationMode int32          `protobuf:"varint,8,opt,name=IsolationMode,proto3" json:"IsolationMode,omitempty"`
+	Egress        *EgressProfile `protobuf:"bytes,9,opt,name=Egress,proto3" json:"Egress,omitempty"`
+	Segments      []*LabSegment  `protobuf:"bytes,10,rep,name=Segments,proto3" json:"Segments,omitempty"`
+	// 0 - the lab has no IPv6 network
+	IPv6CIDRMask uint32 `protobuf:"varint,11,opt,name=IPv6CIDRMask,proto3" json:"IPv6CIDRMask,omitempty"`
+	// empty value means the labs group pool or the default one
+	CIDRPool string `protobuf:"bytes,12,opt,name=CIDRPool,proto3" json:"CIDRPool,omitempty"`
+	// exact lab networks, one per lab, they replace the CIDRMask and set the count if it is 0
+	CIDRs []string `protobuf:"bytes,13,rep,name=CIDRs,proto3" json:"CIDRs,omitempty"`
+	// 0 - the lab has no VPN gateway, 1 - NodePort gateway, 2 - LoadBalancer gateway
+	VPNGateway int32 `protobuf:"varint,14,opt,name=VPNGateway,proto3" json:"VPNGateway,omitempty"`
+	// deploys the lab SSH bastion reached through the shared SSH router
+	SSHBastion bool `protobuf:"varint,15,opt,name=SSHBastion,proto3" json:"SSHBastion,omitempty"`
+	// deploys the lab attack box reached by the authenticated lab URL
+	AttackBox *AttackBox `protobuf:"bytes,16,opt,name=AttackBox,proto3" json:"AttackBox,omitempty"`
 }
 
 func (x *CreateLabsRequest) Reset() {
@@ -159,17 +179,111 @@ func (x *CreateLabsRequest) GetLabsGroupID() string {
 	return ""
 }
 
-type LabsRequest struct {
+func (x *CreateLabsRequest) GetTemplateID() string {
+	if x != nil {
+		return x.TemplateID
+	}
+	return ""
+}
+
+func (x *CreateLabsRequest) GetFlagEnvVariables() []*TemplateFlagEnvVariable {
+	if x != nil {
+		return x.FlagEnvVariables
+	}
+	return nil
+}
+
+func (x *CreateLabsRequest) GetQuota() *ResourceQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *CreateLabsRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateLabsRequest) GetIsolationMode() int32 {
+	if x != nil {
+		return x.IsolationMode
+	}
+	return 0
+}
+
+func (x *CreateLabsRequest) GetEgress() *EgressProfile {
+	if x != nil {
+		return x.Egress
+	}
+	return nil
+}
+
+func (x *CreateLabsRequest) GetSegments() []*LabSegment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+func (x *CreateLabsRequest) GetIPv6CIDRMask() uint32 {
+	if x != nil {
+		return x.IPv6CIDRMask
+	}
+	return 0
+}
+
+func (x *CreateLabsRequest) GetCIDRPool() string {
+	if x != nil {
+		return x.CIDRPool
+	}
+	return ""
+}
+
+func (x *CreateLabsRequest) GetCIDRs() []string {
+	if x != nil {
+		return x.CIDRs
+	}
+	return nil
+}
+
+func (x *CreateLabsRequest) GetVPNGateway() int32 {
+	if x != nil {
+		return x.VPNGateway
+	}
+	return 0
+}
+
+func (x *CreateLabsRequest) GetSSHBastion() bool {
+	if x != nil {
+		return x.SSHBastion
+	}
+	return false
+}
+
+func (x *CreateLabsRequest) GetAttackBox() *AttackBox {
+	if x != nil {
+		return x.AttackBox
+	}
+	return nil
+}
+
+type AttackBox struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IDs         []string `protobuf:"bytes,1,rep,name=IDs,proto3" json:"IDs,omitempty"`
-	LabsGroupID string   `protobuf:"bytes,2,opt,name=LabsGroupID,proto3" json:"LabsGroupID,omitempty"`
+	// zero values are the platform defaults
+	Image string `protobuf:"bytes,1,opt,name=Image,proto3" json:"Image,omitempty"`
+	// web port of the attack box front end
+	Port      int32      `protobuf:"varint,2,opt,name=Port,proto3" json:"Port,omitempty"`
+	Resources *Resources `protobuf:"bytes,3,opt,name=Resources,proto3" json:"Resources,omitempty"`
 }
 
-func (x *LabsRequest) Reset() {
-	*x = LabsRequest{}
+func (x *AttackBox) Reset() {
+	*x = AttackBox{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -177,13 +291,13 @@ func (x *LabsRequest) Reset() {
 	}
 }
 
-func (x *LabsRequest) String() string {
+func (x *AttackBox) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LabsRequest) ProtoMessage() {}
+func (*AttackBox) ProtoMessage() {}
 
-func (x *LabsRequest) ProtoReflect() protoreflect.Message {
+func (x *AttackBox) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -195,38 +309,47 @@ func (x *LabsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LabsRequest.ProtoReflect.Descriptor instead.
-func (*LabsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AttackBox.ProtoReflect.Descriptor instead.
+func (*AttackBox) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *LabsRequest) GetIDs() []string {
+func (x *AttackBox) GetImage() string {
 	if x != nil {
-		return x.IDs
+		return x.Image
 	}
-	return nil
+	return ""
 }
 
-func (x *LabsRequest) GetLabsGroupID() string {
+func (x *AttackBox) GetPort() int32 {
 	if x != nil {
-		return x.LabsGroupID
+		return x.Port
 	}
-	return ""
+	return 0
 }
 
-type AddLabsChallengesRequest struct {
+func (x *AttackBox) GetResources() *Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+type LabSegment struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LabIDs           []string           `protobuf:"bytes,1,rep,name=LabIDs,proto3" json:"LabIDs,omitempty"`
-	LabsGroupID      string             `protobuf:"bytes,2,opt,name=LabsGroupID,proto3" json:"LabsGroupID,omitempty"`
-	Challenges       []*Challenge       `protobuf:"bytes,3,rep,name=Challenges,proto3" json:"Challenges,omitempty"`
-	FlagEnvVariables []*FlagEnvVariable `protobuf:"bytes,4,rep,name=FlagEnvVariables,proto3" json:"FlagEnvVariables,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	// subnet mask of the segment, 0 - the lab one
+	CIDRMask uint32 `protobuf:"varint,2,opt,name=CIDRMask,proto3" json:"CIDRMask,omitempty"`
+	CIDR     string `protobuf:"bytes,3,opt,name=CIDR,proto3" json:"CIDR,omitempty"`
+	// segments whose members can reach the segment members, "default" - the lab pods without segments
+	AllowFrom []string `protobuf:"bytes,4,rep,name=AllowFrom,proto3" json:"AllowFrom,omitempty"`
 }
 
-func (x *AddLabsChallengesRequest) Reset() {
-	*x = AddLabsChallengesRequest{}
+func (x *LabSegment) Reset() {
+	*x = LabSegment{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -234,13 +357,13 @@ func (x *AddLabsChallengesRequest) Reset() {
 	}
 }
 
-func (x *AddLabsChallengesRequest) String() string {
+func (x *LabSegment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddLabsChallengesRequest) ProtoMessage() {}
+func (*LabSegment) ProtoMessage() {}
 
-func (x *AddLabsChallengesRequest) ProtoReflect() protoreflect.Message {
+func (x *LabSegment) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -252,51 +375,52 @@ func (x *AddLabsChallengesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddLabsChallengesRequest.ProtoReflect.Descriptor instead.
-func (*AddLabsChallengesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LabSegment.ProtoReflect.Descriptor instead.
+func (*LabSegment) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *AddLabsChallengesRequest) GetLabIDs() []string {
+func (x *LabSegment) GetName() string {
 	if x != nil {
-		return x.LabIDs
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *AddLabsChallengesRequest) GetLabsGroupID() string {
+func (x *LabSegment) GetCIDRMask() uint32 {
 	if x != nil {
-		return x.LabsGroupID
+		return x.CIDRMask
 	}
-	return ""
+	return 0
 }
 
-func (x *AddLabsChallengesRequest) GetChallenges() []*Challenge {
+func (x *LabSegment) GetCIDR() string {
 	if x != nil {
-		return x.Challenges
+		return x.CIDR
 	}
-	return nil
+	return ""
 }
 
-func (x *AddLabsChallengesRequest) GetFlagEnvVariables() []*FlagEnvVariable {
+func (x *LabSegment) GetAllowFrom() []string {
 	if x != nil {
-		return x.FlagEnvVariables
+		return x.AllowFrom
 	}
 	return nil
 }
 
-type LabsChallengesRequest struct {
+type LabsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LabIDs       []string `protobuf:"bytes,1,rep,name=LabIDs,proto3" json:"LabIDs,omitempty"`
-	LabsGroupID  string   `protobuf:"bytes,2,opt,name=LabsGroupID,proto3" json:"LabsGroupID,omitempty"`
-	ChallengeIDs []string `protobuf:"bytes,3,rep,name=ChallengeIDs,proto3" json:"ChallengeIDs,omitempty"`
+	IDs         []string `protobuf:"bytes,1,rep,name=IDs,proto3" json:"IDs,omitempty"`
+	LabsGroupID string   `protobuf:"bytes,2,opt,name=LabsGroupID,proto3" json:"LabsGroupID,omitempty"`
+	// label selector matched against the lab metadata, e.g. "track=advanced,team!=red"
+	Selector string `protobuf:"bytes,3,opt,name=Selector,proto3" json:"Selector,omitempty"`
 }
 
-func (x *LabsChallengesRequest) Reset() {
-	*x = LabsChallengesRequest{}
+func (x *LabsRequest) Reset() {
+	*x = LabsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -304,13 +428,13 @@ func (x *LabsChallengesRequest) Reset() {
 	}
 }
 
-func (x *LabsChallengesRequest) String() string {
+func (x *LabsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LabsChallengesRequest) ProtoMessage() {}
+func (*LabsRequest) ProtoMessage() {}
 
-func (x *LabsChallengesRequest) ProtoReflect() protoreflect.Message {
+func (x *LabsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -322,42 +446,43 @@ func (x *LabsChallengesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LabsChallengesRequest.ProtoReflect.Descriptor instead.
-func (*LabsChallengesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LabsRequest.ProtoReflect.Descriptor instead.
+func (*LabsRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *LabsChallengesRequest) GetLabIDs() []string {
+func (x *LabsRequest) GetIDs() []string {
 	if x != nil {
-		return x.LabIDs
+		return x.IDs
 	}
 	return nil
 }
 
-func (x *LabsChallengesRequest) GetLabsGroupID() string {
+func (x *LabsRequest) GetLabsGroupID() string {
 	if x != nil {
 		return x.LabsGroupID
 	}
 	return ""
 }
 
-func (x *LabsChallengesRequest) GetChallengeIDs() []string {
+func (x *LabsRequest) GetSelector() string {
 	if x != nil {
-		return x.ChallengeIDs
+		return x.Selector
 	}
-	return nil
+	return ""
 }
 
-type CreateLabsResponse struct {
+type UpdateLabMetadataRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Labs []*Lab `protobuf:"bytes,1,rep,name=Labs,proto3" json:"Labs,omitempty"`
+	LabID    string            `protobuf:"bytes,1,opt,name=LabID,proto3" json:"LabID,omitempty"`
+	Metadata map[string]string `protobuf:"bytes,2,rep,name=Metadata,proto3" json:"Metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *CreateLabsResponse) Reset() {
-	*x = CreateLabsResponse{}
+func (x *UpdateLabMetadataRequest) Reset() {
+	*x = UpdateLabMetadataRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -365,13 +490,13 @@ func (x *CreateLabsResponse) Reset() {
 	}
 }
 
-func (x *CreateLabsResponse) String() string {
+func (x *UpdateLabMetadataRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateLabsResponse) ProtoMessage() {}
+func (*UpdateLabMetadataRequest) ProtoMessage() {}
 
-func (x *CreateLabsResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateLabMetadataRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -383,28 +508,38 @@ func (x *CreateLabsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateLabsResponse.ProtoReflect.Descriptor instead.
-func (*CreateLabsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateLabMetadataRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLabMetadataRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CreateLabsResponse) GetLabs() []*Lab {
+func (x *UpdateLabMetadataRequest) GetLabID() string {
 	if x != nil {
-		return x.Labs
+		return x.LabID
+	}
+	return ""
+}
+
+func (x *UpdateLabMetadataRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
 	}
 	return nil
 }
 
-type GetLabsResponse struct {
+type MoveLabsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Labs []*Lab `protobuf:"bytes,1,rep,name=Labs,proto3" json:"Labs,omitempty"`
+	IDs               []string `protobuf:"bytes,1,rep,name=IDs,proto3" json:"IDs,omitempty"`
+	LabsGroupID       string   `protobuf:"bytes,2,opt,name=LabsGroupID,proto3" json:"LabsGroupID,omitempty"`
+	Selector          string   `protobuf:"bytes,3,opt,name=Selector,proto3" json:"Selector,omitempty"`
+	TargetLabsGroupID string   `protobuf:"bytes,4,opt,name=TargetLabsGroupID,proto3" json:"TargetLabsGroupID,omitempty"`
 }
 
-func (x *GetLabsResponse) Reset() {
-	*x = GetLabsResponse{}
+func (x *MoveLabsRequest) Reset() {
+	*x = MoveLabsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -412,13 +547,13 @@ func (x *GetLabsResponse) Reset() {
 	}
 }
 
-func (x *GetLabsResponse) String() string {
+func (x *MoveLabsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLabsResponse) ProtoMessage() {}
+func (*MoveLabsRequest) ProtoMessage() {}
 
-func (x *GetLabsResponse) ProtoReflect() protoreflect.Message {
+func (x *MoveLabsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -430,28 +565,52 @@ func (x *GetLabsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLabsResponse.ProtoReflect.Descriptor instead.
-func (*GetLabsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use MoveLabsRequest.ProtoReflect.Descriptor instead.
+func (*MoveLabsRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetLabsResponse) GetLabs() []*Lab {
+func (x *MoveLabsRequest) GetIDs() []string {
 	if x != nil {
-		return x.Labs
+		return x.IDs
 	}
 	return nil
 }
 
-type MonitoringResponse struct {
+func (x *MoveLabsRequest) GetLabsGroupID() string {
+	if x != nil {
+		return x.LabsGroupID
+	}
+	return ""
+}
+
+func (x *MoveLabsRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *MoveLabsRequest) GetTargetLabsGroupID() string {
+	if x != nil {
+		return x.TargetLabsGroupID
+	}
+	return ""
+}
+
+type SetLabsIsolationModeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Labs []*LabStatus `protobuf:"bytes,1,rep,name=Labs,proto3" json:"Labs,omitempty"`
+	IDs           []string `protobuf:"bytes,1,rep,name=IDs,proto3" json:"IDs,omitempty"`
+	LabsGroupID   string   `protobuf:"bytes,2,opt,name=LabsGroupID,proto3" json:"LabsGroupID,omitempty"`
+	Selector      string   `protobuf:"bytes,3,opt,name=Selector,proto3" json:"Selector,omitempty"`
+	IsolationMode int32    `protobuf:"varint,4,opt,name=IsolationMode,proto3" json:"IsolationMode,omitempty"`
 }
 
-func (x *MonitoringResponse) Reset() {
-	*x = MonitoringResponse{}
+func (x *SetLabsIsolationModeRequest) Reset() {
+	*x = SetLabsIsolationModeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -459,13 +618,13 @@ func (x *MonitoringResponse) Reset() {
 	}
 }
 
-func (x *MonitoringResponse) String() string {
+func (x *SetLabsIsolationModeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MonitoringResponse) ProtoMessage() {}
+func (*SetLabsIsolationModeRequest) ProtoMessage() {}
 
-func (x *MonitoringResponse) ProtoReflect() protoreflect.Message {
+func (x *SetLabsIsolationModeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -477,30 +636,52 @@ func (x *MonitoringResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MonitoringResponse.ProtoReflect.Descriptor instead.
-func (*MonitoringResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetLabsIsolationModeRequest.ProtoReflect.Descriptor instead.
+func (*SetLabsIsolationModeRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *MonitoringResponse) GetLabs() []*LabStatus {
+func (x *SetLabsIsolationModeRequest) GetIDs() []string {
 	if x != nil {
-		return x.Labs
+		return x.IDs
 	}
 	return nil
 }
 
-type Lab struct {
+func (x *SetLabsIsolationModeRequest) GetLabsGroupID() string {
+	if x != nil {
+		return x.LabsGroupID
+	}
+	return ""
+}
+
+func (x *SetLabsIsolationModeRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *SetLabsIsolationModeRequest) GetIsolationMode() int32 {
+	if x != nil {
+		return x.IsolationMode
+	}
+	return 0
+}
+
+type SetLabsEgressProfileRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ID      string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	GroupID string `protobuf:"bytes,2,opt,name=GroupID,proto3" json:"GroupID,omitempty"`
-	CIDR    string `protobuf:"bytes,3,opt,name=CIDR,proto3" json:"CIDR,omitempty"`
+	IDs         []string       `protobuf:"bytes,1,rep,name=IDs,proto3" json:"IDs,omitempty"`
+	LabsGroupID string         `protobuf:"bytes,2,opt,name=LabsGroupID,proto3" json:"LabsGroupID,omitempty"`
+	Selector    string         `protobuf:"bytes,3,opt,name=Selector,proto3" json:"Selector,omitempty"`
+	Egress      *EgressProfile `protobuf:"bytes,4,opt,name=Egress,proto3" json:"Egress,omitempty"`
 }
 
-func (x *Lab) Reset() {
-	*x = Lab{}
+func (x *SetLabsEgressProfileRequest) Reset() {
+	*x = SetLabsEgressProfileRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -508,13 +689,13 @@ func (x *Lab) Reset() {
 	}
 }
 
-func (x *Lab) String() string {
+func (x *SetLabsEgressProfileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Lab) ProtoMessage() {}
+func (*SetLabsEgressProfileRequest) ProtoMessage() {}
 
-func (x *Lab) ProtoReflect() protoreflect.Message {
+func (x *SetLabsEgressProfileRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -526,46 +707,54 @@ func (x *Lab) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Lab.ProtoReflect.Descriptor instead.
-func (*Lab) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetLabsEgressProfileRequest.ProtoReflect.Descriptor instead.
+func (*SetLabsEgressProfileRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *Lab) GetID() string {
+func (x *SetLabsEgressProfileRequest) GetIDs() []string {
 	if x != nil {
-		return x.ID
+		return x.IDs
 	}
-	return ""
+	return nil
 }
 
-func (x *Lab) GetGroupID() string {
+func (x *SetLabsEgressProfileRequest) GetLabsGroupID() string {
 	if x != nil {
-		return x.GroupID
+		return x.LabsGroupID
 	}
 	return ""
 }
 
-func (x *Lab) GetCIDR() string {
+func (x *SetLabsEgressProfileRequest) GetSelector() string {
 	if x != nil {
-		return x.CIDR
+		return x.Selector
 	}
 	return ""
 }
 
-type LabStatus struct {
+func (x *SetLabsEgressProfileRequest) GetEgress() *EgressProfile {
+	if x != nil {
+		return x.Egress
+	}
+	return nil
+}
+
+type CheckLabsIPAMRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ID        string            `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	GroupID   string            `protobuf:"bytes,2,opt,name=GroupID,proto3" json:"GroupID,omitempty"`
-	CIDR      string            `protobuf:"bytes,3,opt,name=CIDR,proto3" json:"CIDR,omitempty"`
-	DNS       *DNSStatus        `protobuf:"bytes,4,opt,name=DNS,proto3" json:"DNS,omitempty"`
-	Instances []*InstanceStatus `protobuf:"bytes,5,rep,name=Instances,proto3" json:"Instances,omitempty"`
+	// no IDs, labs group and selector mean all the labs
+	IDs         []string `protobuf:"bytes,1,rep,name=IDs,proto3" json:"IDs,omitempty"`
+	LabsGroupID string   `protobuf:"bytes,2,opt,name=LabsGroupID,proto3" json:"LabsGroupID,omitempty"`
+	Selector    string   `protobuf:"bytes,3,opt,name=Selector,proto3" json:"Selector,omitempty"`
+	// releases the IPs held in IPAM without a deployment
+	ReleaseLeaked bool `protobuf:"varint,4,opt,name=ReleaseLeaked,proto3" json:"ReleaseLeaked,omitempty"`
 }
 
-func (x *LabStatus) Reset() {
-	*x = LabStatus{}
+func (x *CheckLabsIPAMRequest) Reset() {
+	*x = CheckLabsIPAMRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -573,13 +762,13 @@ func (x *LabStatus) Reset() {
 	}
 }
 
-func (x *LabStatus) String() string {
+func (x *CheckLabsIPAMRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LabStatus) ProtoMessage() {}
+func (*CheckLabsIPAMRequest) ProtoMessage() {}
 
-func (x *LabStatus) ProtoReflect() protoreflect.Message {
+func (x *CheckLabsIPAMRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -591,58 +780,51 @@ func (x *LabStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LabStatus.ProtoReflect.Descriptor instead.
-func (*LabStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckLabsIPAMRequest.ProtoReflect.Descriptor instead.
+func (*CheckLabsIPAMRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *LabStatus) GetID() string {
+func (x *CheckLabsIPAMRequest) GetIDs() []string {
 	if x != nil {
-		return x.ID
+		return x.IDs
 	}
-	return ""
+	return nil
 }
 
-func (x *LabStatus) GetGroupID() string {
+func (x *CheckLabsIPAMRequest) GetLabsGroupID() string {
 	if x != nil {
-		return x.GroupID
+		return x.LabsGroupID
 	}
 	return ""
 }
 
-func (x *LabStatus) GetCIDR() string {
+func (x *CheckLabsIPAMRequest) GetSelector() string {
 	if x != nil {
-		return x.CIDR
+		return x.Selector
 	}
 	return ""
 }
 
-func (x *LabStatus) GetDNS() *DNSStatus {
-	if x != nil {
-		return x.DNS
-	}
-	return nil
-}
-
-func (x *LabStatus) GetInstances() []*InstanceStatus {
+func (x *CheckLabsIPAMRequest) GetReleaseLeaked() bool {
 	if x != nil {
-		return x.Instances
+		return x.ReleaseLeaked
 	}
-	return nil
+	return false
 }
 
-type DNSStatus struct {
+type LabVPNPeerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status    int32      `protobuf:"varint,2,opt,name=Status,proto3" json:"Status,omitempty"`
-	Reason    string     `protobuf:"bytes,3,opt,name=Reason,proto3" json:"Reason,omitempty"`
-	Resources *Resources `protobuf:"bytes,4,opt,name=Resources,proto3" json:"Resources,omitempty"`
+	LabID string `protobuf:"bytes,1,opt,name=LabID,proto3" json:"LabID,omitempty"`
+	// player peer id, a DNS label
+	PeerID string `protobuf:"bytes,2,opt,name=PeerID,proto3" json:"PeerID,omitempty"`
 }
 
-func (x *DNSStatus) Reset() {
-	*x = DNSStatus{}
+func (x *LabVPNPeerRequest) Reset() {
+	*x = LabVPNPeerRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -650,13 +832,13 @@ func (x *DNSStatus) Reset() {
 	}
 }
 
-func (x *DNSStatus) String() string {
+func (x *LabVPNPeerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DNSStatus) ProtoMessage() {}
+func (*LabVPNPeerRequest) ProtoMessage() {}
 
-func (x *DNSStatus) ProtoReflect() protoreflect.Message {
+func (x *LabVPNPeerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -668,46 +850,39 @@ func (x *DNSStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DNSStatus.ProtoReflect.Descriptor instead.
-func (*DNSStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use LabVPNPeerRequest.ProtoReflect.Descriptor instead.
+func (*LabVPNPeerRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *DNSStatus) GetStatus() int32 {
-	if x != nil {
-		return x.Status
-	}
-	return 0
-}
-
-func (x *DNSStatus) GetReason() string {
+func (x *LabVPNPeerRequest) GetLabID() string {
 	if x != nil {
-		return x.Reason
+		return x.LabID
 	}
 	return ""
 }
 
-func (x *DNSStatus) GetResources() *Resources {
+func (x *LabVPNPeerRequest) GetPeerID() string {
 	if x != nil {
-		return x.Resources
+		return x.PeerID
 	}
-	return nil
+	return ""
 }
 
-type InstanceStatus struct {
+type LabSSHKeyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ID          string     `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	ChallengeID string     `protobuf:"bytes,2,opt,name=ChallengeID,proto3" json:"ChallengeID,omitempty"`
-	Status      int32      `protobuf:"varint,3,opt,name=Status,proto3" json:"Status,omitempty"`
-	Reason      string     `protobuf:"bytes,4,opt,name=Reason,proto3" json:"Reason,omitempty"`
-	Resources   *Resources `protobuf:"bytes,5,opt,name=Resources,proto3" json:"Resources,omitempty"`
+	LabID string `protobuf:"bytes,1,opt,name=LabID,proto3" json:"LabID,omitempty"`
+	// player id, a DNS label
+	PlayerID string `protobuf:"bytes,2,opt,name=PlayerID,proto3" json:"PlayerID,omitempty"`
+	// public key in the authorized keys format, the empty key of the remove request removes all player keys
+	PublicKey string `protobuf:"bytes,3,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
 }
 
-func (x *InstanceStatus) Reset() {
-	*x = InstanceStatus{}
+func (x *LabSSHKeyRequest) Reset() {
+	*x = LabSSHKeyRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -715,13 +890,13 @@ func (x *InstanceStatus) Reset() {
 	}
 }
 
-func (x *InstanceStatus) String() string {
+func (x *LabSSHKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InstanceStatus) ProtoMessage() {}
+func (*LabSSHKeyRequest) ProtoMessage() {}
 
-func (x *InstanceStatus) ProtoReflect() protoreflect.Message {
+func (x *LabSSHKeyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -733,57 +908,42 @@ func (x *InstanceStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InstanceStatus.ProtoReflect.Descriptor instead.
-func (*InstanceStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use LabSSHKeyRequest.ProtoReflect.Descriptor instead.
+func (*LabSSHKeyRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *InstanceStatus) GetID() string {
+func (x *LabSSHKeyRequest) GetLabID() string {
 	if x != nil {
-		return x.ID
+		return x.LabID
 	}
 	return ""
 }
 
-func (x *InstanceStatus) GetChallengeID() string {
+func (x *LabSSHKeyRequest) GetPlayerID() string {
 	if x != nil {
-		return x.ChallengeID
+		return x.PlayerID
 	}
 	return ""
 }
 
-func (x *InstanceStatus) GetStatus() int32 {
-	if x != nil {
-		return x.Status
-	}
-	return 0
-}
-
-func (x *InstanceStatus) GetReason() string {
+func (x *LabSSHKeyRequest) GetPublicKey() string {
 	if x != nil {
-		return x.Reason
+		return x.PublicKey
 	}
 	return ""
 }
 
-func (x *InstanceStatus) GetResources() *Resources {
-	if x != nil {
-		return x.Resources
-	}
-	return nil
-}
-
-type Challenge struct {
+type LabAttackBoxRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ID        string      `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Instances []*Instance `protobuf:"bytes,2,rep,name=Instances,proto3" json:"Instances,omitempty"`
+	LabID string `protobuf:"bytes,1,opt,name=LabID,proto3" json:"LabID,omitempty"`
 }
 
-func (x *Challenge) Reset() {
-	*x = Challenge{}
+func (x *LabAttackBoxRequest) Reset() {
+	*x = LabAttackBoxRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -791,13 +951,13 @@ func (x *Challenge) Reset() {
 	}
 }
 
-func (x *Challenge) String() string {
+func (x *LabAttackBoxRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Challenge) ProtoMessage() {}
+func (*LabAttackBoxRequest) ProtoMessage() {}
 
-func (x *Challenge) ProtoReflect() protoreflect.Message {
+func (x *LabAttackBoxRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -809,39 +969,31 @@ func (x *Challenge) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Challenge.ProtoReflect.Descriptor instead.
-func (*Challenge) Descriptor() ([]byte, []int) {
+// Deprecated: Use LabAttackBoxRequest.ProtoReflect.Descriptor instead.
+func (*LabAttackBoxRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *Challenge) GetID() string {
+func (x *LabAttackBoxRequest) GetLabID() string {
 	if x != nil {
-		return x.ID
+		return x.LabID
 	}
 	return ""
 }
 
-func (x *Challenge) GetInstances() []*Instance {
-	if x != nil {
-		return x.Instances
-	}
-	return nil
-}
-
-type Instance struct {
+type EgressProfile struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ID        string         `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Image     string         `protobuf:"bytes,2,opt,name=Image,proto3" json:"Image,omitempty"`
-	Resources *Resources     `protobuf:"bytes,3,opt,name=Resources,proto3" json:"Resources,omitempty"`
-	Envs      []*EnvVariable `protobuf:"bytes,4,rep,name=Envs,proto3" json:"Envs,omitempty"`
-	Records   []*DNSRecord   `protobuf:"bytes,5,rep,name=Records,proto3" json:"Records,omitempty"`
+	// 0 - internet for the lab and the lab profile for the instance, 1 - internet, 2 - no egress, 3 - allowed CIDRs, 4 - allowed domains
+	Mode    int32    `protobuf:"varint,1,opt,name=Mode,proto3" json:"Mode,omitempty"`
+	CIDRs   []string `protobuf:"bytes,2,rep,name=CIDRs,proto3" json:"CIDRs,omitempty"`
+	Domains []string `protobuf:"bytes,3,rep,name=Domains,proto3" json:"Domains,omitempty"`
 }
 
-func (x *Instance) Reset() {
-	*x = Instance{}
+func (x *EgressProfile) Reset() {
+	*x = EgressProfile{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -849,13 +1001,13 @@ func (x *Instance) Reset() {
 	}
 }
 
-func (x *Instance) String() string {
+func (x *EgressProfile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Instance) ProtoMessage() {}
+func (*EgressProfile) ProtoMessage() {}
 
-func (x *Instance) ProtoReflect() protoreflect.Message {
+func (x *EgressProfile) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -867,57 +1019,46 @@ func (x *Instance) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Instance.ProtoReflect.Descriptor instead.
-func (*Instance) Descriptor() ([]byte, []int) {
+// Deprecated: Use EgressProfile.ProtoReflect.Descriptor instead.
+func (*EgressProfile) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *Instance) GetID() string {
-	if x != nil {
-		return x.ID
-	}
-	return ""
-}
-
-func (x *Instance) GetImage() string {
-	if x != nil {
-		return x.Image
-	}
-	return ""
-}
-
-func (x *Instance) GetResources() *Resources {
+func (x *EgressProfile) GetMode() int32 {
 	if x != nil {
-		return x.Resources
+		return x.Mode
 	}
-	return nil
+	return 0
 }
 
-func (x *Instance) GetEnvs() []*EnvVariable {
+func (x *EgressProfile) GetCIDRs() []string {
 	if x != nil {
-		return x.Envs
+		return x.CIDRs
 	}
 	return nil
 }
 
-func (x *Instance) GetRecords() []*DNSRecord {
+func (x *EgressProfile) GetDomains() []string {
 	if x != nil {
-		return x.Records
+		return x.Domains
 	}
 	return nil
 }
 
-type Resources struct {
+type AddLabsChallengesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Memory int64 `protobuf:"varint,1,opt,name=Memory,proto3" json:"Memory,omitempty"`
-	CPU    int64 `protobuf:"varint,2,opt,name=CPU,proto3" json:"CPU,omitempty"`
+	LabIDs           []string           `protobuf:"bytes,1,rep,name=LabIDs,proto3" json:"LabIDs,omitempty"`
+	LabsGroupID      string             `protobuf:"bytes,2,opt,name=LabsGroupID,proto3" json:"LabsGroupID,omitempty"`
+	Challenges       []*Challenge       `protobuf:"bytes,3,rep,name=Challenges,proto3" json:"Challenges,omitempty"`
+	FlagEnvVariables []*FlagEnvVariable `protobuf:"bytes,4,rep,name=FlagEnvVariables,proto3" json:"FlagEnvVariables,omitempty"`
+	Selector         string             `protobuf:"bytes,5,opt,name=Selector,proto3" json:"Selector,omitempty"`
 }
 
-func (x *Resources) Reset() {
-	*x = Resources{}
+func (x *AddLabsChallengesRequest) Reset() {
+	*x = AddLabsChallengesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -925,13 +1066,13 @@ func (x *Resources) Reset() {
 	}
 }
 
-func (x *Resources) String() string {
+func (x *AddLabsChallengesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Resources) ProtoMessage() {}
+func (*AddLabsChallengesRequest) ProtoMessage() {}
 
-func (x *Resources) ProtoReflect() protoreflect.Message {
+func (x *AddLabsChallengesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -943,36 +1084,59 @@ func (x *Resources) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Resources.ProtoReflect.Descriptor instead.
-func (*Resources) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddLabsChallengesRequest.ProtoReflect.Descriptor instead.
+func (*AddLabsChallengesRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *Resources) GetMemory() int64 {
+func (x *AddLabsChallengesRequest) GetLabIDs() []string {
 	if x != nil {
-		return x.Memory
+		return x.LabIDs
 	}
-	return 0
+	return nil
 }
 
-func (x *Resources) GetCPU() int64 {
+func (x *AddLabsChallengesRequest) GetLabsGroupID() string {
 	if x != nil {
-		return x.CPU
+		return x.LabsGroupID
 	}
-	return 0
+	return ""
 }
 
-type EnvVariable struct {
+func (x *AddLabsChallengesRequest) GetChallenges() []*Challenge {
+	if x != nil {
+		return x.Challenges
+	}
+	return nil
+}
+
+func (x *AddLabsChallengesRequest) GetFlagEnvVariables() []*FlagEnvVariable {
+	if x != nil {
+		return x.FlagEnvVariables
+	}
+	return nil
+}
+
+func (x *AddLabsChallengesRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+type LabsChallengesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name  string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	Value string `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	LabIDs       []string `protobuf:"bytes,1,rep,name=LabIDs,proto3" json:"LabIDs,omitempty"`
+	LabsGroupID  string   `protobuf:"bytes,2,opt,name=LabsGroupID,proto3" json:"LabsGroupID,omitempty"`
+	ChallengeIDs []string `protobuf:"bytes,3,rep,name=ChallengeIDs,proto3" json:"ChallengeIDs,omitempty"`
+	Selector     string   `protobuf:"bytes,4,opt,name=Selector,proto3" json:"Selector,omitempty"`
 }
 
-func (x *EnvVariable) Reset() {
-	*x = EnvVariable{}
+func (x *LabsChallengesRequest) Reset() {
+	*x = LabsChallengesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -980,13 +1144,13 @@ func (x *EnvVariable) Reset() {
 	}
 }
 
-func (x *EnvVariable) String() string {
+func (x *LabsChallengesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EnvVariable) ProtoMessage() {}
+func (*LabsChallengesRequest) ProtoMessage() {}
 
-func (x *EnvVariable) ProtoReflect() protoreflect.Message {
+func (x *LabsChallengesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -998,39 +1162,49 @@ func (x *EnvVariable) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EnvVariable.ProtoReflect.Descriptor instead.
-func (*EnvVariable) Descriptor() ([]byte, []int) {
+// Deprecated: Use LabsChallengesRequest.ProtoReflect.Descriptor instead.
+func (*LabsChallengesRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *EnvVariable) GetName() string {
+func (x *LabsChallengesRequest) GetLabIDs() []string {
 	if x != nil {
-		return x.Name
+		return x.LabIDs
+	}
+	return nil
+}
+
+func (x *LabsChallengesRequest) GetLabsGroupID() string {
+	if x != nil {
+		return x.LabsGroupID
 	}
 	return ""
 }
 
-func (x *EnvVariable) GetValue() string {
+func (x *LabsChallengesRequest) GetChallengeIDs() []string {
 	if x != nil {
-		return x.Value
+		return x.ChallengeIDs
+	}
+	return nil
+}
+
+func (x *LabsChallengesRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
 	}
 	return ""
 }
 
-type FlagEnvVariable struct {
+type CreateLabsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LabID       string `protobuf:"bytes,1,opt,name=LabID,proto3" json:"LabID,omitempty"`
-	ChallengeID string `protobuf:"bytes,2,opt,name=ChallengeID,proto3" json:"ChallengeID,omitempty"`
-	InstanceID  string `protobuf:"bytes,3,opt,name=InstanceID,proto3" json:"InstanceID,omitempty"`
-	Variable    string `protobuf:"bytes,4,opt,name=Variable,proto3" json:"Variable,omitempty"`
-	Flag        string `protobuf:"bytes,5,opt,name=Flag,proto3" json:"Flag,omitempty"`
+	Labs []*Lab `protobuf:"bytes,1,rep,name=Labs,proto3" json:"Labs,omitempty"`
 }
 
-func (x *FlagEnvVariable) Reset() {
-	*x = FlagEnvVariable{}
+func (x *CreateLabsResponse) Reset() {
+	*x = CreateLabsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1038,13 +1212,13 @@ func (x *FlagEnvVariable) Reset() {
 	}
 }
 
-func (x *FlagEnvVariable) String() string {
+func (x *CreateLabsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FlagEnvVariable) ProtoMessage() {}
+func (*CreateLabsResponse) ProtoMessage() {}
 
-func (x *FlagEnvVariable) ProtoReflect() protoreflect.Message {
+func (x *CreateLabsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1056,73 +1230,197 @@ func (x *FlagEnvVariable) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FlagEnvVariable.ProtoReflect.Descriptor instead.
-func (*FlagEnvVariable) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateLabsResponse.ProtoReflect.Descriptor instead.
+func (*CreateLabsResponse) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *FlagEnvVariable) GetLabID() string {
+func (x *CreateLabsResponse) GetLabs() []*Lab {
 	if x != nil {
-		return x.LabID
+		return x.Labs
 	}
-	return ""
+	return nil
 }
 
-func (x *FlagEnvVariable) GetChallengeID() string {
+type GetLabsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Labs []*Lab `protobuf:"bytes,1,rep,name=Labs,proto3" json:"Labs,omitempty"`
+}
+
+func (x *GetLabsResponse) Reset() {
+	*x = GetLabsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLabsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLabsResponse) ProtoMessage() {}
+
+func (x *GetLabsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLabsResponse.ProtoReflect.Descriptor instead.
+func (*GetLabsResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetLabsResponse) GetLabs() []*Lab {
 	if x != nil {
-		return x.ChallengeID
+		return x.Labs
 	}
-	return ""
+	return nil
 }
 
-func (x *FlagEnvVariable) GetInstanceID() string {
+type LabVPNPeerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// WireGuard client config with the new peer keys
+	ClientConfig string `protobuf:"bytes,1,opt,name=ClientConfig,proto3" json:"ClientConfig,omitempty"`
+}
+
+func (x *LabVPNPeerResponse) Reset() {
+	*x = LabVPNPeerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabVPNPeerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabVPNPeerResponse) ProtoMessage() {}
+
+func (x *LabVPNPeerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabVPNPeerResponse.ProtoReflect.Descriptor instead.
+func (*LabVPNPeerResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LabVPNPeerResponse) GetClientConfig() string {
 	if x != nil {
-		return x.InstanceID
+		return x.ClientConfig
 	}
 	return ""
 }
 
-func (x *FlagEnvVariable) GetVariable() string {
+type LabSSHKeyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// login of the player on the shared SSH router
+	Username string `protobuf:"bytes,1,opt,name=Username,proto3" json:"Username,omitempty"`
+	Endpoint string `protobuf:"bytes,2,opt,name=Endpoint,proto3" json:"Endpoint,omitempty"`
+}
+
+func (x *LabSSHKeyResponse) Reset() {
+	*x = LabSSHKeyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabSSHKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabSSHKeyResponse) ProtoMessage() {}
+
+func (x *LabSSHKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabSSHKeyResponse.ProtoReflect.Descriptor instead.
+func (*LabSSHKeyResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LabSSHKeyResponse) GetUsername() string {
 	if x != nil {
-		return x.Variable
+		return x.Username
 	}
 	return ""
 }
 
-func (x *FlagEnvVariable) GetFlag() string {
+func (x *LabSSHKeyResponse) GetEndpoint() string {
 	if x != nil {
-		return x.Flag
+		return x.Endpoint
 	}
 	return ""
 }
 
-type DNSRecord struct {
+type LabAttackBoxAccessResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type string `protobuf:"bytes,1,opt,name=Type,proto3" json:"Type,omitempty"`
-	Name string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
-	Data string `protobuf:"bytes,3,opt,name=Data,proto3" json:"Data,omitempty"`
+	URL      string `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=Username,proto3" json:"Username,omitempty"`
+	Password string `protobuf:"bytes,3,opt,name=Password,proto3" json:"Password,omitempty"`
+	Status   int32  `protobuf:"varint,4,opt,name=Status,proto3" json:"Status,omitempty"`
 }
 
-func (x *DNSRecord) Reset() {
-	*x = DNSRecord{}
+func (x *LabAttackBoxAccessResponse) Reset() {
+	*x = LabAttackBoxAccessResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[18]
+		mi := &file_agent_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DNSRecord) String() string {
+func (x *LabAttackBoxAccessResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DNSRecord) ProtoMessage() {}
+func (*LabAttackBoxAccessResponse) ProtoMessage() {}
 
-func (x *DNSRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[18]
+func (x *LabAttackBoxAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1133,294 +1431,4315 @@ func (x *DNSRecord) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DNSRecord.ProtoReflect.Descriptor instead.
-func (*DNSRecord) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use LabAttackBoxAccessResponse.ProtoReflect.Descriptor instead.
+func (*LabAttackBoxAccessResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *DNSRecord) GetType() string {
+func (x *LabAttackBoxAccessResponse) GetURL() string {
 	if x != nil {
-		return x.Type
+		return x.URL
 	}
 	return ""
 }
 
-func (x *DNSRecord) GetName() string {
+func (x *LabAttackBoxAccessResponse) GetUsername() string {
 	if x != nil {
-		return x.Name
+		return x.Username
 	}
 	return ""
 }
 
-func (x *DNSRecord) GetData() string {
+func (x *LabAttackBoxAccessResponse) GetPassword() string {
 	if x != nil {
-		return x.Data
+		return x.Password
 	}
 	return ""
 }
 
-var File_agent_proto protoreflect.FileDescriptor
+func (x *LabAttackBoxAccessResponse) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
 
-var file_agent_proto_rawDesc = []byte{
-	0x0a, 0x0b, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x61,
-	0x67, 0x65, 0x6e, 0x74, 0x22, 0x0e, 0x0a, 0x0c, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x22, 0x0f, 0x0a, 0x0d, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x67, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c,
-	0x61, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x49,
-	0x44, 0x52, 0x4d, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x43, 0x49,
-	0x44, 0x52, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b,
-	0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x22, 0x41,
-	0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
-	0x03, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x49, 0x44, 0x73, 0x12,
-	0x20, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49,
-	0x44, 0x22, 0xca, 0x01, 0x0a, 0x18, 0x41, 0x64, 0x64, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61,
-	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x4c, 0x61, 0x62, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
-	0x4c, 0x61, 0x62, 0x49, 0x44, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x61, 0x62,
-	0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x12, 0x30, 0x0a, 0x0a, 0x43, 0x68, 0x61, 0x6c,
-	0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61,
-	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x0a,
-	0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x10, 0x46, 0x6c,
-	0x61, 0x67, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x46, 0x6c, 0x61,
-	0x67, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x10, 0x46, 0x6c,
-	0x61, 0x67, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x22, 0x75,
-	0x0a, 0x15, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x49, 0x44,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x49, 0x44, 0x73, 0x12,
-	0x20, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49,
-	0x44, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x44,
-	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
-	0x67, 0x65, 0x49, 0x44, 0x73, 0x22, 0x34, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c,
-	0x61, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x04, 0x4c,
-	0x61, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x67, 0x65, 0x6e,
-	0x74, 0x2e, 0x4c, 0x61, 0x62, 0x52, 0x04, 0x4c, 0x61, 0x62, 0x73, 0x22, 0x31, 0x0a, 0x0f, 0x47,
-	0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e,
-	0x0a, 0x04, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61,
-	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x52, 0x04, 0x4c, 0x61, 0x62, 0x73, 0x22, 0x3a,
-	0x0a, 0x12, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x4c, 0x61, 0x62, 0x73, 0x22, 0x43, 0x0a, 0x03, 0x4c, 0x61,
-	0x62, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49,
-	0x44, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x43,
-	0x49, 0x44, 0x52, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x43, 0x49, 0x44, 0x52, 0x22,
-	0xa2, 0x01, 0x0a, 0x09, 0x4c, 0x61, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0e, 0x0a,
-	0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x18, 0x0a,
-	0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x49, 0x44, 0x52, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x43, 0x49, 0x44, 0x52, 0x12, 0x22, 0x0a, 0x03, 0x44,
-	0x4e, 0x53, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
-	0x2e, 0x44, 0x4e, 0x53, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x03, 0x44, 0x4e, 0x53, 0x12,
-	0x33, 0x0a, 0x09, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x09, 0x49, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x73, 0x22, 0x6b, 0x0a, 0x09, 0x44, 0x4e, 0x53, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61,
-	0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f,
-	0x6e, 0x12, 0x2e, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x73, 0x22, 0xa2, 0x01, 0x0a, 0x0e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x02, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67,
-	0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x68, 0x61, 0x6c, 0x6c,
-	0x65, 0x6e, 0x67, 0x65, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16,
-	0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e,
-	0x74, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x09, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x4a, 0x0a, 0x09, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
-	0x6e, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x49, 0x44, 0x12, 0x2d, 0x0a, 0x09, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x49,
-	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x73, 0x22, 0xb4, 0x01, 0x0a, 0x08, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12,
-	0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12,
-	0x14, 0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
-	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x09, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x04, 0x45, 0x6e, 0x76, 0x73, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6e, 0x76, 0x56,
-	0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x04, 0x45, 0x6e, 0x76, 0x73, 0x12, 0x2a, 0x0a,
-	0x07, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x44, 0x4e, 0x53, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x52, 0x07, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x35, 0x0a, 0x09, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x43, 0x50, 0x55, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x43, 0x50, 0x55,
-	0x22, 0x37, 0x0a, 0x0b, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e,
-	0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x0f, 0x46, 0x6c,
-	0x61, 0x67, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x4c, 0x61, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x4c, 0x61,
-	0x62, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
-	0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
-	0x6e, 0x67, 0x65, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x49, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x6c, 0x61, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x46, 0x6c, 0x61, 0x67, 0x22, 0x47, 0x0a, 0x09, 0x44, 0x4e, 0x53, 0x52, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x61,
-	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x32, 0xab,
-	0x06, 0x0a, 0x05, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67,
-	0x12, 0x13, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a,
-	0x0a, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x13, 0x2e, 0x61, 0x67,
-	0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x19, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72,
-	0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30,
-	0x01, 0x12, 0x37, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x12, 0x12, 0x2e, 0x61,
-	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0a, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x73, 0x12, 0x18, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x38, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x73, 0x12, 0x12, 0x2e,
-	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x08, 0x53, 0x74, 0x6f,
-	0x70, 0x4c, 0x61, 0x62, 0x73, 0x12, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61,
-	0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e,
-	0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x37, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x12, 0x12,
-	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x11, 0x41, 0x64,
-	0x64, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x12,
-	0x1f, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x41, 0x64, 0x64, 0x4c, 0x61, 0x62, 0x73, 0x43,
-	0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73,
-	0x12, 0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61,
-	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
-	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4c,
-	0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1c, 0x2e,
-	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
-	0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67,
-	0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x12, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x61, 0x62, 0x73, 0x43,
-	0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e,
-	0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x4b, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c,
-	0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c,
-	0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x3b, 0x5a, 0x39,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x79, 0x62, 0x65, 0x72,
-	0x69, 0x63, 0x65, 0x62, 0x6f, 0x78, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x6b, 0x67,
-	0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x67, 0x72, 0x70, 0x63,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+type CheckLabsIPAMResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Labs []*LabIPAMReport `protobuf:"bytes,1,rep,name=Labs,proto3" json:"Labs,omitempty"`
 }
 
-var (
-	file_agent_proto_rawDescOnce sync.Once
-	file_agent_proto_rawDescData = file_agent_proto_rawDesc
-)
+func (x *CheckLabsIPAMResponse) Reset() {
+	*x = CheckLabsIPAMResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_agent_proto_rawDescGZIP() []byte {
-	file_agent_proto_rawDescOnce.Do(func() {
-		file_agent_proto_rawDescData = protoimpl.X.CompressGZIP(file_agent_proto_rawDescData)
-	})
-	return file_agent_proto_rawDescData
+func (x *CheckLabsIPAMResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
-var file_agent_proto_goTypes = []interface{}{
-	(*EmptyRequest)(nil),             // 0: agent.EmptyRequest
-	(*EmptyResponse)(nil),            // 1: agent.EmptyResponse
-	(*CreateLabsRequest)(nil),        // 2: agent.CreateLabsRequest
-	(*LabsRequest)(nil),              // 3: agent.LabsRequest
-	(*AddLabsChallengesRequest)(nil), // 4: agent.AddLabsChallengesRequest
-	(*LabsChallengesRequest)(nil),    // 5: agent.LabsChallengesRequest
-	(*CreateLabsResponse)(nil),       // 6: agent.CreateLabsResponse
-	(*GetLabsResponse)(nil),          // 7: agent.GetLabsResponse
-	(*MonitoringResponse)(nil),       // 8: agent.MonitoringResponse
-	(*Lab)(nil),                      // 9: agent.Lab
-	(*LabStatus)(nil),                // 10: agent.LabStatus
-	(*DNSStatus)(nil),                // 11: agent.DNSStatus
-	(*InstanceStatus)(nil),           // 12: agent.InstanceStatus
-	(*Challenge)(nil),                // 13: agent.Challenge
-	(*Instance)(nil),                 // 14: agent.Instance
-	(*Resources)(nil),                // 15: agent.Resources
-	(*EnvVariable)(nil),              // 16: agent.EnvVariable
-	(*FlagEnvVariable)(nil),          // 17: agent.FlagEnvVariable
-	(*DNSRecord)(nil),                // 18: agent.DNSRecord
+func (*CheckLabsIPAMResponse) ProtoMessage() {}
+
+func (x *CheckLabsIPAMResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_agent_proto_depIdxs = []int32{
-	13, // 0: agent.AddLabsChallengesRequest.Challenges:type_name -> agent.Challenge
-	17, // 1: agent.AddLabsChallengesRequest.FlagEnvVariables:type_name -> agent.FlagEnvVariable
-	9,  // 2: agent.CreateLabsResponse.Labs:type_name -> agent.Lab
-	9,  // 3: agent.GetLabsResponse.Labs:type_name -> agent.Lab
-	10, // 4: agent.MonitoringResponse.Labs:type_name -> agent.LabStatus
-	11, // 5: agent.LabStatus.DNS:type_name -> agent.DNSStatus
-	12, // 6: agent.LabStatus.Instances:type_name -> agent.InstanceStatus
-	15, // 7: agent.DNSStatus.Resources:type_name -> agent.Resources
-	15, // 8: agent.InstanceStatus.Resources:type_name -> agent.Resources
-	14, // 9: agent.Challenge.Instances:type_name -> agent.Instance
-	15, // 10: agent.Instance.Resources:type_name -> agent.Resources
-	16, // 11: agent.Instance.Envs:type_name -> agent.EnvVariable
-	18, // 12: agent.Instance.Records:type_name -> agent.DNSRecord
-	0,  // 13: agent.Agent.Ping:input_type -> agent.EmptyRequest
-	0,  // 14: agent.Agent.Monitoring:input_type -> agent.EmptyRequest
-	3,  // 15: agent.Agent.GetLabs:input_type -> agent.LabsRequest
-	2,  // 16: agent.Agent.CreateLabs:input_type -> agent.CreateLabsRequest
-	3,  // 17: agent.Agent.DeleteLabs:input_type -> agent.LabsRequest
-	3,  // 18: agent.Agent.StopLabs:input_type -> agent.LabsRequest
-	3,  // 19: agent.Agent.StartLabs:input_type -> agent.LabsRequest
-	4,  // 20: agent.Agent.AddLabsChallenges:input_type -> agent.AddLabsChallengesRequest
-	5,  // 21: agent.Agent.DeleteLabsChallenges:input_type -> agent.LabsChallengesRequest
-	5,  // 22: agent.Agent.StartLabsChallenges:input_type -> agent.LabsChallengesRequest
-	5,  // 23: agent.Agent.StopLabsChallenges:input_type -> agent.LabsChallengesRequest
-	5,  // 24: agent.Agent.ResetLabsChallenges:input_type -> agent.LabsChallengesRequest
-	1,  // 25: agent.Agent.Ping:output_type -> agent.EmptyResponse
-	8,  // 26: agent.Agent.Monitoring:output_type -> agent.MonitoringResponse
-	7,  // 27: agent.Agent.GetLabs:output_type -> agent.GetLabsResponse
-	6,  // 28: agent.Agent.CreateLabs:output_type -> agent.CreateLabsResponse
-	1,  // 29: agent.Agent.DeleteLabs:output_type -> agent.EmptyResponse
-	1,  // 30: agent.Agent.StopLabs:output_type -> agent.EmptyResponse
-	1,  // 31: agent.Agent.StartLabs:output_type -> agent.EmptyResponse
-	1,  // 32: agent.Agent.AddLabsChallenges:output_type -> agent.EmptyResponse
-	1,  // 33: agent.Agent.DeleteLabsChallenges:output_type -> agent.EmptyResponse
-	1,  // 34: agent.Agent.StartLabsChallenges:output_type -> agent.EmptyResponse
-	1,  // 35: agent.Agent.StopLabsChallenges:output_type -> agent.EmptyResponse
-	1,  // 36: agent.Agent.ResetLabsChallenges:output_type -> agent.EmptyResponse
-	25, // [25:37] is the sub-list for method output_type
-	13, // [13:25] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+
+// Deprecated: Use CheckLabsIPAMResponse.ProtoReflect.Descriptor instead.
+func (*CheckLabsIPAMResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{22}
 }
 
-func init() { file_agent_proto_init() }
-func file_agent_proto_init() {
-	if File_agent_proto != nil {
-		return
+func (x *CheckLabsIPAMResponse) GetLabs() []*LabIPAMReport {
+	if x != nil {
+		return x.Labs
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_agent_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EmptyRequest); i {
+	return nil
+}
+
+type LabIPAMReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID       string          `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Networks []*NetworkUsage `protobuf:"bytes,2,rep,name=Networks,proto3" json:"Networks,omitempty"`
+	// held in IPAM, but not used by the deployments and the DNS server
+	LeakedIPs []string `protobuf:"bytes,3,rep,name=LeakedIPs,proto3" json:"LeakedIPs,omitempty"`
+	// used by the deployments, but not held in IPAM
+	UnallocatedIPs []string `protobuf:"bytes,4,rep,name=UnallocatedIPs,proto3" json:"UnallocatedIPs,omitempty"`
+	Released       bool     `protobuf:"varint,5,opt,name=Released,proto3" json:"Released,omitempty"`
+}
+
+func (x *LabIPAMReport) Reset() {
+	*x = LabIPAMReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabIPAMReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabIPAMReport) ProtoMessage() {}
+
+func (x *LabIPAMReport) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabIPAMReport.ProtoReflect.Descriptor instead.
+func (*LabIPAMReport) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LabIPAMReport) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *LabIPAMReport) GetNetworks() []*NetworkUsage {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+func (x *LabIPAMReport) GetLeakedIPs() []string {
+	if x != nil {
+		return x.LeakedIPs
+	}
+	return nil
+}
+
+func (x *LabIPAMReport) GetUnallocatedIPs() []string {
+	if x != nil {
+		return x.UnallocatedIPs
+	}
+	return nil
+}
+
+func (x *LabIPAMReport) GetReleased() bool {
+	if x != nil {
+		return x.Released
+	}
+	return false
+}
+
+type NetworkUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CIDR    string   `protobuf:"bytes,1,opt,name=CIDR,proto3" json:"CIDR,omitempty"`
+	UsedIPs []string `protobuf:"bytes,2,rep,name=UsedIPs,proto3" json:"UsedIPs,omitempty"`
+	// percentage of the usable network addresses
+	Utilization float64 `protobuf:"fixed64,3,opt,name=Utilization,proto3" json:"Utilization,omitempty"`
+}
+
+func (x *NetworkUsage) Reset() {
+	*x = NetworkUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkUsage) ProtoMessage() {}
+
+func (x *NetworkUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkUsage.ProtoReflect.Descriptor instead.
+func (*NetworkUsage) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *NetworkUsage) GetCIDR() string {
+	if x != nil {
+		return x.CIDR
+	}
+	return ""
+}
+
+func (x *NetworkUsage) GetUsedIPs() []string {
+	if x != nil {
+		return x.UsedIPs
+	}
+	return nil
+}
+
+func (x *NetworkUsage) GetUtilization() float64 {
+	if x != nil {
+		return x.Utilization
+	}
+	return 0
+}
+
+type MonitoringResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Labs []*LabStatus `protobuf:"bytes,1,rep,name=Labs,proto3" json:"Labs,omitempty"`
+}
+
+func (x *MonitoringResponse) Reset() {
+	*x = MonitoringResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MonitoringResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonitoringResponse) ProtoMessage() {}
+
+func (x *MonitoringResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonitoringResponse.ProtoReflect.Descriptor instead.
+func (*MonitoringResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MonitoringResponse) GetLabs() []*LabStatus {
+	if x != nil {
+		return x.Labs
+	}
+	return nil
+}
+
+type Lab struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID             string            `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	GroupID        string            `protobuf:"bytes,2,opt,name=GroupID,proto3" json:"GroupID,omitempty"`
+	CIDR           string            `protobuf:"bytes,3,opt,name=CIDR,proto3" json:"CIDR,omitempty"`
+	Metadata       map[string]string `protobuf:"bytes,4,rep,name=Metadata,proto3" json:"Metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	IsolationMode  int32             `protobuf:"varint,5,opt,name=IsolationMode,proto3" json:"IsolationMode,omitempty"`
+	Egress         *EgressProfile    `protobuf:"bytes,6,opt,name=Egress,proto3" json:"Egress,omitempty"`
+	Segments       []*LabSegment     `protobuf:"bytes,7,rep,name=Segments,proto3" json:"Segments,omitempty"`
+	IPv6CIDR       string            `protobuf:"bytes,8,opt,name=IPv6CIDR,proto3" json:"IPv6CIDR,omitempty"`
+	CIDRPool       string            `protobuf:"bytes,9,opt,name=CIDRPool,proto3" json:"CIDRPool,omitempty"`
+	VPNGateway     int32             `protobuf:"varint,10,opt,name=VPNGateway,proto3" json:"VPNGateway,omitempty"`
+	SSHBastion     bool              `protobuf:"varint,11,opt,name=SSHBastion,proto3" json:"SSHBastion,omitempty"`
+	AttackBox      *AttackBox        `protobuf:"bytes,12,opt,name=AttackBox,proto3" json:"AttackBox,omitempty"`
+	PublicURLs     []*PublicURL      `protobuf:"bytes,13,rep,name=PublicURLs,proto3" json:"PublicURLs,omitempty"`
+	PublishedPorts []*PublishedPort  `protobuf:"bytes,14,rep,name=PublishedPorts,proto3" json:"PublishedPorts,omitempty"`
+}
+
+func (x *Lab) Reset() {
+	*x = Lab{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Lab) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lab) ProtoMessage() {}
+
+func (x *Lab) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lab.ProtoReflect.Descriptor instead.
+func (*Lab) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Lab) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *Lab) GetGroupID() string {
+	if x != nil {
+		return x.GroupID
+	}
+	return ""
+}
+
+func (x *Lab) GetCIDR() string {
+	if x != nil {
+		return x.CIDR
+	}
+	return ""
+}
+
+func (x *Lab) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Lab) GetIsolationMode() int32 {
+	if x != nil {
+		return x.IsolationMode
+	}
+	return 0
+}
+
+func (x *Lab) GetEgress() *EgressProfile {
+	if x != nil {
+		return x.Egress
+	}
+	return nil
+}
+
+func (x *Lab) GetSegments() []*LabSegment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+func (x *Lab) GetIPv6CIDR() string {
+	if x != nil {
+		return x.IPv6CIDR
+	}
+	return ""
+}
+
+func (x *Lab) GetCIDRPool() string {
+	if x != nil {
+		return x.CIDRPool
+	}
+	return ""
+}
+
+func (x *Lab) GetVPNGateway() int32 {
+	if x != nil {
+		return x.VPNGateway
+	}
+	return 0
+}
+
+func (x *Lab) GetSSHBastion() bool {
+	if x != nil {
+		return x.SSHBastion
+	}
+	return false
+}
+
+func (x *Lab) GetAttackBox() *AttackBox {
+	if x != nil {
+		return x.AttackBox
+	}
+	return nil
+}
+
+func (x *Lab) GetPublicURLs() []*PublicURL {
+	if x != nil {
+		return x.PublicURLs
+	}
+	return nil
+}
+
+func (x *Lab) GetPublishedPorts() []*PublishedPort {
+	if x != nil {
+		return x.PublishedPorts
+	}
+	return nil
+}
+
+type PublicURL struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChallengeID string `protobuf:"bytes,1,opt,name=ChallengeID,proto3" json:"ChallengeID,omitempty"`
+	InstanceID  string `protobuf:"bytes,2,opt,name=InstanceID,proto3" json:"InstanceID,omitempty"`
+	URL         string `protobuf:"bytes,3,opt,name=URL,proto3" json:"URL,omitempty"`
+}
+
+func (x *PublicURL) Reset() {
+	*x = PublicURL{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublicURL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicURL) ProtoMessage() {}
+
+func (x *PublicURL) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicURL.ProtoReflect.Descriptor instead.
+func (*PublicURL) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PublicURL) GetChallengeID() string {
+	if x != nil {
+		return x.ChallengeID
+	}
+	return ""
+}
+
+func (x *PublicURL) GetInstanceID() string {
+	if x != nil {
+		return x.InstanceID
+	}
+	return ""
+}
+
+func (x *PublicURL) GetURL() string {
+	if x != nil {
+		return x.URL
+	}
+	return ""
+}
+
+type PublishedPort struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChallengeID string `protobuf:"bytes,1,opt,name=ChallengeID,proto3" json:"ChallengeID,omitempty"`
+	InstanceID  string `protobuf:"bytes,2,opt,name=InstanceID,proto3" json:"InstanceID,omitempty"`
+	Port        int32  `protobuf:"varint,3,opt,name=Port,proto3" json:"Port,omitempty"`
+	Protocol    string `protobuf:"bytes,4,opt,name=Protocol,proto3" json:"Protocol,omitempty"`
+	// host:port of the published port, empty - the load balancer has no address yet
+	Address string `protobuf:"bytes,5,opt,name=Address,proto3" json:"Address,omitempty"`
+}
+
+func (x *PublishedPort) Reset() {
+	*x = PublishedPort{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublishedPort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishedPort) ProtoMessage() {}
+
+func (x *PublishedPort) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishedPort.ProtoReflect.Descriptor instead.
+func (*PublishedPort) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PublishedPort) GetChallengeID() string {
+	if x != nil {
+		return x.ChallengeID
+	}
+	return ""
+}
+
+func (x *PublishedPort) GetInstanceID() string {
+	if x != nil {
+		return x.InstanceID
+	}
+	return ""
+}
+
+func (x *PublishedPort) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *PublishedPort) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *PublishedPort) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type LabStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID        string            `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	GroupID   string            `protobuf:"bytes,2,opt,name=GroupID,proto3" json:"GroupID,omitempty"`
+	CIDR      string            `protobuf:"bytes,3,opt,name=CIDR,proto3" json:"CIDR,omitempty"`
+	DNS       *DNSStatus        `protobuf:"bytes,4,opt,name=DNS,proto3" json:"DNS,omitempty"`
+	Instances []*InstanceStatus `protobuf:"bytes,5,rep,name=Instances,proto3" json:"Instances,omitempty"`
+	Quota     *QuotaStatus      `protobuf:"bytes,6,opt,name=Quota,proto3" json:"Quota,omitempty"`
+}
+
+func (x *LabStatus) Reset() {
+	*x = LabStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabStatus) ProtoMessage() {}
+
+func (x *LabStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabStatus.ProtoReflect.Descriptor instead.
+func (*LabStatus) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *LabStatus) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *LabStatus) GetGroupID() string {
+	if x != nil {
+		return x.GroupID
+	}
+	return ""
+}
+
+func (x *LabStatus) GetCIDR() string {
+	if x != nil {
+		return x.CIDR
+	}
+	return ""
+}
+
+func (x *LabStatus) GetDNS() *DNSStatus {
+	if x != nil {
+		return x.DNS
+	}
+	return nil
+}
+
+func (x *LabStatus) GetInstances() []*InstanceStatus {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+func (x *LabStatus) GetQuota() *QuotaStatus {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+type QuotaStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hard *ResourceQuota `protobuf:"bytes,1,opt,name=Hard,proto3" json:"Hard,omitempty"`
+	Used *ResourceQuota `protobuf:"bytes,2,opt,name=Used,proto3" json:"Used,omitempty"`
+}
+
+func (x *QuotaStatus) Reset() {
+	*x = QuotaStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuotaStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotaStatus) ProtoMessage() {}
+
+func (x *QuotaStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotaStatus.ProtoReflect.Descriptor instead.
+func (*QuotaStatus) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *QuotaStatus) GetHard() *ResourceQuota {
+	if x != nil {
+		return x.Hard
+	}
+	return nil
+}
+
+func (x *QuotaStatus) GetUsed() *ResourceQuota {
+	if x != nil {
+		return x.Used
+	}
+	return nil
+}
+
+type DNSStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status    int32      `protobuf:"varint,2,opt,name=Status,proto3" json:"Status,omitempty"`
+	Reason    string     `protobuf:"bytes,3,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	Resources *Resources `protobuf:"bytes,4,opt,name=Resources,proto3" json:"Resources,omitempty"`
+}
+
+func (x *DNSStatus) Reset() {
+	*x = DNSStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DNSStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSStatus) ProtoMessage() {}
+
+func (x *DNSStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSStatus.ProtoReflect.Descriptor instead.
+func (*DNSStatus) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DNSStatus) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *DNSStatus) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DNSStatus) GetResources() *Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+type InstanceStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID          string     `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	ChallengeID string     `protobuf:"bytes,2,opt,name=ChallengeID,proto3" json:"ChallengeID,omitempty"`
+	Status      int32      `protobuf:"varint,3,opt,name=Status,proto3" json:"Status,omitempty"`
+	Reason      string     `protobuf:"bytes,4,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	Resources   *Resources `protobuf:"bytes,5,opt,name=Resources,proto3" json:"Resources,omitempty"`
+	URLs        []string   `protobuf:"bytes,6,rep,name=URLs,proto3" json:"URLs,omitempty"`
+}
+
+func (x *InstanceStatus) Reset() {
+	*x = InstanceStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstanceStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceStatus) ProtoMessage() {}
+
+func (x *InstanceStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceStatus.ProtoReflect.Descriptor instead.
+func (*InstanceStatus) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *InstanceStatus) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *InstanceStatus) GetChallengeID() string {
+	if x != nil {
+		return x.ChallengeID
+	}
+	return ""
+}
+
+func (x *InstanceStatus) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *InstanceStatus) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *InstanceStatus) GetResources() *Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *InstanceStatus) GetURLs() []string {
+	if x != nil {
+		return x.URLs
+	}
+	return nil
+}
+
+type Challenge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID        string      `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Instances []*Instance `protobuf:"bytes,2,rep,name=Instances,proto3" json:"Instances,omitempty"`
+}
+
+func (x *Challenge) Reset() {
+	*x = Challenge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Challenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Challenge) ProtoMessage() {}
+
+func (x *Challenge) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Challenge.ProtoReflect.Descriptor instead.
+func (*Challenge) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Challenge) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *Challenge) GetInstances() []*Instance {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+type Instance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID    string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Image string `protobuf:"bytes,2,opt,name=Image,proto3" json:"Image,omitempty"`
+	// limits of the instance container
+	Resources *Resources     `protobuf:"bytes,3,opt,name=Resources,proto3" json:"Resources,omitempty"`
+	Envs      []*EnvVariable `protobuf:"bytes,4,rep,name=Envs,proto3" json:"Envs,omitempty"`
+	Records   []*DNSRecord   `protobuf:"bytes,5,rep,name=Records,proto3" json:"Records,omitempty"`
+	Egress    *EgressProfile `protobuf:"bytes,6,opt,name=Egress,proto3" json:"Egress,omitempty"`
+	// lab segment joined by the instance, at most one as the instance has a single network interface,
+	// the instance ip is from the segment and the segment policies admit it
+	Segments []string `protobuf:"bytes,7,rep,name=Segments,proto3" json:"Segments,omitempty"`
+	// lab pods which can reach the instance, empty - the whole lab or segment
+	AllowFrom []*NetworkRule `protobuf:"bytes,8,rep,name=AllowFrom,proto3" json:"AllowFrom,omitempty"`
+	// lab pods the instance can reach, empty - the whole lab
+	AllowTo []*NetworkRule `protobuf:"bytes,9,rep,name=AllowTo,proto3" json:"AllowTo,omitempty"`
+	// pinned instance ip in the lab network or the first segment, empty - the next free ip
+	IP string `protobuf:"bytes,10,opt,name=IP,proto3" json:"IP,omitempty"`
+	// pinned host offset in the instance network, 0 - not pinned
+	IPOffset uint32 `protobuf:"varint,11,opt,name=IPOffset,proto3" json:"IPOffset,omitempty"`
+	// ports published by the instance lab hostnames, the first one by <instance>-<lab>.<baseDomain>, the others by <instance>-<port>-<lab>.<baseDomain>
+	HTTPPorts []int32 `protobuf:"varint,12,rep,packed,name=HTTPPorts,proto3" json:"HTTPPorts,omitempty"`
+	// TCP or UDP ports published by the NodePort or LoadBalancer service of the instance on the allocated public ports
+	PublishedPorts []*Port `protobuf:"bytes,13,rep,name=PublishedPorts,proto3" json:"PublishedPorts,omitempty"`
+	// in-cluster service of the instance resolved by the instance ID in the lab DNS zone, empty - no service
+	Service *InstanceService `protobuf:"bytes,14,opt,name=Service,proto3" json:"Service,omitempty"`
+	// privileged mode is allowed only in the labs of the groups with the AllowPrivileged permission
+	Privileged bool `protobuf:"varint,15,opt,name=Privileged,proto3" json:"Privileged,omitempty"`
+	// linux capabilities without the CAP_ prefix, for example NET_ADMIN, the ones out of the runtime defaults and NET_ADMIN
+	// are allowed only in the labs of the groups with the AllowPrivileged permission
+	CapAdds        []string `protobuf:"bytes,16,rep,name=CapAdds,proto3" json:"CapAdds,omitempty"`
+	ReadinessProbe *Probe   `protobuf:"bytes,17,opt,name=ReadinessProbe,proto3" json:"ReadinessProbe,omitempty"`
+	LivenessProbe  *Probe   `protobuf:"bytes,18,opt,name=LivenessProbe,proto3" json:"LivenessProbe,omitempty"`
+	// sidecars sharing the instance network, the names are unique in the instance and differ from the instance ID
+	Containers []*Container `protobuf:"bytes,19,rep,name=Containers,proto3" json:"Containers,omitempty"`
+	// containers run in order before the instance containers start
+	InitContainers []*Container `protobuf:"bytes,20,rep,name=InitContainers,proto3" json:"InitContainers,omitempty"`
+	// empty - the image entrypoint
+	Command []string `protobuf:"bytes,21,rep,name=Command,proto3" json:"Command,omitempty"`
+	Args    []string `protobuf:"bytes,22,rep,name=Args,proto3" json:"Args,omitempty"`
+	// empty - the image working directory
+	WorkingDir     string  `protobuf:"bytes,23,opt,name=WorkingDir,proto3" json:"WorkingDir,omitempty"`
+	ContainerPorts []*Port `protobuf:"bytes,24,rep,name=ContainerPorts,proto3" json:"ContainerPorts,omitempty"`
+	// empty - the image user and group
+	RunAs *RunAs `protobuf:"bytes,25,opt,name=RunAs,proto3" json:"RunAs,omitempty"`
+	// empty - the generated pod name
+	Hostname string `protobuf:"bytes,26,opt,name=Hostname,proto3" json:"Hostname,omitempty"`
+	// empty - the limits reduced by the group overcommit ratios
+	Requests *Resources `protobuf:"bytes,27,opt,name=Requests,proto3" json:"Requests,omitempty"`
+}
+
+func (x *Instance) Reset() {
+	*x = Instance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Instance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Instance) ProtoMessage() {}
+
+func (x *Instance) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Instance.ProtoReflect.Descriptor instead.
+func (*Instance) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Instance) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *Instance) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Instance) GetResources() *Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *Instance) GetEnvs() []*EnvVariable {
+	if x != nil {
+		return x.Envs
+	}
+	return nil
+}
+
+func (x *Instance) GetRecords() []*DNSRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *Instance) GetEgress() *EgressProfile {
+	if x != nil {
+		return x.Egress
+	}
+	return nil
+}
+
+func (x *Instance) GetSegments() []string {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+func (x *Instance) GetAllowFrom() []*NetworkRule {
+	if x != nil {
+		return x.AllowFrom
+	}
+	return nil
+}
+
+func (x *Instance) GetAllowTo() []*NetworkRule {
+	if x != nil {
+		return x.AllowTo
+	}
+	return nil
+}
+
+func (x *Instance) GetIP() string {
+	if x != nil {
+		return x.IP
+	}
+	return ""
+}
+
+func (x *Instance) GetIPOffset() uint32 {
+	if x != nil {
+		return x.IPOffset
+	}
+	return 0
+}
+
+func (x *Instance) GetHTTPPorts() []int32 {
+	if x != nil {
+		return x.HTTPPorts
+	}
+	return nil
+}
+
+func (x *Instance) GetPublishedPorts() []*Port {
+	if x != nil {
+		return x.PublishedPorts
+	}
+	return nil
+}
+
+func (x *Instance) GetService() *InstanceService {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *Instance) GetPrivileged() bool {
+	if x != nil {
+		return x.Privileged
+	}
+	return false
+}
+
+func (x *Instance) GetCapAdds() []string {
+	if x != nil {
+		return x.CapAdds
+	}
+	return nil
+}
+
+func (x *Instance) GetReadinessProbe() *Probe {
+	if x != nil {
+		return x.ReadinessProbe
+	}
+	return nil
+}
+
+func (x *Instance) GetLivenessProbe() *Probe {
+	if x != nil {
+		return x.LivenessProbe
+	}
+	return nil
+}
+
+func (x *Instance) GetContainers() []*Container {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+func (x *Instance) GetInitContainers() []*Container {
+	if x != nil {
+		return x.InitContainers
+	}
+	return nil
+}
+
+func (x *Instance) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *Instance) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *Instance) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *Instance) GetContainerPorts() []*Port {
+	if x != nil {
+		return x.ContainerPorts
+	}
+	return nil
+}
+
+func (x *Instance) GetRunAs() *RunAs {
+	if x != nil {
+		return x.RunAs
+	}
+	return nil
+}
+
+func (x *Instance) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *Instance) GetRequests() *Resources {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type RunAs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID  int64 `protobuf:"varint,1,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	GroupID int64 `protobuf:"varint,2,opt,name=GroupID,proto3" json:"GroupID,omitempty"`
+}
+
+func (x *RunAs) Reset() {
+	*x = RunAs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunAs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunAs) ProtoMessage() {}
+
+func (x *RunAs) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunAs.ProtoReflect.Descriptor instead.
+func (*RunAs) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RunAs) GetUserID() int64 {
+	if x != nil {
+		return x.UserID
+	}
+	return 0
+}
+
+func (x *RunAs) GetGroupID() int64 {
+	if x != nil {
+		return x.GroupID
+	}
+	return 0
+}
+
+type Container struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string         `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Image string         `protobuf:"bytes,2,opt,name=Image,proto3" json:"Image,omitempty"`
+	Envs  []*EnvVariable `protobuf:"bytes,3,rep,name=Envs,proto3" json:"Envs,omitempty"`
+	// limits of the container
+	Resources *Resources `protobuf:"bytes,4,opt,name=Resources,proto3" json:"Resources,omitempty"`
+	// empty - the image entrypoint
+	Command []string `protobuf:"bytes,5,rep,name=Command,proto3" json:"Command,omitempty"`
+	Args    []string `protobuf:"bytes,6,rep,name=Args,proto3" json:"Args,omitempty"`
+	// empty - the limits reduced by the group overcommit ratios
+	Requests *Resources `protobuf:"bytes,7,opt,name=Requests,proto3" json:"Requests,omitempty"`
+}
+
+func (x *Container) Reset() {
+	*x = Container{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Container) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Container) ProtoMessage() {}
+
+func (x *Container) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Container.ProtoReflect.Descriptor instead.
+func (*Container) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Container) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Container) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Container) GetEnvs() []*EnvVariable {
+	if x != nil {
+		return x.Envs
+	}
+	return nil
+}
+
+func (x *Container) GetResources() *Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *Container) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *Container) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *Container) GetRequests() *Resources {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+// exactly one of Command, HTTPPort or TCPPort is set
+type Probe struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command []string `protobuf:"bytes,1,rep,name=Command,proto3" json:"Command,omitempty"`
+	// empty - /
+	HTTPPath string `protobuf:"bytes,2,opt,name=HTTPPath,proto3" json:"HTTPPath,omitempty"`
+	HTTPPort int32  `protobuf:"varint,3,opt,name=HTTPPort,proto3" json:"HTTPPort,omitempty"`
+	TCPPort  int32  `protobuf:"varint,4,opt,name=TCPPort,proto3" json:"TCPPort,omitempty"`
+	// 0 - the kubernetes default
+	InitialDelaySeconds int32 `protobuf:"varint,5,opt,name=InitialDelaySeconds,proto3" json:"InitialDelaySeconds,omitempty"`
+	PeriodSeconds       int32 `protobuf:"varint,6,opt,name=PeriodSeconds,proto3" json:"PeriodSeconds,omitempty"`
+	FailureThreshold    int32 `protobuf:"varint,7,opt,name=FailureThreshold,proto3" json:"FailureThreshold,omitempty"`
+}
+
+func (x *Probe) Reset() {
+	*x = Probe{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Probe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Probe) ProtoMessage() {}
+
+func (x *Probe) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Probe.ProtoReflect.Descriptor instead.
+func (*Probe) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Probe) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *Probe) GetHTTPPath() string {
+	if x != nil {
+		return x.HTTPPath
+	}
+	return ""
+}
+
+func (x *Probe) GetHTTPPort() int32 {
+	if x != nil {
+		return x.HTTPPort
+	}
+	return 0
+}
+
+func (x *Probe) GetTCPPort() int32 {
+	if x != nil {
+		return x.TCPPort
+	}
+	return 0
+}
+
+func (x *Probe) GetInitialDelaySeconds() int32 {
+	if x != nil {
+		return x.InitialDelaySeconds
+	}
+	return 0
+}
+
+func (x *Probe) GetPeriodSeconds() int32 {
+	if x != nil {
+		return x.PeriodSeconds
+	}
+	return 0
+}
+
+func (x *Probe) GetFailureThreshold() int32 {
+	if x != nil {
+		return x.FailureThreshold
+	}
+	return 0
+}
+
+type InstanceService struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// headless service has no cluster ip, the instance ID is resolved to the instance ip
+	Headless bool    `protobuf:"varint,1,opt,name=Headless,proto3" json:"Headless,omitempty"`
+	Ports    []*Port `protobuf:"bytes,2,rep,name=Ports,proto3" json:"Ports,omitempty"`
+}
+
+func (x *InstanceService) Reset() {
+	*x = InstanceService{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstanceService) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceService) ProtoMessage() {}
+
+func (x *InstanceService) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceService.ProtoReflect.Descriptor instead.
+func (*InstanceService) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *InstanceService) GetHeadless() bool {
+	if x != nil {
+		return x.Headless
+	}
+	return false
+}
+
+func (x *InstanceService) GetPorts() []*Port {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+type NetworkRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// empty - any lab pod
+	InstanceIDs []string `protobuf:"bytes,1,rep,name=InstanceIDs,proto3" json:"InstanceIDs,omitempty"`
+	// empty - any port
+	Ports []*Port `protobuf:"bytes,2,rep,name=Ports,proto3" json:"Ports,omitempty"`
+}
+
+func (x *NetworkRule) Reset() {
+	*x = NetworkRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkRule) ProtoMessage() {}
+
+func (x *NetworkRule) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkRule.ProtoReflect.Descriptor instead.
+func (*NetworkRule) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *NetworkRule) GetInstanceIDs() []string {
+	if x != nil {
+		return x.InstanceIDs
+	}
+	return nil
+}
+
+func (x *NetworkRule) GetPorts() []*Port {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+type Port struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Port int32 `protobuf:"varint,1,opt,name=Port,proto3" json:"Port,omitempty"`
+	// TCP, UDP or SCTP, empty - TCP
+	Protocol string `protobuf:"bytes,2,opt,name=Protocol,proto3" json:"Protocol,omitempty"`
+}
+
+func (x *Port) Reset() {
+	*x = Port{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Port) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Port) ProtoMessage() {}
+
+func (x *Port) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Port.ProtoReflect.Descriptor instead.
+func (*Port) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Port) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Port) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+type Resources struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Memory int64 `protobuf:"varint,1,opt,name=Memory,proto3" json:"Memory,omitempty"`
+	CPU    int64 `protobuf:"varint,2,opt,name=CPU,proto3" json:"CPU,omitempty"`
+	// bytes of the container writable layer, logs and emptyDir volumes
+	EphemeralStorage int64 `protobuf:"varint,3,opt,name=EphemeralStorage,proto3" json:"EphemeralStorage,omitempty"`
+}
+
+func (x *Resources) Reset() {
+	*x = Resources{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Resources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resources) ProtoMessage() {}
+
+func (x *Resources) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resources.ProtoReflect.Descriptor instead.
+func (*Resources) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Resources) GetMemory() int64 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *Resources) GetCPU() int64 {
+	if x != nil {
+		return x.CPU
+	}
+	return 0
+}
+
+func (x *Resources) GetEphemeralStorage() int64 {
+	if x != nil {
+		return x.EphemeralStorage
+	}
+	return 0
+}
+
+type ResourceQuota struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CPU              int64 `protobuf:"varint,1,opt,name=CPU,proto3" json:"CPU,omitempty"`
+	Memory           int64 `protobuf:"varint,2,opt,name=Memory,proto3" json:"Memory,omitempty"`
+	Pods             int64 `protobuf:"varint,3,opt,name=Pods,proto3" json:"Pods,omitempty"`
+	EphemeralStorage int64 `protobuf:"varint,4,opt,name=EphemeralStorage,proto3" json:"EphemeralStorage,omitempty"`
+}
+
+func (x *ResourceQuota) Reset() {
+	*x = ResourceQuota{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceQuota) ProtoMessage() {}
+
+func (x *ResourceQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceQuota.ProtoReflect.Descriptor instead.
+func (*ResourceQuota) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ResourceQuota) GetCPU() int64 {
+	if x != nil {
+		return x.CPU
+	}
+	return 0
+}
+
+func (x *ResourceQuota) GetMemory() int64 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *ResourceQuota) GetPods() int64 {
+	if x != nil {
+		return x.Pods
+	}
+	return 0
+}
+
+func (x *ResourceQuota) GetEphemeralStorage() int64 {
+	if x != nil {
+		return x.EphemeralStorage
+	}
+	return 0
+}
+
+type EnvVariable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+}
+
+func (x *EnvVariable) Reset() {
+	*x = EnvVariable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnvVariable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvVariable) ProtoMessage() {}
+
+func (x *EnvVariable) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvVariable.ProtoReflect.Descriptor instead.
+func (*EnvVariable) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *EnvVariable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EnvVariable) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type FlagEnvVariable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LabID       string `protobuf:"bytes,1,opt,name=LabID,proto3" json:"LabID,omitempty"`
+	ChallengeID string `protobuf:"bytes,2,opt,name=ChallengeID,proto3" json:"ChallengeID,omitempty"`
+	InstanceID  string `protobuf:"bytes,3,opt,name=InstanceID,proto3" json:"InstanceID,omitempty"`
+	Variable    string `protobuf:"bytes,4,opt,name=Variable,proto3" json:"Variable,omitempty"`
+	Flag        string `protobuf:"bytes,5,opt,name=Flag,proto3" json:"Flag,omitempty"`
+}
+
+func (x *FlagEnvVariable) Reset() {
+	*x = FlagEnvVariable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlagEnvVariable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlagEnvVariable) ProtoMessage() {}
+
+func (x *FlagEnvVariable) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlagEnvVariable.ProtoReflect.Descriptor instead.
+func (*FlagEnvVariable) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *FlagEnvVariable) GetLabID() string {
+	if x != nil {
+		return x.LabID
+	}
+	return ""
+}
+
+func (x *FlagEnvVariable) GetChallengeID() string {
+	if x != nil {
+		return x.ChallengeID
+	}
+	return ""
+}
+
+func (x *FlagEnvVariable) GetInstanceID() string {
+	if x != nil {
+		return x.InstanceID
+	}
+	return ""
+}
+
+func (x *FlagEnvVariable) GetVariable() string {
+	if x != nil {
+		return x.Variable
+	}
+	return ""
+}
+
+func (x *FlagEnvVariable) GetFlag() string {
+	if x != nil {
+		return x.Flag
+	}
+	return ""
+}
+
+type TemplateFlagEnvVariable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LabIndex    uint32 `protobuf:"varint,1,opt,name=LabIndex,proto3" json:"LabIndex,omitempty"`
+	ChallengeID string `protobuf:"bytes,2,opt,name=ChallengeID,proto3" json:"ChallengeID,omitempty"`
+	InstanceID  string `protobuf:"bytes,3,opt,name=InstanceID,proto3" json:"InstanceID,omitempty"`
+	Variable    string `protobuf:"bytes,4,opt,name=Variable,proto3" json:"Variable,omitempty"`
+	Flag        string `protobuf:"bytes,5,opt,name=Flag,proto3" json:"Flag,omitempty"`
+}
+
+func (x *TemplateFlagEnvVariable) Reset() {
+	*x = TemplateFlagEnvVariable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TemplateFlagEnvVariable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateFlagEnvVariable) ProtoMessage() {}
+
+func (x *TemplateFlagEnvVariable) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateFlagEnvVariable.ProtoReflect.Descriptor instead.
+func (*TemplateFlagEnvVariable) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *TemplateFlagEnvVariable) GetLabIndex() uint32 {
+	if x != nil {
+		return x.LabIndex
+	}
+	return 0
+}
+
+func (x *TemplateFlagEnvVariable) GetChallengeID() string {
+	if x != nil {
+		return x.ChallengeID
+	}
+	return ""
+}
+
+func (x *TemplateFlagEnvVariable) GetInstanceID() string {
+	if x != nil {
+		return x.InstanceID
+	}
+	return ""
+}
+
+func (x *TemplateFlagEnvVariable) GetVariable() string {
+	if x != nil {
+		return x.Variable
+	}
+	return ""
+}
+
+func (x *TemplateFlagEnvVariable) GetFlag() string {
+	if x != nil {
+		return x.Flag
+	}
+	return ""
+}
+
+type DNSRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type string `protobuf:"bytes,1,opt,name=Type,proto3" json:"Type,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Data string `protobuf:"bytes,3,opt,name=Data,proto3" json:"Data,omitempty"`
+}
+
+func (x *DNSRecord) Reset() {
+	*x = DNSRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DNSRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSRecord) ProtoMessage() {}
+
+func (x *DNSRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSRecord.ProtoReflect.Descriptor instead.
+func (*DNSRecord) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DNSRecord) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DNSRecord) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DNSRecord) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type CreateTemplateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string       `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Challenges []*Challenge `protobuf:"bytes,2,rep,name=Challenges,proto3" json:"Challenges,omitempty"`
+}
+
+func (x *CreateTemplateRequest) Reset() {
+	*x = CreateTemplateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTemplateRequest) ProtoMessage() {}
+
+func (x *CreateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTemplateRequest) GetChallenges() []*Challenge {
+	if x != nil {
+		return x.Challenges
+	}
+	return nil
+}
+
+type CreateTemplateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Template *Template `protobuf:"bytes,1,opt,name=Template,proto3" json:"Template,omitempty"`
+}
+
+func (x *CreateTemplateResponse) Reset() {
+	*x = CreateTemplateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTemplateResponse) ProtoMessage() {}
+
+func (x *CreateTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTemplateResponse.ProtoReflect.Descriptor instead.
+func (*CreateTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateTemplateResponse) GetTemplate() *Template {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type ListTemplatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+}
+
+func (x *ListTemplatesRequest) Reset() {
+	*x = ListTemplatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesRequest) ProtoMessage() {}
+
+func (x *ListTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListTemplatesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListTemplatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Templates []*Template `protobuf:"bytes,1,rep,name=Templates,proto3" json:"Templates,omitempty"`
+}
+
+func (x *ListTemplatesResponse) Reset() {
+	*x = ListTemplatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesResponse) ProtoMessage() {}
+
+func (x *ListTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListTemplatesResponse) GetTemplates() []*Template {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type DeleteTemplateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+}
+
+func (x *DeleteTemplateRequest) Reset() {
+	*x = DeleteTemplateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DeleteTemplateRequest) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+type Template struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID         string       `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Name       string       `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Version    uint32       `protobuf:"varint,3,opt,name=Version,proto3" json:"Version,omitempty"`
+	Challenges []*Challenge `protobuf:"bytes,4,rep,name=Challenges,proto3" json:"Challenges,omitempty"`
+}
+
+func (x *Template) Reset() {
+	*x = Template{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Template) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Template) ProtoMessage() {}
+
+func (x *Template) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Template.ProtoReflect.Descriptor instead.
+func (*Template) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *Template) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *Template) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Template) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Template) GetChallenges() []*Challenge {
+	if x != nil {
+		return x.Challenges
+	}
+	return nil
+}
+
+type LabsGroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group *LabsGroup `protobuf:"bytes,1,opt,name=Group,proto3" json:"Group,omitempty"`
+}
+
+func (x *LabsGroupRequest) Reset() {
+	*x = LabsGroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabsGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabsGroupRequest) ProtoMessage() {}
+
+func (x *LabsGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabsGroupRequest.ProtoReflect.Descriptor instead.
+func (*LabsGroupRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *LabsGroupRequest) GetGroup() *LabsGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+type LabsGroupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group *LabsGroup `protobuf:"bytes,1,opt,name=Group,proto3" json:"Group,omitempty"`
+}
+
+func (x *LabsGroupResponse) Reset() {
+	*x = LabsGroupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabsGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabsGroupResponse) ProtoMessage() {}
+
+func (x *LabsGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabsGroupResponse.ProtoReflect.Descriptor instead.
+func (*LabsGroupResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *LabsGroupResponse) GetGroup() *LabsGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+type GetLabsGroupsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IDs []string `protobuf:"bytes,1,rep,name=IDs,proto3" json:"IDs,omitempty"`
+}
+
+func (x *GetLabsGroupsRequest) Reset() {
+	*x = GetLabsGroupsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLabsGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLabsGroupsRequest) ProtoMessage() {}
+
+func (x *GetLabsGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLabsGroupsRequest.ProtoReflect.Descriptor instead.
+func (*GetLabsGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetLabsGroupsRequest) GetIDs() []string {
+	if x != nil {
+		return x.IDs
+	}
+	return nil
+}
+
+type GetLabsGroupsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Groups []*LabsGroup `protobuf:"bytes,1,rep,name=Groups,proto3" json:"Groups,omitempty"`
+}
+
+func (x *GetLabsGroupsResponse) Reset() {
+	*x = GetLabsGroupsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLabsGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLabsGroupsResponse) ProtoMessage() {}
+
+func (x *GetLabsGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLabsGroupsResponse.ProtoReflect.Descriptor instead.
+func (*GetLabsGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetLabsGroupsResponse) GetGroups() []*LabsGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type DeleteLabsGroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+}
+
+func (x *DeleteLabsGroupRequest) Reset() {
+	*x = DeleteLabsGroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteLabsGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLabsGroupRequest) ProtoMessage() {}
+
+func (x *DeleteLabsGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLabsGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLabsGroupRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *DeleteLabsGroupRequest) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+type LabsGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID          string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
+	// unix seconds, 0 means no limit
+	StartsAt      int64             `protobuf:"varint,4,opt,name=StartsAt,proto3" json:"StartsAt,omitempty"`
+	EndsAt        int64             `protobuf:"varint,5,opt,name=EndsAt,proto3" json:"EndsAt,omitempty"`
+	CIDRMask      uint32            `protobuf:"varint,6,opt,name=CIDRMask,proto3" json:"CIDRMask,omitempty"`
+	Quota         *ResourceQuota    `protobuf:"bytes,7,opt,name=Quota,proto3" json:"Quota,omitempty"`
+	MaxLabs       int32             `protobuf:"varint,8,opt,name=MaxLabs,proto3" json:"MaxLabs,omitempty"`
+	Labels        map[string]string `protobuf:"bytes,9,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	IsolationMode int32             `protobuf:"varint,10,opt,name=IsolationMode,proto3" json:"IsolationMode,omitempty"`
+	// empty value means the default pool
+	CIDRPool string `protobuf:"bytes,11,opt,name=CIDRPool,proto3" json:"CIDRPool,omitempty"`
+	// privileged instances are allowed only in the labs of the groups with this permission,
+	// it can not be taken from the group and the labs can not be moved to the group without it while they run the privileged instances
+	AllowPrivileged bool `protobuf:"varint,12,opt,name=AllowPrivileged,proto3" json:"AllowPrivileged,omitempty"`
+	// the instances without the requests request the limits divided by the ratios from 1 to 100, 0 - no overcommit
+	CPUOvercommitRatio    float64 `protobuf:"fixed64,13,opt,name=CPUOvercommitRatio,proto3" json:"CPUOvercommitRatio,omitempty"`
+	MemoryOvercommitRatio float64 `protobuf:"fixed64,14,opt,name=MemoryOvercommitRatio,proto3" json:"MemoryOvercommitRatio,omitempty"`
+}
+
+func (x *LabsGroup) Reset() {
+	*x = LabsGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabsGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabsGroup) ProtoMessage() {}
+
+func (x *LabsGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabsGroup.ProtoReflect.Descriptor instead.
+func (*LabsGroup) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *LabsGroup) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *LabsGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LabsGroup) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LabsGroup) GetStartsAt() int64 {
+	if x != nil {
+		return x.StartsAt
+	}
+	return 0
+}
+
+func (x *LabsGroup) GetEndsAt() int64 {
+	if x != nil {
+		return x.EndsAt
+	}
+	return 0
+}
+
+func (x *LabsGroup) GetCIDRMask() uint32 {
+	if x != nil {
+		return x.CIDRMask
+	}
+	return 0
+}
+
+func (x *LabsGroup) GetQuota() *ResourceQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *LabsGroup) GetMaxLabs() int32 {
+	if x != nil {
+		return x.MaxLabs
+	}
+	return 0
+}
+
+func (x *LabsGroup) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *LabsGroup) GetIsolationMode() int32 {
+	if x != nil {
+		return x.IsolationMode
+	}
+	return 0
+}
+
+func (x *LabsGroup) GetCIDRPool() string {
+	if x != nil {
+		return x.CIDRPool
+	}
+	return ""
+}
+
+func (x *LabsGroup) GetAllowPrivileged() bool {
+	if x != nil {
+		return x.AllowPrivileged
+	}
+	return false
+}
+
+func (x *LabsGroup) GetCPUOvercommitRatio() float64 {
+	if x != nil {
+		return x.CPUOvercommitRatio
+	}
+	return 0
+}
+
+func (x *LabsGroup) GetMemoryOvercommitRatio() float64 {
+	if x != nil {
+		return x.MemoryOvercommitRatio
+	}
+	return 0
+}
+
+type AddCIDRPoolRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	CIDR string `protobuf:"bytes,2,opt,name=CIDR,proto3" json:"CIDR,omitempty"`
+}
+
+func (x *AddCIDRPoolRequest) Reset() {
+	*x = AddCIDRPoolRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddCIDRPoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCIDRPoolRequest) ProtoMessage() {}
+
+func (x *AddCIDRPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCIDRPoolRequest.ProtoReflect.Descriptor instead.
+func (*AddCIDRPoolRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *AddCIDRPoolRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddCIDRPoolRequest) GetCIDR() string {
+	if x != nil {
+		return x.CIDR
+	}
+	return ""
+}
+
+type CIDRPoolResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pool *CIDRPool `protobuf:"bytes,1,opt,name=Pool,proto3" json:"Pool,omitempty"`
+}
+
+func (x *CIDRPoolResponse) Reset() {
+	*x = CIDRPoolResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CIDRPoolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CIDRPoolResponse) ProtoMessage() {}
+
+func (x *CIDRPoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CIDRPoolResponse.ProtoReflect.Descriptor instead.
+func (*CIDRPoolResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CIDRPoolResponse) GetPool() *CIDRPool {
+	if x != nil {
+		return x.Pool
+	}
+	return nil
+}
+
+type GetCIDRPoolsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pools []*CIDRPool `protobuf:"bytes,1,rep,name=Pools,proto3" json:"Pools,omitempty"`
+}
+
+func (x *GetCIDRPoolsResponse) Reset() {
+	*x = GetCIDRPoolsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCIDRPoolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCIDRPoolsResponse) ProtoMessage() {}
+
+func (x *GetCIDRPoolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCIDRPoolsResponse.ProtoReflect.Descriptor instead.
+func (*GetCIDRPoolsResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GetCIDRPoolsResponse) GetPools() []*CIDRPool {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+type CIDRPool struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	CIDR string `protobuf:"bytes,2,opt,name=CIDR,proto3" json:"CIDR,omitempty"`
+	// unix seconds, 0 for the default pool
+	CreatedAt int64 `protobuf:"varint,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+}
+
+func (x *CIDRPool) Reset() {
+	*x = CIDRPool{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CIDRPool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CIDRPool) ProtoMessage() {}
+
+func (x *CIDRPool) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CIDRPool.ProtoReflect.Descriptor instead.
+func (*CIDRPool) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CIDRPool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CIDRPool) GetCIDR() string {
+	if x != nil {
+		return x.CIDR
+	}
+	return ""
+}
+
+func (x *CIDRPool) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type GetCIDRPoolsUsageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pools []*CIDRPoolUsage `protobuf:"bytes,1,rep,name=Pools,proto3" json:"Pools,omitempty"`
+}
+
+func (x *GetCIDRPoolsUsageResponse) Reset() {
+	*x = GetCIDRPoolsUsageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCIDRPoolsUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCIDRPoolsUsageResponse) ProtoMessage() {}
+
+func (x *GetCIDRPoolsUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCIDRPoolsUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetCIDRPoolsUsageResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetCIDRPoolsUsageResponse) GetPools() []*CIDRPoolUsage {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+type CIDRPoolUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pool *CIDRPool `protobuf:"bytes,1,opt,name=Pool,proto3" json:"Pool,omitempty"`
+	// lab and segment networks acquired from the pool
+	ChildCIDRs []string `protobuf:"bytes,2,rep,name=ChildCIDRs,proto3" json:"ChildCIDRs,omitempty"`
+	// percentage of the pool addresses in the child networks
+	Utilization float64 `protobuf:"fixed64,3,opt,name=Utilization,proto3" json:"Utilization,omitempty"`
+}
+
+func (x *CIDRPoolUsage) Reset() {
+	*x = CIDRPoolUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CIDRPoolUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CIDRPoolUsage) ProtoMessage() {}
+
+func (x *CIDRPoolUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CIDRPoolUsage.ProtoReflect.Descriptor instead.
+func (*CIDRPoolUsage) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *CIDRPoolUsage) GetPool() *CIDRPool {
+	if x != nil {
+		return x.Pool
+	}
+	return nil
+}
+
+func (x *CIDRPoolUsage) GetChildCIDRs() []string {
+	if x != nil {
+		return x.ChildCIDRs
+	}
+	return nil
+}
+
+func (x *CIDRPoolUsage) GetUtilization() float64 {
+	if x != nil {
+		return x.Utilization
+	}
+	return 0
+}
+
+var File_agent_proto protoreflect.FileDescriptor
+
+var file_agent_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x22, 0x0e, 0x0a, 0x0c, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x0f, 0x0a, 0x0d, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xc9, 0x05, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x43,
+	0x49, 0x44, 0x52, 0x4d, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x43,
+	0x49, 0x44, 0x52, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a,
+	0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x12,
+	0x1e, 0x0a, 0x0a, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x49, 0x44, 0x12,
+	0x4a, 0x0a, 0x10, 0x46, 0x6c, 0x61, 0x67, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62,
+	0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x45, 0x6e,
+	0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x10, 0x46, 0x6c, 0x61, 0x67, 0x45,
+	0x6e, 0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x51,
+	0x75, 0x6f, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x61,
+	0x52, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x42, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x24, 0x0a, 0x0d, 0x49,
+	0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0d, 0x49, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x2c, 0x0a, 0x06, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x06, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x2d, 0x0a, 0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x53, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x22,
+	0x0a, 0x0c, 0x49, 0x50, 0x76, 0x36, 0x43, 0x49, 0x44, 0x52, 0x4d, 0x61, 0x73, 0x6b, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x49, 0x50, 0x76, 0x36, 0x43, 0x49, 0x44, 0x52, 0x4d, 0x61,
+	0x73, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x14,
+	0x0a, 0x05, 0x43, 0x49, 0x44, 0x52, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x43,
+	0x49, 0x44, 0x52, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x56, 0x50, 0x4e, 0x47, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x56, 0x50, 0x4e, 0x47, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x53, 0x48, 0x42, 0x61, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x53, 0x53, 0x48, 0x42, 0x61, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x42, 0x6f,
+	0x78, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x42, 0x6f, 0x78, 0x52, 0x09, 0x41, 0x74, 0x74, 0x61, 0x63,
+	0x6b, 0x42, 0x6f, 0x78, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x65, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x42, 0x6f, 0x78, 0x12, 0x14,
+	0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x2e, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x09, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x6e, 0x0a, 0x0a, 0x4c, 0x61, 0x62, 0x53,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x49,
+	0x44, 0x52, 0x4d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x43, 0x49,
+	0x44, 0x52, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x49, 0x44, 0x52, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x43, 0x49, 0x44, 0x52, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x6c,
+	0x6c, 0x6f, 0x77, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x41,
+	0x6c, 0x6c, 0x6f, 0x77, 0x46, 0x72, 0x6f, 0x6d, 0x22, 0x5d, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x49, 0x44, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x49, 0x44, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x61, 0x62,
+	0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x53,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x53,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0xb8, 0x01, 0x0a, 0x18, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x4c, 0x61, 0x62, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x61, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x4c, 0x61, 0x62, 0x49, 0x44, 0x12, 0x49, 0x0a, 0x08, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x8f, 0x01, 0x0a, 0x0f, 0x4d, 0x6f, 0x76, 0x65, 0x4c, 0x61, 0x62, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x03, 0x49, 0x44, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x73,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c,
+	0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x2c, 0x0a, 0x11, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x49, 0x44, 0x22, 0x93, 0x01, 0x0a, 0x1b, 0x53, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73,
+	0x49, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x03, 0x49, 0x44, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x61, 0x62,
+	0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x49, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x49, 0x73, 0x6f,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0x9b, 0x01, 0x0a, 0x1b, 0x53,
+	0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x49, 0x44,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x49, 0x44, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x12, 0x1a,
+	0x0a, 0x08, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x2c, 0x0a, 0x06, 0x45, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x52, 0x06, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x8c, 0x01, 0x0a, 0x14, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x4c, 0x61, 0x62, 0x73, 0x49, 0x50, 0x41, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03,
+	0x49, 0x44, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x12, 0x24, 0x0a, 0x0d, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4c, 0x65, 0x61, 0x6b,
+	0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x4c, 0x65, 0x61, 0x6b, 0x65, 0x64, 0x22, 0x41, 0x0a, 0x11, 0x4c, 0x61, 0x62, 0x56, 0x50,
+	0x4e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x4c, 0x61, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x4c, 0x61, 0x62,
+	0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x65, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x50, 0x65, 0x65, 0x72, 0x49, 0x44, 0x22, 0x62, 0x0a, 0x10, 0x4c, 0x61,
+	0x62, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x4c, 0x61, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x4c,
+	0x61, 0x62, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x44,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x44,
+	0x12, 0x1c, 0x0a, 0x09, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x22, 0x2b,
+	0x0a, 0x13, 0x4c, 0x61, 0x62, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x42, 0x6f, 0x78, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x61, 0x62, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x4c, 0x61, 0x62, 0x49, 0x44, 0x22, 0x53, 0x0a, 0x0d, 0x45,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x4d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x4d, 0x6f, 0x64, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x43, 0x49, 0x44, 0x52, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x43, 0x49, 0x44, 0x52, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73,
+	0x22, 0xe6, 0x01, 0x0a, 0x18, 0x41, 0x64, 0x64, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c,
+	0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x4c, 0x61, 0x62, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x4c,
+	0x61, 0x62, 0x49, 0x44, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x61, 0x62, 0x73,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x12, 0x30, 0x0a, 0x0a, 0x43, 0x68, 0x61, 0x6c, 0x6c,
+	0x65, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x0a, 0x43,
+	0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x10, 0x46, 0x6c, 0x61,
+	0x67, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x46, 0x6c, 0x61, 0x67,
+	0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x10, 0x46, 0x6c, 0x61,
+	0x67, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x91, 0x01, 0x0a, 0x15, 0x4c, 0x61,
+	0x62, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x49, 0x44, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4c,
+	0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x12, 0x22, 0x0a,
+	0x0c, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x44, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0c, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x44,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x34, 0x0a,
+	0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x04, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x52, 0x04, 0x4c,
+	0x61, 0x62, 0x73, 0x22, 0x31, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x04, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62,
+	0x52, 0x04, 0x4c, 0x61, 0x62, 0x73, 0x22, 0x38, 0x0a, 0x12, 0x4c, 0x61, 0x62, 0x56, 0x50, 0x4e,
+	0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x22, 0x4b, 0x0a, 0x11, 0x4c, 0x61, 0x62, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x7e, 0x0a,
+	0x1a, 0x4c, 0x61, 0x62, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x42, 0x6f, 0x78, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x55,
+	0x52, 0x4c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x55, 0x52, 0x4c, 0x12, 0x1a, 0x0a,
+	0x08, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x41, 0x0a,
+	0x15, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x73, 0x49, 0x50, 0x41, 0x4d, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62,
+	0x49, 0x50, 0x41, 0x4d, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x04, 0x4c, 0x61, 0x62, 0x73,
+	0x22, 0xb2, 0x01, 0x0a, 0x0d, 0x4c, 0x61, 0x62, 0x49, 0x50, 0x41, 0x4d, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x49, 0x44, 0x12, 0x2f, 0x0a, 0x08, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x4c, 0x65, 0x61, 0x6b, 0x65, 0x64, 0x49, 0x50, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x4c, 0x65, 0x61, 0x6b, 0x65, 0x64, 0x49, 0x50,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x55, 0x6e, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x49, 0x50, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x55, 0x6e, 0x61, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x49, 0x50, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x52, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x64, 0x22, 0x5e, 0x0a, 0x0c, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x49, 0x44, 0x52, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x43, 0x49, 0x44, 0x52, 0x12, 0x18, 0x0a, 0x07, 0x55, 0x73, 0x65,
+	0x64, 0x49, 0x50, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x55, 0x73, 0x65, 0x64,
+	0x49, 0x50, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x55, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x55, 0x74, 0x69, 0x6c, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3a, 0x0a, 0x12, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x4c,
+	0x61, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x4c, 0x61, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x4c, 0x61, 0x62,
+	0x73, 0x22, 0xd1, 0x04, 0x0a, 0x03, 0x4c, 0x61, 0x62, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x49, 0x44, 0x52, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x43, 0x49, 0x44, 0x52, 0x12, 0x34, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x4c, 0x61, 0x62, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x24, 0x0a,
+	0x0d, 0x49, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x49, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x6f, 0x64, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x06, 0x45, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x2d, 0x0a, 0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x53,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x49, 0x50, 0x76, 0x36, 0x43, 0x49, 0x44, 0x52, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x49, 0x50, 0x76, 0x36, 0x43, 0x49, 0x44, 0x52, 0x12, 0x1a, 0x0a, 0x08,
+	0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x56, 0x50, 0x4e, 0x47,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x56, 0x50,
+	0x4e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x53, 0x48, 0x42,
+	0x61, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x53, 0x53,
+	0x48, 0x42, 0x61, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x61,
+	0x63, 0x6b, 0x42, 0x6f, 0x78, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x42, 0x6f, 0x78, 0x52, 0x09, 0x41,
+	0x74, 0x74, 0x61, 0x63, 0x6b, 0x42, 0x6f, 0x78, 0x12, 0x30, 0x0a, 0x0a, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x55, 0x52, 0x4c, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x55, 0x52, 0x4c, 0x52, 0x0a,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x55, 0x52, 0x4c, 0x73, 0x12, 0x3c, 0x0a, 0x0e, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x0e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x65, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5f, 0x0a, 0x09, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x55,
+	0x52, 0x4c, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x55, 0x52, 0x4c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x55, 0x52, 0x4c, 0x22, 0x9b, 0x01, 0x0a, 0x0d, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6c,
+	0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43,
+	0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f,
+	0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x22, 0xcc, 0x01, 0x0a, 0x09, 0x4c, 0x61, 0x62, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04,
+	0x43, 0x49, 0x44, 0x52, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x43, 0x49, 0x44, 0x52,
+	0x12, 0x22, 0x0a, 0x03, 0x44, 0x4e, 0x53, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x44, 0x4e, 0x53, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x03, 0x44, 0x4e, 0x53, 0x12, 0x33, 0x0a, 0x09, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x09,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x51, 0x75, 0x6f,
+	0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x51, 0x75,
+	0x6f, 0x74, 0x61, 0x22, 0x61, 0x0a, 0x0b, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x48, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x52, 0x04, 0x48, 0x61, 0x72, 0x64, 0x12, 0x28, 0x0a, 0x04,
+	0x55, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x61,
+	0x52, 0x04, 0x55, 0x73, 0x65, 0x64, 0x22, 0x6b, 0x0a, 0x09, 0x44, 0x4e, 0x53, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x22, 0xb6, 0x01, 0x0a, 0x0e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x68, 0x61,
+	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x09, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x52, 0x4c, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x55, 0x52, 0x4c, 0x73, 0x22, 0x4a, 0x0a, 0x09,
+	0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x2d, 0x0a, 0x09, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x90, 0x08, 0x0a, 0x08, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x52, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x04, 0x45,
+	0x6e, 0x76, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x04, 0x45,
+	0x6e, 0x76, 0x73, 0x12, 0x2a, 0x0a, 0x07, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x44, 0x4e, 0x53,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12,
+	0x2c, 0x0a, 0x06, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x06, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x09, 0x41, 0x6c, 0x6c,
+	0x6f, 0x77, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x75, 0x6c, 0x65,
+	0x52, 0x09, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x2c, 0x0a, 0x07, 0x41,
+	0x6c, 0x6c, 0x6f, 0x77, 0x54, 0x6f, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x75, 0x6c, 0x65,
+	0x52, 0x07, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x54, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x50, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x50, 0x12, 0x1a, 0x0a, 0x08, 0x49, 0x50, 0x4f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x49, 0x50, 0x4f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x48, 0x54, 0x54, 0x50, 0x50, 0x6f, 0x72,
+	0x74, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x48, 0x54, 0x54, 0x50, 0x50, 0x6f,
+	0x72, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x0e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64,
+	0x50, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x0e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x65, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x72,
+	0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x61,
+	0x70, 0x41, 0x64, 0x64, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x43, 0x61, 0x70,
+	0x41, 0x64, 0x64, 0x73, 0x12, 0x34, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x73,
+	0x73, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x0e, 0x52, 0x65, 0x61, 0x64,
+	0x69, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x32, 0x0a, 0x0d, 0x4c, 0x69,
+	0x76, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52,
+	0x0d, 0x4c, 0x69, 0x76, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x30,
+	0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x13, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x52, 0x0a, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73,
+	0x12, 0x38, 0x0a, 0x0e, 0x49, 0x6e, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x0e, 0x49, 0x6e, 0x69, 0x74,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x15, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x41, 0x72, 0x67, 0x73, 0x18, 0x16, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x04, 0x41, 0x72, 0x67, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x57, 0x6f, 0x72, 0x6b,
+	0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x57, 0x6f,
+	0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x33, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x18, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x0e, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x22, 0x0a,
+	0x05, 0x52, 0x75, 0x6e, 0x41, 0x73, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x75, 0x6e, 0x41, 0x73, 0x52, 0x05, 0x52, 0x75, 0x6e, 0x41,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x1a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a,
+	0x08, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x52, 0x08, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x39, 0x0a, 0x05, 0x52,
+	0x75, 0x6e, 0x41, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x49, 0x44, 0x22, 0xe9, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x26,
+	0x0a, 0x04, 0x45, 0x6e, 0x76, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x52, 0x04, 0x45, 0x6e, 0x76, 0x73, 0x12, 0x2e, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x09, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x41, 0x72, 0x67, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x41, 0x72, 0x67, 0x73, 0x12, 0x2c, 0x0a, 0x08, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x08, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x22, 0xf7, 0x01, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x54, 0x54, 0x50, 0x50, 0x61,
+	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x48, 0x54, 0x54, 0x50, 0x50, 0x61,
+	0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x54, 0x54, 0x50, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x48, 0x54, 0x54, 0x50, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x54, 0x43, 0x50, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x54, 0x43, 0x50, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x30, 0x0a, 0x13, 0x49, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65,
+	0x6c, 0x61, 0x79, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x50, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0d, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x12, 0x2a, 0x0a, 0x10, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0x50, 0x0a, 0x0f,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x48, 0x65, 0x61, 0x64, 0x6c, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x48, 0x65, 0x61, 0x64, 0x6c, 0x65, 0x73, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x50,
+	0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x05, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x52,
+	0x0a, 0x0b, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0b, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x73, 0x12,
+	0x21, 0x0a, 0x05, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b,
+	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x05, 0x50, 0x6f, 0x72,
+	0x74, 0x73, 0x22, 0x36, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f,
+	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x22, 0x61, 0x0a, 0x09, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x43, 0x50, 0x55, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x43, 0x50,
+	0x55, 0x12, 0x2a, 0x0a, 0x10, 0x45, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x45, 0x70, 0x68,
+	0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x22, 0x79, 0x0a,
+	0x0d, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x10,
+	0x0a, 0x03, 0x43, 0x50, 0x55, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x43, 0x50, 0x55,
+	0x12, 0x16, 0x0a, 0x06, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x64, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x50, 0x6f, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x10,
+	0x45, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x45, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61,
+	0x6c, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x22, 0x37, 0x0a, 0x0b, 0x45, 0x6e, 0x76, 0x56,
+	0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0x99, 0x01, 0x0a, 0x0f, 0x46, 0x6c, 0x61, 0x67, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x61, 0x62, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x4c, 0x61, 0x62, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x43,
+	0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x44, 0x12, 0x1e, 0x0a,
+	0x0a, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x12, 0x1a, 0x0a,
+	0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x6c, 0x61,
+	0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x46, 0x6c, 0x61, 0x67, 0x22, 0xa7, 0x01,
+	0x0a, 0x17, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x45, 0x6e,
+	0x76, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x61, 0x62,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x4c, 0x61, 0x62,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x68, 0x61, 0x6c,
+	0x6c, 0x65, 0x6e, 0x67, 0x65, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x6c, 0x61, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x46, 0x6c, 0x61, 0x67, 0x22, 0x47, 0x0a, 0x09, 0x44, 0x4e, 0x53, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x44, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61,
+	0x22, 0x5d, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a,
+	0x0a, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x52, 0x0a, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x22,
+	0x45, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x54, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x08, 0x54, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x22, 0x2a, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61,
+	0x6d, 0x65, 0x22, 0x46, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x54,
+	0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52,
+	0x09, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x22, 0x27, 0x0a, 0x15, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x49, 0x44, 0x22, 0x7a, 0x0a, 0x08, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12,
+	0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a,
+	0x0a, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x52, 0x0a, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x22,
+	0x3a, 0x0a, 0x10, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x3b, 0x0a, 0x11, 0x4c,
+	0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x26, 0x0a, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x52, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x28, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4c,
+	0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x49,
+	0x44, 0x73, 0x22, 0x41, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x28, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c,
+	0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x22,
+	0xaa, 0x04, 0x0a, 0x09, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x0e, 0x0a,
+	0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x12, 0x0a,
+	0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x72, 0x74, 0x73, 0x41, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x53, 0x74, 0x61, 0x72, 0x74, 0x73, 0x41, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x45, 0x6e, 0x64, 0x73, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x45, 0x6e, 0x64, 0x73, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x49, 0x44, 0x52, 0x4d,
+	0x61, 0x73, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x43, 0x49, 0x44, 0x52, 0x4d,
+	0x61, 0x73, 0x6b, 0x12, 0x2a, 0x0a, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x52, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12,
+	0x18, 0x0a, 0x07, 0x4d, 0x61, 0x78, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x4d, 0x61, 0x78, 0x4c, 0x61, 0x62, 0x73, 0x12, 0x34, 0x0a, 0x06, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x2e, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12,
+	0x24, 0x0a, 0x0d, 0x49, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x49, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f,
+	0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f,
+	0x6c, 0x12, 0x28, 0x0a, 0x0f, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c,
+	0x65, 0x67, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x41, 0x6c, 0x6c, 0x6f,
+	0x77, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x64, 0x12, 0x2e, 0x0a, 0x12, 0x43,
+	0x50, 0x55, 0x4f, 0x76, 0x65, 0x72, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x61, 0x74, 0x69,
+	0x6f, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x43, 0x50, 0x55, 0x4f, 0x76, 0x65, 0x72,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x34, 0x0a, 0x15, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4f, 0x76, 0x65, 0x72, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52,
+	0x61, 0x74, 0x69, 0x6f, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x15, 0x4d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x4f, 0x76, 0x65, 0x72, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x61, 0x74, 0x69,
+	0x6f, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3c, 0x0a, 0x12,
+	0x41, 0x64, 0x64, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x49, 0x44, 0x52, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x43, 0x49, 0x44, 0x52, 0x22, 0x37, 0x0a, 0x10, 0x43, 0x49,
+	0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23,
+	0x0a, 0x04, 0x50, 0x6f, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x04, 0x50,
+	0x6f, 0x6f, 0x6c, 0x22, 0x3d, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f,
+	0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x50,
+	0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x05, 0x50, 0x6f, 0x6f,
+	0x6c, 0x73, 0x22, 0x50, 0x0a, 0x08, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x49, 0x44, 0x52, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x43, 0x49, 0x44, 0x52, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0x47, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x43, 0x49, 0x44, 0x52, 0x50,
+	0x6f, 0x6f, 0x6c, 0x73, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2a, 0x0a, 0x05, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f,
+	0x6c, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x22, 0x76, 0x0a,
+	0x0d, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23,
+	0x0a, 0x04, 0x50, 0x6f, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x04, 0x50,
+	0x6f, 0x6f, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x43, 0x49, 0x44, 0x52,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x43, 0x49,
+	0x44, 0x52, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x55, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x55, 0x74, 0x69, 0x6c, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x85, 0x13, 0x0a, 0x05, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12,
+	0x33, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x13, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69,
+	0x6e, 0x67, 0x12, 0x13, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4c,
+	0x61, 0x62, 0x73, 0x12, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x43, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x73, 0x12,
+	0x18, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x61,
+	0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x4c, 0x61, 0x62, 0x73, 0x12, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x36, 0x0a, 0x08, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x61, 0x62, 0x73, 0x12, 0x12, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x4c, 0x61, 0x62, 0x73, 0x12, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61,
+	0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x4c, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x3a, 0x0a, 0x08, 0x4d, 0x6f, 0x76, 0x65, 0x4c, 0x61, 0x62, 0x73, 0x12, 0x16, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x14, 0x53,
+	0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x49, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x6f, 0x64, 0x65, 0x12, 0x22, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x65, 0x74, 0x4c,
+	0x61, 0x62, 0x73, 0x49, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x52, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x22, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x53, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x73,
+	0x49, 0x50, 0x41, 0x4d, 0x12, 0x1b, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x4c, 0x61, 0x62, 0x73, 0x49, 0x50, 0x41, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4c,
+	0x61, 0x62, 0x73, 0x49, 0x50, 0x41, 0x4d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x46, 0x0a, 0x0d, 0x41, 0x64, 0x64, 0x4c, 0x61, 0x62, 0x56, 0x50, 0x4e, 0x50, 0x65,
+	0x65, 0x72, 0x12, 0x18, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x56, 0x50,
+	0x4e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x56, 0x50, 0x4e, 0x50, 0x65, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x10, 0x52, 0x65, 0x76,
+	0x6f, 0x6b, 0x65, 0x4c, 0x61, 0x62, 0x56, 0x50, 0x4e, 0x50, 0x65, 0x65, 0x72, 0x12, 0x18, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x56, 0x50, 0x4e, 0x50, 0x65, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x43, 0x0a, 0x0c, 0x41, 0x64, 0x64, 0x4c, 0x61, 0x62, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x12,
+	0x17, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x53, 0x53, 0x48, 0x4b, 0x65,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x4c, 0x61, 0x62, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4c, 0x61,
+	0x62, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x12, 0x17, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x4c, 0x61, 0x62, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4c,
+	0x61, 0x62, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x42, 0x6f, 0x78, 0x41, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x12, 0x1a, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x41, 0x74, 0x74,
+	0x61, 0x63, 0x6b, 0x42, 0x6f, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x42,
+	0x6f, 0x78, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x40, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x41,
+	0x74, 0x74, 0x61, 0x63, 0x6b, 0x42, 0x6f, 0x78, 0x12, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x61, 0x62, 0x73,
+	0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x42, 0x6f, 0x78, 0x12, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x4c, 0x61, 0x62, 0x73,
+	0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x41, 0x64, 0x64, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x61, 0x62,
+	0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x4b, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68,
+	0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a,
+	0x0a, 0x12, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62,
+	0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x13, 0x52, 0x65,
+	0x73, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x73, 0x12, 0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x43, 0x68,
+	0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46,
+	0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x12, 0x17, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x17, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x73, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c,
+	0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12,
+	0x1b, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0f,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x1d, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x61,
+	0x62, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
+	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0b, 0x41, 0x64, 0x64, 0x43, 0x49, 0x44,
+	0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x19, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x41, 0x64,
+	0x64, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0c, 0x47,
+	0x65, 0x74, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x13, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x49, 0x44, 0x52,
+	0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x4c, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x13, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x49, 0x44, 0x52, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x55, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x3b, 0x5a,
+	0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x79, 0x62, 0x65,
+	0x72, 0x69, 0x63, 0x65, 0x62, 0x6f, 0x78, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x67, 0x72, 0x70,
+	0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_agent_proto_rawDescOnce sync.Once
+	file_agent_proto_rawDescData = file_agent_proto_rawDesc
+)
+
+func file_agent_proto_rawDescGZIP() []byte {
+	file_agent_proto_rawDescOnce.Do(func() {
+		file_agent_proto_rawDescData = protoimpl.X.CompressGZIP(file_agent_proto_rawDescData)
+	})
+	return file_agent_proto_rawDescData
+}
+
+var file_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 69)
+var file_agent_proto_goTypes = []interface{}{
+	(*EmptyRequest)(nil),                // 0: agent.EmptyRequest
+	(*EmptyResponse)(nil),               // 1: agent.EmptyResponse
+	(*CreateLabsRequest)(nil),           // 2: agent.CreateLabsRequest
+	(*AttackBox)(nil),                   // 3: agent.AttackBox
+	(*LabSegment)(nil),                  // 4: agent.LabSegment
+	(*LabsRequest)(nil),                 // 5: agent.LabsRequest
+	(*UpdateLabMetadataRequest)(nil),    // 6: agent.UpdateLabMetadataRequest
+	(*MoveLabsRequest)(nil),             // 7: agent.MoveLabsRequest
+	(*SetLabsIsolationModeRequest)(nil), // 8: agent.SetLabsIsolationModeRequest
+	(*SetLabsEgressProfileRequest)(nil), // 9: agent.SetLabsEgressProfileRequest
+	(*CheckLabsIPAMRequest)(nil),        // 10: agent.CheckLabsIPAMRequest
+	(*LabVPNPeerRequest)(nil),           // 11: agent.LabVPNPeerRequest
+	(*LabSSHKeyRequest)(nil),            // 12: agent.LabSSHKeyRequest
+	(*LabAttackBoxRequest)(nil),         // 13: agent.LabAttackBoxRequest
+	(*EgressProfile)(nil),               // 14: agent.EgressProfile
+	(*AddLabsChallengesRequest)(nil),    // 15: agent.AddLabsChallengesRequest
+	(*LabsChallengesRequest)(nil),       // 16: agent.LabsChallengesRequest
+	(*CreateLabsResponse)(nil),          // 17: agent.CreateLabsResponse
+	(*GetLabsResponse)(nil),             // 18: agent.GetLabsResponse
+	(*LabVPNPeerResponse)(nil),          // 19: agent.LabVPNPeerResponse
+	(*LabSSHKeyResponse)(nil),           // 20: agent.LabSSHKeyResponse
+	(*LabAttackBoxAccessResponse)(nil),  // 21: agent.LabAttackBoxAccessResponse
+	(*CheckLabsIPAMResponse)(nil),       // 22: agent.CheckLabsIPAMResponse
+	(*LabIPAMReport)(nil),               // 23: agent.LabIPAMReport
+	(*NetworkUsage)(nil),                // 24: agent.NetworkUsage
+	(*MonitoringResponse)(nil),          // 25: agent.MonitoringResponse
+	(*Lab)(nil),                         // 26: agent.Lab
+	(*PublicURL)(nil),                   // 27: agent.PublicURL
+	(*PublishedPort)(nil),               // 28: agent.PublishedPort
+	(*LabStatus)(nil),                   // 29: agent.LabStatus
+	(*QuotaStatus)(nil),                 // 30: agent.QuotaStatus
+	(*DNSStatus)(nil),                   // 31: agent.DNSStatus
+	(*InstanceStatus)(nil),              // 32: agent.InstanceStatus
+	(*Challenge)(nil),                   // 33: agent.Challenge
+	(*Instance)(nil),                    // 34: agent.Instance
+	(*RunAs)(nil),                       // 35: agent.RunAs
+	(*Container)(nil),                   // 36: agent.Container
+	(*Probe)(nil),                       // 37: agent.Probe
+	(*InstanceService)(nil),             // 38: agent.InstanceService
+	(*NetworkRule)(nil),                 // 39: agent.NetworkRule
+	(*Port)(nil),                        // 40: agent.Port
+	(*Resources)(nil),                   // 41: agent.Resources
+	(*ResourceQuota)(nil),               // 42: agent.ResourceQuota
+	(*EnvVariable)(nil),                 // 43: agent.EnvVariable
+	(*FlagEnvVariable)(nil),             // 44: agent.FlagEnvVariable
+	(*TemplateFlagEnvVariable)(nil),     // 45: agent.TemplateFlagEnvVariable
+	(*DNSRecord)(nil),                   // 46: agent.DNSRecord
+	(*CreateTemplateRequest)(nil),       // 47: agent.CreateTemplateRequest
+	(*CreateTemplateResponse)(nil),      // 48: agent.CreateTemplateResponse
+	(*ListTemplatesRequest)(nil),        // 49: agent.ListTemplatesRequest
+	(*ListTemplatesResponse)(nil),       // 50: agent.ListTemplatesResponse
+	(*DeleteTemplateRequest)(nil),       // 51: agent.DeleteTemplateRequest
+	(*Template)(nil),                    // 52: agent.Template
+	(*LabsGroupRequest)(nil),            // 53: agent.LabsGroupRequest
+	(*LabsGroupResponse)(nil),           // 54: agent.LabsGroupResponse
+	(*GetLabsGroupsRequest)(nil),        // 55: agent.GetLabsGroupsRequest
+	(*GetLabsGroupsResponse)(nil),       // 56: agent.GetLabsGroupsResponse
+	(*DeleteLabsGroupRequest)(nil),      // 57: agent.DeleteLabsGroupRequest
+	(*LabsGroup)(nil),                   // 58: agent.LabsGroup
+	(*AddCIDRPoolRequest)(nil),          // 59: agent.AddCIDRPoolRequest
+	(*CIDRPoolResponse)(nil),            // 60: agent.CIDRPoolResponse
+	(*GetCIDRPoolsResponse)(nil),        // 61: agent.GetCIDRPoolsResponse
+	(*CIDRPool)(nil),                    // 62: agent.CIDRPool
+	(*GetCIDRPoolsUsageResponse)(nil),   // 63: agent.GetCIDRPoolsUsageResponse
+	(*CIDRPoolUsage)(nil),               // 64: agent.CIDRPoolUsage
+	nil,                                 // 65: agent.CreateLabsRequest.MetadataEntry
+	nil,                                 // 66: agent.UpdateLabMetadataRequest.MetadataEntry
+	nil,                                 // 67: agent.Lab.MetadataEntry
+	nil,                                 // 68: agent.LabsGroup.LabelsEntry
+}
+var file_agent_proto_depIdxs = []int32{
+	45, // 0: agent.CreateLabsRequest.FlagEnvVariables:type_name -> agent.TemplateFlagEnvVariable
+	42, // 1: agent.CreateLabsRequest.Quota:type_name -> agent.ResourceQuota
+	65, // 2: agent.CreateLabsRequest.Metadata:type_name -> agent.CreateLabsRequest.MetadataEntry
+	14, // 3: agent.CreateLabsRequest.Egress:type_name -> agent.EgressProfile
+	4,  // 4: agent.CreateLabsRequest.Segments:type_name -> agent.LabSegment
+	3,  // 5: agent.CreateLabsRequest.AttackBox:type_name -> agent.AttackBox
+	41, // 6: agent.AttackBox.Resources:type_name -> agent.Resources
+	66, // 7: agent.UpdateLabMetadataRequest.Metadata:type_name -> agent.UpdateLabMetadataRequest.MetadataEntry
+	14, // 8: agent.SetLabsEgressProfileRequest.Egress:type_name -> agent.EgressProfile
+	33, // 9: agent.AddLabsChallengesRequest.Challenges:type_name -> agent.Challenge
+	44, // 10: agent.AddLabsChallengesRequest.FlagEnvVariables:type_name -> agent.FlagEnvVariable
+	26, // 11: agent.CreateLabsResponse.Labs:type_name -> agent.Lab
+	26, // 12: agent.GetLabsResponse.Labs:type_name -> agent.Lab
+	23, // 13: agent.CheckLabsIPAMResponse.Labs:type_name -> agent.LabIPAMReport
+	24, // 14: agent.LabIPAMReport.Networks:type_name -> agent.NetworkUsage
+	29, // 15: agent.MonitoringResponse.Labs:type_name -> agent.LabStatus
+	67, // 16: agent.Lab.Metadata:type_name -> agent.Lab.MetadataEntry
+	14, // 17: agent.Lab.Egress:type_name -> agent.EgressProfile
+	4,  // 18: agent.Lab.Segments:type_name -> agent.LabSegment
+	3,  // 19: agent.Lab.AttackBox:type_name -> agent.AttackBox
+	27, // 20: agent.Lab.PublicURLs:type_name -> agent.PublicURL
+	28, // 21: agent.Lab.PublishedPorts:type_name -> agent.PublishedPort
+	31, // 22: agent.LabStatus.DNS:type_name -> agent.DNSStatus
+	32, // 23: agent.LabStatus.Instances:type_name -> agent.InstanceStatus
+	30, // 24: agent.LabStatus.Quota:type_name -> agent.QuotaStatus
+	42, // 25: agent.QuotaStatus.Hard:type_name -> agent.ResourceQuota
+	42, // 26: agent.QuotaStatus.Used:type_name -> agent.ResourceQuota
+	41, // 27: agent.DNSStatus.Resources:type_name -> agent.Resources
+	41, // 28: agent.InstanceStatus.Resources:type_name -> agent.Resources
+	34, // 29: agent.Challenge.Instances:type_name -> agent.Instance
+	41, // 30: agent.Instance.Resources:type_name -> agent.Resources
+	43, // 31: agent.Instance.Envs:type_name -> agent.EnvVariable
+	46, // 32: agent.Instance.Records:type_name -> agent.DNSRecord
+	14, // 33: agent.Instance.Egress:type_name -> agent.EgressProfile
+	39, // 34: agent.Instance.AllowFrom:type_name -> agent.NetworkRule
+	39, // 35: agent.Instance.AllowTo:type_name -> agent.NetworkRule
+	40, // 36: agent.Instance.PublishedPorts:type_name -> agent.Port
+	38, // 37: agent.Instance.Service:type_name -> agent.InstanceService
+	37, // 38: agent.Instance.ReadinessProbe:type_name -> agent.Probe
+	37, // 39: agent.Instance.LivenessProbe:type_name -> agent.Probe
+	36, // 40: agent.Instance.Containers:type_name -> agent.Container
+	36, // 41: agent.Instance.InitContainers:type_name -> agent.Container
+	40, // 42: agent.Instance.ContainerPorts:type_name -> agent.Port
+	35, // 43: agent.Instance.RunAs:type_name -> agent.RunAs
+	41, // 44: agent.Instance.Requests:type_name -> agent.Resources
+	43, // 45: agent.Container.Envs:type_name -> agent.EnvVariable
+	41, // 46: agent.Container.Resources:type_name -> agent.Resources
+	41, // 47: agent.Container.Requests:type_name -> agent.Resources
+	40, // 48: agent.InstanceService.Ports:type_name -> agent.Port
+	40, // 49: agent.NetworkRule.Ports:type_name -> agent.Port
+	33, // 50: agent.CreateTemplateRequest.Challenges:type_name -> agent.Challenge
+	52, // 51: agent.CreateTemplateResponse.Template:type_name -> agent.Template
+	52, // 52: agent.ListTemplatesResponse.Templates:type_name -> agent.Template
+	33, // 53: agent.Template.Challenges:type_name -> agent.Challenge
+	58, // 54: agent.LabsGroupRequest.Group:type_name -> agent.LabsGroup
+	58, // 55: agent.LabsGroupResponse.Group:type_name -> agent.LabsGroup
+	58, // 56: agent.GetLabsGroupsResponse.Groups:type_name -> agent.LabsGroup
+	42, // 57: agent.LabsGroup.Quota:type_name -> agent.ResourceQuota
+	68, // 58: agent.LabsGroup.Labels:type_name -> agent.LabsGroup.LabelsEntry
+	62, // 59: agent.CIDRPoolResponse.Pool:type_name -> agent.CIDRPool
+	62, // 60: agent.GetCIDRPoolsResponse.Pools:type_name -> agent.CIDRPool
+	64, // 61: agent.GetCIDRPoolsUsageResponse.Pools:type_name -> agent.CIDRPoolUsage
+	62, // 62: agent.CIDRPoolUsage.Pool:type_name -> agent.CIDRPool
+	0,  // 63: agent.Agent.Ping:input_type -> agent.EmptyRequest
+	0,  // 64: agent.Agent.Monitoring:input_type -> agent.EmptyRequest
+	5,  // 65: agent.Agent.GetLabs:input_type -> agent.LabsRequest
+	2,  // 66: agent.Agent.CreateLabs:input_type -> agent.CreateLabsRequest
+	5,  // 67: agent.Agent.DeleteLabs:input_type -> agent.LabsRequest
+	5,  // 68: agent.Agent.StopLabs:input_type -> agent.LabsRequest
+	5,  // 69: agent.Agent.StartLabs:input_type -> agent.LabsRequest
+	6,  // 70: agent.Agent.UpdateLabMetadata:input_type -> agent.UpdateLabMetadataRequest
+	7,  // 71: agent.Agent.MoveLabs:input_type -> agent.MoveLabsRequest
+	8,  // 72: agent.Agent.SetLabsIsolationMode:input_type -> agent.SetLabsIsolationModeRequest
+	9,  // 73: agent.Agent.SetLabsEgressProfile:input_type -> agent.SetLabsEgressProfileRequest
+	10, // 74: agent.Agent.CheckLabsIPAM:input_type -> agent.CheckLabsIPAMRequest
+	11, // 75: agent.Agent.AddLabVPNPeer:input_type -> agent.LabVPNPeerRequest
+	11, // 76: agent.Agent.RevokeLabVPNPeer:input_type -> agent.LabVPNPeerRequest
+	12, // 77: agent.Agent.AddLabSSHKey:input_type -> agent.LabSSHKeyRequest
+	12, // 78: agent.Agent.RemoveLabSSHKey:input_type -> agent.LabSSHKeyRequest
+	13, // 79: agent.Agent.GetLabAttackBoxAccess:input_type -> agent.LabAttackBoxRequest
+	5,  // 80: agent.Agent.StartLabsAttackBox:input_type -> agent.LabsRequest
+	5,  // 81: agent.Agent.StopLabsAttackBox:input_type -> agent.LabsRequest
+	15, // 82: agent.Agent.AddLabsChallenges:input_type -> agent.AddLabsChallengesRequest
+	16, // 83: agent.Agent.DeleteLabsChallenges:input_type -> agent.LabsChallengesRequest
+	16, // 84: agent.Agent.StartLabsChallenges:input_type -> agent.LabsChallengesRequest
+	16, // 85: agent.Agent.StopLabsChallenges:input_type -> agent.LabsChallengesRequest
+	16, // 86: agent.Agent.ResetLabsChallenges:input_type -> agent.LabsChallengesRequest
+	47, // 87: agent.Agent.CreateTemplate:input_type -> agent.CreateTemplateRequest
+	49, // 88: agent.Agent.ListTemplates:input_type -> agent.ListTemplatesRequest
+	51, // 89: agent.Agent.DeleteTemplate:input_type -> agent.DeleteTemplateRequest
+	53, // 90: agent.Agent.CreateLabsGroup:input_type -> agent.LabsGroupRequest
+	53, // 91: agent.Agent.UpdateLabsGroup:input_type -> agent.LabsGroupRequest
+	55, // 92: agent.Agent.GetLabsGroups:input_type -> agent.GetLabsGroupsRequest
+	57, // 93: agent.Agent.DeleteLabsGroup:input_type -> agent.DeleteLabsGroupRequest
+	59, // 94: agent.Agent.AddCIDRPool:input_type -> agent.AddCIDRPoolRequest
+	0,  // 95: agent.Agent.GetCIDRPools:input_type -> agent.EmptyRequest
+	0,  // 96: agent.Agent.GetCIDRPoolsUsage:input_type -> agent.EmptyRequest
+	1,  // 97: agent.Agent.Ping:output_type -> agent.EmptyResponse
+	25, // 98: agent.Agent.Monitoring:output_type -> agent.MonitoringResponse
+	18, // 99: agent.Agent.GetLabs:output_type -> agent.GetLabsResponse
+	17, // 100: agent.Agent.CreateLabs:output_type -> agent.CreateLabsResponse
+	1,  // 101: agent.Agent.DeleteLabs:output_type -> agent.EmptyResponse
+	1,  // 102: agent.Agent.StopLabs:output_type -> agent.EmptyResponse
+	1,  // 103: agent.Agent.StartLabs:output_type -> agent.EmptyResponse
+	1,  // 104: agent.Agent.UpdateLabMetadata:output_type -> agent.EmptyResponse
+	1,  // 105: agent.Agent.MoveLabs:output_type -> agent.EmptyResponse
+	1,  // 106: agent.Agent.SetLabsIsolationMode:output_type -> agent.EmptyResponse
+	1,  // 107: agent.Agent.SetLabsEgressProfile:output_type -> agent.EmptyResponse
+	22, // 108: agent.Agent.CheckLabsIPAM:output_type -> agent.CheckLabsIPAMResponse
+	19, // 109: agent.Agent.AddLabVPNPeer:output_type -> agent.LabVPNPeerResponse
+	1,  // 110: agent.Agent.RevokeLabVPNPeer:output_type -> agent.EmptyResponse
+	20, // 111: agent.Agent.AddLabSSHKey:output_type -> agent.LabSSHKeyResponse
+	1,  // 112: agent.Agent.RemoveLabSSHKey:output_type -> agent.EmptyResponse
+	21, // 113: agent.Agent.GetLabAttackBoxAccess:output_type -> agent.LabAttackBoxAccessResponse
+	1,  // 114: agent.Agent.StartLabsAttackBox:output_type -> agent.EmptyResponse
+	1,  // 115: agent.Agent.StopLabsAttackBox:output_type -> agent.EmptyResponse
+	1,  // 116: agent.Agent.AddLabsChallenges:output_type -> agent.EmptyResponse
+	1,  // 117: agent.Agent.DeleteLabsChallenges:output_type -> agent.EmptyResponse
+	1,  // 118: agent.Agent.StartLabsChallenges:output_type -> agent.EmptyResponse
+	1,  // 119: agent.Agent.StopLabsChallenges:output_type -> agent.EmptyResponse
+	1,  // 120: agent.Agent.ResetLabsChallenges:output_type -> agent.EmptyResponse
+	48, // 121: agent.Agent.CreateTemplate:output_type -> agent.CreateTemplateResponse
+	50, // 122: agent.Agent.ListTemplates:output_type -> agent.ListTemplatesResponse
+	1,  // 123: agent.Agent.DeleteTemplate:output_type -> agent.EmptyResponse
+	54, // 124: agent.Agent.CreateLabsGroup:output_type -> agent.LabsGroupResponse
+	54, // 125: agent.Agent.UpdateLabsGroup:output_type -> agent.LabsGroupResponse
+	56, // 126: agent.Agent.GetLabsGroups:output_type -> agent.GetLabsGroupsResponse
+	1,  // 127: agent.Agent.DeleteLabsGroup:output_type -> agent.EmptyResponse
+	60, // 128: agent.Agent.AddCIDRPool:output_type -> agent.CIDRPoolResponse
+	61, // 129: agent.Agent.GetCIDRPools:output_type -> agent.GetCIDRPoolsResponse
+	63, // 130: agent.Agent.GetCIDRPoolsUsage:output_type -> agent.GetCIDRPoolsUsageResponse
+	97, // [97:131] is the sub-list for method output_type
+	63, // [63:97] is the sub-list for method input_type
+	63, // [63:63] is the sub-list for extension type_name
+	63, // [63:63] is the sub-list for extension extendee
+	0,  // [0:63] is the sub-list for field type_name
+}
+
+func init() { file_agent_proto_init() }
+func file_agent_proto_init() {
+	if File_agent_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_agent_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmptyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmptyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateLabsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttackBox); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabSegment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateLabMetadataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveLabsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLabsIsolationModeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLabsEgressProfileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckLabsIPAMRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabVPNPeerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabSSHKeyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabAttackBoxRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EgressProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddLabsChallengesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabsChallengesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateLabsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLabsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabVPNPeerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabSSHKeyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabAttackBoxAccessResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckLabsIPAMResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabIPAMReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MonitoringResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1431,8 +5750,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EmptyResponse); i {
+		file_agent_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Lab); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1443,8 +5762,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateLabsRequest); i {
+		file_agent_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublicURL); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1455,8 +5774,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LabsRequest); i {
+		file_agent_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublishedPort); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1467,8 +5786,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddLabsChallengesRequest); i {
+		file_agent_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1479,8 +5798,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LabsChallengesRequest); i {
+		file_agent_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuotaStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1491,8 +5810,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateLabsResponse); i {
+		file_agent_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DNSStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1503,8 +5822,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetLabsResponse); i {
+		file_agent_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstanceStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1515,8 +5834,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MonitoringResponse); i {
+		file_agent_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Challenge); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1527,8 +5846,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Lab); i {
+		file_agent_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Instance); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1539,8 +5858,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LabStatus); i {
+		file_agent_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunAs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1551,8 +5870,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DNSStatus); i {
+		file_agent_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Container); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1563,8 +5882,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InstanceStatus); i {
+		file_agent_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Probe); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1575,8 +5894,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Challenge); i {
+		file_agent_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstanceService); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1587,8 +5906,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Instance); i {
+		file_agent_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkRule); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1599,7 +5918,19 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Port); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Resources); i {
 			case 0:
 				return &v.state
@@ -1611,7 +5942,19 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceQuota); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EnvVariable); i {
 			case 0:
 				return &v.state
@@ -1623,7 +5966,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FlagEnvVariable); i {
 			case 0:
 				return &v.state
@@ -1635,7 +5978,19 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TemplateFlagEnvVariable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DNSRecord); i {
 			case 0:
 				return &v.state
@@ -1647,6 +6002,222 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
+		file_agent_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTemplateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTemplateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTemplatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTemplatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTemplateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Template); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabsGroupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabsGroupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLabsGroupsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLabsGroupsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteLabsGroupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabsGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddCIDRPoolRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CIDRPoolResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCIDRPoolsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CIDRPool); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCIDRPoolsUsageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CIDRPoolUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1654,7 +6225,7 @@ func file_agent_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_agent_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   19,
+			NumMessages:   69,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
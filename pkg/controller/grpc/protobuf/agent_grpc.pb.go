@@ -19,18 +19,40 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	Agent_Ping_FullMethodName                 = "/agent.Agent/Ping"
-	Agent_Monitoring_FullMethodName           = "/agent.Agent/Monitoring"
-	Agent_GetLabs_FullMethodName              = "/agent.Agent/GetLabs"
-	Agent_CreateLabs_FullMethodName           = "/agent.Agent/CreateLabs"
-	Agent_DeleteLabs_FullMethodName           = "/agent.Agent/DeleteLabs"
-	Agent_StopLabs_FullMethodName             = "/agent.Agent/StopLabs"
-	Agent_StartLabs_FullMethodName            = "/agent.Agent/StartLabs"
-	Agent_AddLabsChallenges_FullMethodName    = "/agent.Agent/AddLabsChallenges"
-	Agent_DeleteLabsChallenges_FullMethodName = "/agent.Agent/DeleteLabsChallenges"
-	Agent_StartLabsChallenges_FullMethodName  = "/agent.Agent/StartLabsChallenges"
-	Agent_StopLabsChallenges_FullMethodName   = "/agent.Agent/StopLabsChallenges"
-	Agent_ResetLabsChallenges_FullMethodName  = "/agent.Agent/ResetLabsChallenges"
+	Agent_Ping_FullMethodName                  = "/agent.Agent/Ping"
+	Agent_Monitoring_FullMethodName            = "/agent.Agent/Monitoring"
+	Agent_GetLabs_FullMethodName               = "/agent.Agent/GetLabs"
+	Agent_CreateLabs_FullMethodName            = "/agent.Agent/CreateLabs"
+	Agent_DeleteLabs_FullMethodName            = "/agent.Agent/DeleteLabs"
+	Agent_StopLabs_FullMethodName              = "/agent.Agent/StopLabs"
+	Agent_StartLabs_FullMethodName             = "/agent.Agent/StartLabs"
+	Agent_UpdateLabMetadata_FullMethodName     = "/agent.Agent/UpdateLabMetadata"
+	Agent_MoveLabs_FullMethodName              = "/agent.Agent/MoveLabs"
+	Agent_SetLabsIsolationMode_FullMethodName  = "/agent.Agent/SetLabsIsolationMode"
+	Agent_SetLabsEgressProfile_FullMethodName  = "/agent.Agent/SetLabsEgressProfile"
+	Agent_CheckLabsIPAM_FullMethodName         = "/agent.Agent/CheckLabsIPAM"
+	Agent_AddLabVPNPeer_FullMethodName         = "/agent.Agent/AddLabVPNPeer"
+	Agent_RevokeLabVPNPeer_FullMethodName      = "/agent.Agent/RevokeLabVPNPeer"
+	Agent_AddLabSSHKey_FullMethodName          = "/agent.Agent/AddLabSSHKey"
+	Agent_RemoveLabSSHKey_FullMethodName       = "/agent.Agent/RemoveLabSSHKey"
+	Agent_GetLabAttackBoxAccess_FullMethodName = "/agent.Agent/GetLabAttackBoxAccess"
+	Agent_StartLabsAttackBox_FullMethodName    = "/agent.Agent/StartLabsAttackBox"
+	Agent_StopLabsAttackBox_FullMethodName     = "/agent.Agent/StopLabsAttackBox"
+	Agent_AddLabsChallenges_FullMethodName     = "/agent.Agent/AddLabsChallenges"
+	Agent_DeleteLabsChallenges_FullMethodName  = "/agent.Agent/DeleteLabsChallenges"
+	Agent_StartLabsChallenges_FullMethodName   = "/agent.Agent/StartLabsChallenges"
+	Agent_StopLabsChallenges_FullMethodName    = "/agent.Agent/StopLabsChallenges"
+	Agent_ResetLabsChallenges_FullMethodName   = "/agent.Agent/ResetLabsChallenges"
+	Agent_CreateTemplate_FullMethodName        = "/agent.Agent/CreateTemplate"
+	Agent_ListTemplates_FullMethodName         = "/agent.Agent/ListTemplates"
+	Agent_DeleteTemplate_FullMethodName        = "/agent.Agent/DeleteTemplate"
+	Agent_CreateLabsGroup_FullMethodName       = "/agent.Agent/CreateLabsGroup"
+	Agent_UpdateLabsGroup_FullMethodName       = "/agent.Agent/UpdateLabsGroup"
+	Agent_GetLabsGroups_FullMethodName         = "/agent.Agent/GetLabsGroups"
+	Agent_DeleteLabsGroup_FullMethodName       = "/agent.Agent/DeleteLabsGroup"
+	Agent_AddCIDRPool_FullMethodName           = "/agent.Agent/AddCIDRPool"
+	Agent_GetCIDRPools_FullMethodName          = "/agent.Agent/GetCIDRPools"
+	Agent_GetCIDRPoolsUsage_FullMethodName     = "/agent.Agent/GetCIDRPoolsUsage"
 )
 
 // AgentClient is the client API for Agent service.
@@ -46,12 +68,37 @@ type AgentClient interface {
 	DeleteLabs(ctx context.Context, in *LabsRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	StopLabs(ctx context.Context, in *LabsRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	StartLabs(ctx context.Context, in *LabsRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	UpdateLabMetadata(ctx context.Context, in *UpdateLabMetadataRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	MoveLabs(ctx context.Context, in *MoveLabsRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	SetLabsIsolationMode(ctx context.Context, in *SetLabsIsolationModeRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	SetLabsEgressProfile(ctx context.Context, in *SetLabsEgressProfileRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	CheckLabsIPAM(ctx context.Context, in *CheckLabsIPAMRequest, opts ...grpc.CallOption) (*CheckLabsIPAMResponse, error)
+	AddLabVPNPeer(ctx context.Context, in *LabVPNPeerRequest, opts ...grpc.CallOption) (*LabVPNPeerResponse, error)
+	RevokeLabVPNPeer(ctx context.Context, in *LabVPNPeerRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AddLabSSHKey(ctx context.Context, in *LabSSHKeyRequest, opts ...grpc.CallOption) (*LabSSHKeyResponse, error)
+	RemoveLabSSHKey(ctx context.Context, in *LabSSHKeyRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	GetLabAttackBoxAccess(ctx context.Context, in *LabAttackBoxRequest, opts ...grpc.CallOption) (*LabAttackBoxAccessResponse, error)
+	StartLabsAttackBox(ctx context.Context, in *LabsRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	StopLabsAttackBox(ctx context.Context, in *LabsRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	// challenge
 	AddLabsChallenges(ctx context.Context, in *AddLabsChallengesRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	DeleteLabsChallenges(ctx context.Context, in *LabsChallengesRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	StartLabsChallenges(ctx context.Context, in *LabsChallengesRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	StopLabsChallenges(ctx context.Context, in *LabsChallengesRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	ResetLabsChallenges(ctx context.Context, in *LabsChallengesRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// template
+	CreateTemplate(ctx context.Context, in *CreateTemplateRequest, opts ...grpc.CallOption) (*CreateTemplateResponse, error)
+	ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error)
+	DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// labs group
+	CreateLabsGroup(ctx context.Context, in *LabsGroupRequest, opts ...grpc.CallOption) (*LabsGroupResponse, error)
+	UpdateLabsGroup(ctx context.Context, in *LabsGroupRequest, opts ...grpc.CallOption) (*LabsGroupResponse, error)
+	GetLabsGroups(ctx context.Context, in *GetLabsGroupsRequest, opts ...grpc.CallOption) (*GetLabsGroupsResponse, error)
+	DeleteLabsGroup(ctx context.Context, in *DeleteLabsGroupRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// cidr pool
+	AddCIDRPool(ctx context.Context, in *AddCIDRPoolRequest, opts ...grpc.CallOption) (*CIDRPoolResponse, error)
+	GetCIDRPools(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetCIDRPoolsResponse, error)
+	GetCIDRPoolsUsage(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetCIDRPoolsUsageResponse, error)
 }
 
 type agentClient struct {
@@ -154,6 +201,126 @@ func (c *agentClient) StartLabs(ctx context.Context, in *LabsRequest, opts ...gr
 	return out, nil
 }
 
+func (c *agentClient) UpdateLabMetadata(ctx context.Context, in *UpdateLabMetadataRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Agent_UpdateLabMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) MoveLabs(ctx context.Context, in *MoveLabsRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Agent_MoveLabs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) SetLabsIsolationMode(ctx context.Context, in *SetLabsIsolationModeRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Agent_SetLabsIsolationMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) SetLabsEgressProfile(ctx context.Context, in *SetLabsEgressProfileRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Agent_SetLabsEgressProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) CheckLabsIPAM(ctx context.Context, in *CheckLabsIPAMRequest, opts ...grpc.CallOption) (*CheckLabsIPAMResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckLabsIPAMResponse)
+	err := c.cc.Invoke(ctx, Agent_CheckLabsIPAM_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) AddLabVPNPeer(ctx context.Context, in *LabVPNPeerRequest, opts ...grpc.CallOption) (*LabVPNPeerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LabVPNPeerResponse)
+	err := c.cc.Invoke(ctx, Agent_AddLabVPNPeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) RevokeLabVPNPeer(ctx context.Context, in *LabVPNPeerRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Agent_RevokeLabVPNPeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) AddLabSSHKey(ctx context.Context, in *LabSSHKeyRequest, opts ...grpc.CallOption) (*LabSSHKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LabSSHKeyResponse)
+	err := c.cc.Invoke(ctx, Agent_AddLabSSHKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) RemoveLabSSHKey(ctx context.Context, in *LabSSHKeyRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Agent_RemoveLabSSHKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetLabAttackBoxAccess(ctx context.Context, in *LabAttackBoxRequest, opts ...grpc.CallOption) (*LabAttackBoxAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LabAttackBoxAccessResponse)
+	err := c.cc.Invoke(ctx, Agent_GetLabAttackBoxAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) StartLabsAttackBox(ctx context.Context, in *LabsRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Agent_StartLabsAttackBox_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) StopLabsAttackBox(ctx context.Context, in *LabsRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Agent_StopLabsAttackBox_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *agentClient) AddLabsChallenges(ctx context.Context, in *AddLabsChallengesRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(EmptyResponse)
@@ -204,6 +371,106 @@ func (c *agentClient) ResetLabsChallenges(ctx context.Context, in *LabsChallenge
 	return out, nil
 }
 
+func (c *agentClient) CreateTemplate(ctx context.Context, in *CreateTemplateRequest, opts ...grpc.CallOption) (*CreateTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTemplateResponse)
+	err := c.cc.Invoke(ctx, Agent_CreateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTemplatesResponse)
+	err := c.cc.Invoke(ctx, Agent_ListTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Agent_DeleteTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) CreateLabsGroup(ctx context.Context, in *LabsGroupRequest, opts ...grpc.CallOption) (*LabsGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LabsGroupResponse)
+	err := c.cc.Invoke(ctx, Agent_CreateLabsGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) UpdateLabsGroup(ctx context.Context, in *LabsGroupRequest, opts ...grpc.CallOption) (*LabsGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LabsGroupResponse)
+	err := c.cc.Invoke(ctx, Agent_UpdateLabsGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetLabsGroups(ctx context.Context, in *GetLabsGroupsRequest, opts ...grpc.CallOption) (*GetLabsGroupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLabsGroupsResponse)
+	err := c.cc.Invoke(ctx, Agent_GetLabsGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteLabsGroup(ctx context.Context, in *DeleteLabsGroupRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, Agent_DeleteLabsGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) AddCIDRPool(ctx context.Context, in *AddCIDRPoolRequest, opts ...grpc.CallOption) (*CIDRPoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CIDRPoolResponse)
+	err := c.cc.Invoke(ctx, Agent_AddCIDRPool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetCIDRPools(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetCIDRPoolsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCIDRPoolsResponse)
+	err := c.cc.Invoke(ctx, Agent_GetCIDRPools_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetCIDRPoolsUsage(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetCIDRPoolsUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCIDRPoolsUsageResponse)
+	err := c.cc.Invoke(ctx, Agent_GetCIDRPoolsUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServer is the server API for Agent service.
 // All implementations must embed UnimplementedAgentServer
 // for forward compatibility
@@ -217,12 +484,37 @@ type AgentServer interface {
 	DeleteLabs(context.Context, *LabsRequest) (*EmptyResponse, error)
 	StopLabs(context.Context, *LabsRequest) (*EmptyResponse, error)
 	StartLabs(context.Context, *LabsRequest) (*EmptyResponse, error)
+	UpdateLabMetadata(context.Context, *UpdateLabMetadataRequest) (*EmptyResponse, error)
+	MoveLabs(context.Context, *MoveLabsRequest) (*EmptyResponse, error)
+	SetLabsIsolationMode(context.Context, *SetLabsIsolationModeRequest) (*EmptyResponse, error)
+	SetLabsEgressProfile(context.Context, *SetLabsEgressProfileRequest) (*EmptyResponse, error)
+	CheckLabsIPAM(context.Context, *CheckLabsIPAMRequest) (*CheckLabsIPAMResponse, error)
+	AddLabVPNPeer(context.Context, *LabVPNPeerRequest) (*LabVPNPeerResponse, error)
+	RevokeLabVPNPeer(context.Context, *LabVPNPeerRequest) (*EmptyResponse, error)
+	AddLabSSHKey(context.Context, *LabSSHKeyRequest) (*LabSSHKeyResponse, error)
+	RemoveLabSSHKey(context.Context, *LabSSHKeyRequest) (*EmptyResponse, error)
+	GetLabAttackBoxAccess(context.Context, *LabAttackBoxRequest) (*LabAttackBoxAccessResponse, error)
+	StartLabsAttackBox(context.Context, *LabsRequest) (*EmptyResponse, error)
+	StopLabsAttackBox(context.Context, *LabsRequest) (*EmptyResponse, error)
 	// challenge
 	AddLabsChallenges(context.Context, *AddLabsChallengesRequest) (*EmptyResponse, error)
 	DeleteLabsChallenges(context.Context, *LabsChallengesRequest) (*EmptyResponse, error)
 	StartLabsChallenges(context.Context, *LabsChallengesRequest) (*EmptyResponse, error)
 	StopLabsChallenges(context.Context, *LabsChallengesRequest) (*EmptyResponse, error)
 	ResetLabsChallenges(context.Context, *LabsChallengesRequest) (*EmptyResponse, error)
+	// template
+	CreateTemplate(context.Context, *CreateTemplateRequest) (*CreateTemplateResponse, error)
+	ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error)
+	DeleteTemplate(context.Context, *DeleteTemplateRequest) (*EmptyResponse, error)
+	// labs group
+	CreateLabsGroup(context.Context, *LabsGroupRequest) (*LabsGroupResponse, error)
+	UpdateLabsGroup(context.Context, *LabsGroupRequest) (*LabsGroupResponse, error)
+	GetLabsGroups(context.Context, *GetLabsGroupsRequest) (*GetLabsGroupsResponse, error)
+	DeleteLabsGroup(context.Context, *DeleteLabsGroupRequest) (*EmptyResponse, error)
+	// cidr pool
+	AddCIDRPool(context.Context, *AddCIDRPoolRequest) (*CIDRPoolResponse, error)
+	GetCIDRPools(context.Context, *EmptyRequest) (*GetCIDRPoolsResponse, error)
+	GetCIDRPoolsUsage(context.Context, *EmptyRequest) (*GetCIDRPoolsUsageResponse, error)
 	mustEmbedUnimplementedAgentServer()
 }
 
@@ -251,6 +543,42 @@ func (UnimplementedAgentServer) StopLabs(context.Context, *LabsRequest) (*EmptyR
 func (UnimplementedAgentServer) StartLabs(context.Context, *LabsRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartLabs not implemented")
 }
+func (UnimplementedAgentServer) UpdateLabMetadata(context.Context, *UpdateLabMetadataRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLabMetadata not implemented")
+}
+func (UnimplementedAgentServer) MoveLabs(context.Context, *MoveLabsRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveLabs not implemented")
+}
+func (UnimplementedAgentServer) SetLabsIsolationMode(context.Context, *SetLabsIsolationModeRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLabsIsolationMode not implemented")
+}
+func (UnimplementedAgentServer) SetLabsEgressProfile(context.Context, *SetLabsEgressProfileRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLabsEgressProfile not implemented")
+}
+func (UnimplementedAgentServer) CheckLabsIPAM(context.Context, *CheckLabsIPAMRequest) (*CheckLabsIPAMResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckLabsIPAM not implemented")
+}
+func (UnimplementedAgentServer) AddLabVPNPeer(context.Context, *LabVPNPeerRequest) (*LabVPNPeerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddLabVPNPeer not implemented")
+}
+func (UnimplementedAgentServer) RevokeLabVPNPeer(context.Context, *LabVPNPeerRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeLabVPNPeer not implemented")
+}
+func (UnimplementedAgentServer) AddLabSSHKey(context.Context, *LabSSHKeyRequest) (*LabSSHKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddLabSSHKey not implemented")
+}
+func (UnimplementedAgentServer) RemoveLabSSHKey(context.Context, *LabSSHKeyRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveLabSSHKey not implemented")
+}
+func (UnimplementedAgentServer) GetLabAttackBoxAccess(context.Context, *LabAttackBoxRequest) (*LabAttackBoxAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLabAttackBoxAccess not implemented")
+}
+func (UnimplementedAgentServer) StartLabsAttackBox(context.Context, *LabsRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartLabsAttackBox not implemented")
+}
+func (UnimplementedAgentServer) StopLabsAttackBox(context.Context, *LabsRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopLabsAttackBox not implemented")
+}
 func (UnimplementedAgentServer) AddLabsChallenges(context.Context, *AddLabsChallengesRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddLabsChallenges not implemented")
 }
@@ -266,6 +594,36 @@ func (UnimplementedAgentServer) StopLabsChallenges(context.Context, *LabsChallen
 func (UnimplementedAgentServer) ResetLabsChallenges(context.Context, *LabsChallengesRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResetLabsChallenges not implemented")
 }
+func (UnimplementedAgentServer) CreateTemplate(context.Context, *CreateTemplateRequest) (*CreateTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTemplate not implemented")
+}
+func (UnimplementedAgentServer) ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTemplates not implemented")
+}
+func (UnimplementedAgentServer) DeleteTemplate(context.Context, *DeleteTemplateRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTemplate not implemented")
+}
+func (UnimplementedAgentServer) CreateLabsGroup(context.Context, *LabsGroupRequest) (*LabsGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLabsGroup not implemented")
+}
+func (UnimplementedAgentServer) UpdateLabsGroup(context.Context, *LabsGroupRequest) (*LabsGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLabsGroup not implemented")
+}
+func (UnimplementedAgentServer) GetLabsGroups(context.Context, *GetLabsGroupsRequest) (*GetLabsGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLabsGroups not implemented")
+}
+func (UnimplementedAgentServer) DeleteLabsGroup(context.Context, *DeleteLabsGroupRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLabsGroup not implemented")
+}
+func (UnimplementedAgentServer) AddCIDRPool(context.Context, *AddCIDRPoolRequest) (*CIDRPoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCIDRPool not implemented")
+}
+func (UnimplementedAgentServer) GetCIDRPools(context.Context, *EmptyRequest) (*GetCIDRPoolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCIDRPools not implemented")
+}
+func (UnimplementedAgentServer) GetCIDRPoolsUsage(context.Context, *EmptyRequest) (*GetCIDRPoolsUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCIDRPoolsUsage not implemented")
+}
 func (UnimplementedAgentServer) mustEmbedUnimplementedAgentServer() {}
 
 // UnsafeAgentServer may be embedded to opt out of forward compatibility for this service.
@@ -413,147 +771,631 @@ func _Agent_StartLabs_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_AddLabsChallenges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddLabsChallengesRequest)
+func _Agent_UpdateLabMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLabMetadataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).AddLabsChallenges(ctx, in)
+		return srv.(AgentServer).UpdateLabMetadata(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Agent_AddLabsChallenges_FullMethodName,
+		FullMethod: Agent_UpdateLabMetadata_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).AddLabsChallenges(ctx, req.(*AddLabsChallengesRequest))
+		return srv.(AgentServer).UpdateLabMetadata(ctx, req.(*UpdateLabMetadataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_DeleteLabsChallenges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LabsChallengesRequest)
+func _Agent_MoveLabs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveLabsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).DeleteLabsChallenges(ctx, in)
+		return srv.(AgentServer).MoveLabs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Agent_DeleteLabsChallenges_FullMethodName,
+		FullMethod: Agent_MoveLabs_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).DeleteLabsChallenges(ctx, req.(*LabsChallengesRequest))
+		return srv.(AgentServer).MoveLabs(ctx, req.(*MoveLabsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_StartLabsChallenges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LabsChallengesRequest)
+func _Agent_SetLabsIsolationMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLabsIsolationModeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).StartLabsChallenges(ctx, in)
+		return srv.(AgentServer).SetLabsIsolationMode(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Agent_StartLabsChallenges_FullMethodName,
+		FullMethod: Agent_SetLabsIsolationMode_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).StartLabsChallenges(ctx, req.(*LabsChallengesRequest))
+		return srv.(AgentServer).SetLabsIsolationMode(ctx, req.(*SetLabsIsolationModeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_StopLabsChallenges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LabsChallengesRequest)
+func _Agent_SetLabsEgressProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLabsEgressProfileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).StopLabsChallenges(ctx, in)
+		return srv.(AgentServer).SetLabsEgressProfile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Agent_StopLabsChallenges_FullMethodName,
+		FullMethod: Agent_SetLabsEgressProfile_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).StopLabsChallenges(ctx, req.(*LabsChallengesRequest))
+		return srv.(AgentServer).SetLabsEgressProfile(ctx, req.(*SetLabsEgressProfileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_ResetLabsChallenges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LabsChallengesRequest)
+func _Agent_CheckLabsIPAM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckLabsIPAMRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).ResetLabsChallenges(ctx, in)
+		return srv.(AgentServer).CheckLabsIPAM(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Agent_ResetLabsChallenges_FullMethodName,
+		FullMethod: Agent_CheckLabsIPAM_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).ResetLabsChallenges(ctx, req.(*LabsChallengesRequest))
+		return srv.(AgentServer).CheckLabsIPAM(ctx, req.(*CheckLabsIPAMRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// Agent_ServiceDesc is the grpc.ServiceDesc for Agent service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Agent_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "agent.Agent",
-	HandlerType: (*AgentServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Ping",
-			Handler:    _Agent_Ping_Handler,
-		},
-		{
-			MethodName: "GetLabs",
-			Handler:    _Agent_GetLabs_Handler,
-		},
-		{
-			MethodName: "CreateLabs",
-			Handler:    _Agent_CreateLabs_Handler,
-		},
-		{
-			MethodName: "DeleteLabs",
-			Handler:    _Agent_DeleteLabs_Handler,
-		},
-		{
-			MethodName: "StopLabs",
-			Handler:    _Agent_StopLabs_Handler,
-		},
-		{
-			MethodName: "StartLabs",
-			Handler:    _Agent_StartLabs_Handler,
-		},
-		{
-			MethodName: "AddLabsChallenges",
-			Handler:    _Agent_AddLabsChallenges_Handler,
-		},
-		{
-			MethodName: "DeleteLabsChallenges",
-			Handler:    _Agent_DeleteLabsChallenges_Handler,
-		},
-		{
-			MethodName: "StartLabsChallenges",
-			Handler:    _Agent_StartLabsChallenges_Handler,
-		},
-		{
-			MethodName: "StopLabsChallenges",
+func _Agent_AddLabVPNPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabVPNPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).AddLabVPNPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_AddLabVPNPeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).AddLabVPNPeer(ctx, req.(*LabVPNPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_RevokeLabVPNPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabVPNPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).RevokeLabVPNPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_RevokeLabVPNPeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).RevokeLabVPNPeer(ctx, req.(*LabVPNPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_AddLabSSHKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabSSHKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).AddLabSSHKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_AddLabSSHKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).AddLabSSHKey(ctx, req.(*LabSSHKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_RemoveLabSSHKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabSSHKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).RemoveLabSSHKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_RemoveLabSSHKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).RemoveLabSSHKey(ctx, req.(*LabSSHKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetLabAttackBoxAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabAttackBoxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetLabAttackBoxAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_GetLabAttackBoxAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetLabAttackBoxAccess(ctx, req.(*LabAttackBoxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_StartLabsAttackBox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).StartLabsAttackBox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_StartLabsAttackBox_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).StartLabsAttackBox(ctx, req.(*LabsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_StopLabsAttackBox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).StopLabsAttackBox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_StopLabsAttackBox_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).StopLabsAttackBox(ctx, req.(*LabsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_AddLabsChallenges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddLabsChallengesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).AddLabsChallenges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_AddLabsChallenges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).AddLabsChallenges(ctx, req.(*AddLabsChallengesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_DeleteLabsChallenges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabsChallengesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).DeleteLabsChallenges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_DeleteLabsChallenges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).DeleteLabsChallenges(ctx, req.(*LabsChallengesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_StartLabsChallenges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabsChallengesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).StartLabsChallenges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_StartLabsChallenges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).StartLabsChallenges(ctx, req.(*LabsChallengesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_StopLabsChallenges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabsChallengesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).StopLabsChallenges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_StopLabsChallenges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).StopLabsChallenges(ctx, req.(*LabsChallengesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ResetLabsChallenges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabsChallengesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ResetLabsChallenges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_ResetLabsChallenges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ResetLabsChallenges(ctx, req.(*LabsChallengesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_CreateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).CreateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_CreateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).CreateTemplate(ctx, req.(*CreateTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ListTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_ListTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ListTemplates(ctx, req.(*ListTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).DeleteTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_DeleteTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).DeleteTemplate(ctx, req.(*DeleteTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_CreateLabsGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabsGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).CreateLabsGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_CreateLabsGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).CreateLabsGroup(ctx, req.(*LabsGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_UpdateLabsGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabsGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).UpdateLabsGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_UpdateLabsGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).UpdateLabsGroup(ctx, req.(*LabsGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetLabsGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLabsGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetLabsGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_GetLabsGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetLabsGroups(ctx, req.(*GetLabsGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_DeleteLabsGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLabsGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).DeleteLabsGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_DeleteLabsGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).DeleteLabsGroup(ctx, req.(*DeleteLabsGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_AddCIDRPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCIDRPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).AddCIDRPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_AddCIDRPool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).AddCIDRPool(ctx, req.(*AddCIDRPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetCIDRPools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetCIDRPools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_GetCIDRPools_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetCIDRPools(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetCIDRPoolsUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetCIDRPoolsUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_GetCIDRPoolsUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetCIDRPoolsUsage(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Agent_ServiceDesc is the grpc.ServiceDesc for Agent service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Agent_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "agent.Agent",
+	HandlerType: (*AgentServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ping",
+			Handler:    _Agent_Ping_Handler,
+		},
+		{
+			MethodName: "GetLabs",
+			Handler:    _Agent_GetLabs_Handler,
+		},
+		{
+			MethodName: "CreateLabs",
+			Handler:    _Agent_CreateLabs_Handler,
+		},
+		{
+			MethodName: "DeleteLabs",
+			Handler:    _Agent_DeleteLabs_Handler,
+		},
+		{
+			MethodName: "StopLabs",
+			Handler:    _Agent_StopLabs_Handler,
+		},
+		{
+			MethodName: "StartLabs",
+			Handler:    _Agent_StartLabs_Handler,
+		},
+		{
+			MethodName: "UpdateLabMetadata",
+			Handler:    _Agent_UpdateLabMetadata_Handler,
+		},
+		{
+			MethodName: "MoveLabs",
+			Handler:    _Agent_MoveLabs_Handler,
+		},
+		{
+			MethodName: "SetLabsIsolationMode",
+			Handler:    _Agent_SetLabsIsolationMode_Handler,
+		},
+		{
+			MethodName: "SetLabsEgressProfile",
+			Handler:    _Agent_SetLabsEgressProfile_Handler,
+		},
+		{
+			MethodName: "CheckLabsIPAM",
+			Handler:    _Agent_CheckLabsIPAM_Handler,
+		},
+		{
+			MethodName: "AddLabVPNPeer",
+			Handler:    _Agent_AddLabVPNPeer_Handler,
+		},
+		{
+			MethodName: "RevokeLabVPNPeer",
+			Handler:    _Agent_RevokeLabVPNPeer_Handler,
+		},
+		{
+			MethodName: "AddLabSSHKey",
+			Handler:    _Agent_AddLabSSHKey_Handler,
+		},
+		{
+			MethodName: "RemoveLabSSHKey",
+			Handler:    _Agent_RemoveLabSSHKey_Handler,
+		},
+		{
+			MethodName: "GetLabAttackBoxAccess",
+			Handler:    _Agent_GetLabAttackBoxAccess_Handler,
+		},
+		{
+			MethodName: "StartLabsAttackBox",
+			Handler:    _Agent_StartLabsAttackBox_Handler,
+		},
+		{
+			MethodName: "StopLabsAttackBox",
+			Handler:    _Agent_StopLabsAttackBox_Handler,
+		},
+		{
+			MethodName: "AddLabsChallenges",
+			Handler:    _Agent_AddLabsChallenges_Handler,
+		},
+		{
+			MethodName: "DeleteLabsChallenges",
+			Handler:    _Agent_DeleteLabsChallenges_Handler,
+		},
+		{
+			MethodName: "StartLabsChallenges",
+			Handler:    _Agent_StartLabsChallenges_Handler,
+		},
+		{
+			MethodName: "StopLabsChallenges",
 			Handler:    _Agent_StopLabsChallenges_Handler,
 		},
 		{
 			MethodName: "ResetLabsChallenges",
 			Handler:    _Agent_ResetLabsChallenges_Handler,
 		},
+		{
+			MethodName: "CreateTemplate",
+			Handler:    _Agent_CreateTemplate_Handler,
+		},
+		{
+			MethodName: "ListTemplates",
+			Handler:    _Agent_ListTemplates_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _Agent_DeleteTemplate_Handler,
+		},
+		{
+			MethodName: "CreateLabsGroup",
+			Handler:    _Agent_CreateLabsGroup_Handler,
+		},
+		{
+			MethodName: "UpdateLabsGroup",
+			Handler:    _Agent_UpdateLabsGroup_Handler,
+		},
+		{
+			MethodName: "GetLabsGroups",
+			Handler:    _Agent_GetLabsGroups_Handler,
+		},
+		{
+			MethodName: "DeleteLabsGroup",
+			Handler:    _Agent_DeleteLabsGroup_Handler,
+		},
+		{
+			MethodName: "AddCIDRPool",
+			Handler:    _Agent_AddCIDRPool_Handler,
+		},
+		{
+			MethodName: "GetCIDRPools",
+			Handler:    _Agent_GetCIDRPools_Handler,
+		},
+		{
+			MethodName: "GetCIDRPoolsUsage",
+			Handler:    _Agent_GetCIDRPoolsUsage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{